@@ -0,0 +1,9 @@
+package abort
+
+const (
+	USAGE            = "abort"
+	SHORTDESCRIPTION = "Reverts the version deployed with --canary"
+	LONGDESCRIPTION  = "Removes the deployment and the resources created by 'azion deploy --local --canary', so that the current version receives all the traffic of the workload again"
+	FLAGHELP         = "Displays more information about the abort command"
+	CONFFLAG         = "Relative path to where your custom azion.json and args.json files are stored"
+)
@@ -8,4 +8,8 @@ var (
 	ErrorCreatingAzionJson   = errors.New("Failed to create azion.json file")
 	ErrorGeneratingManifest  = errors.New("Failed to generate manifest from azion.config")
 	ErrorAzionConfigNotFound = errors.New("azion.config file not found. Create an azion.config file to define your application configuration")
+	ErrorReadingPlan         = errors.New("Failed to read the plan file")
+	ErrorPlanVersion         = errors.New("The plan file was created by an unsupported version of the Azion CLI. Run 'azion config plan --save' again")
+	ErrorPlanManifestChanged = errors.New("The configuration changed since the plan was created. Run 'azion config plan --save' again")
+	ErrorPlanDrift           = "The remote state changed since the plan was created, nothing was applied. Run 'azion config plan --save' again. Changed resources: %s"
 )
@@ -13,4 +13,8 @@ const (
 	CreatingAzionJson   = "Creating azion.json file\n"
 	AzionConfigNotFound = "azion.config file not found. Please create an azion.config file to define your application configuration before running 'azion config apply'\n"
 	GeneratingManifest  = "Generating manifest from azion.config file\n"
+	FlagPlan            = "Path to a plan saved by 'azion config plan --save'. Only the changes of the plan are applied, and nothing is applied if the remote state changed since the plan was created"
+	PlanVerified        = "Remote state matches the plan found in %s\n"
+	DriftedResource     = "%s %s '%s'"
+	FlagNoRollback      = "Keep the resources created or updated so far when applying the configuration fails, instead of rolling them back. Useful for debugging"
 )
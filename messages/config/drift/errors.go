@@ -0,0 +1,10 @@
+package drift
+
+import "errors"
+
+var (
+	ErrorReadingManifest     = errors.New("Failed to read manifest.json file")
+	ErrorAzionConfigNotFound = errors.New("azion.config file not found. Create an azion.config file to define your application configuration")
+	ErrorAzionJsonNotFound   = errors.New("azion.json file not found. Nothing was deployed from this project yet, so there is no drift to detect")
+	ErrorDriftDetected       = errors.New("Drift detected between your configuration and Azion Platform")
+)
@@ -0,0 +1,13 @@
+package drift
+
+const (
+	Usage               = "drift"
+	ShortDescription    = "Detect changes made on Azion Platform to the resources of your configuration"
+	LongDescription     = "Fetch every resource tracked in azion.json and compare it with the configuration defined in azion.config file, listing the fields that were changed on Azion Platform, e.g. through Azion Console, and the resources that were deleted. Exits with an error when drift is found, so it can run periodically in CI"
+	FlagHelp            = "Displays more information about the drift command"
+	FlagConfigDir       = "Path to the configuration directory containing azion.json and azion.config (default: current directory)"
+	AzionConfigNotFound = "azion.config file not found. Please create an azion.config file to define your application configuration before running 'azion config drift'\n"
+	NoDrift             = "No drift detected. The resources on Azion Platform match your configuration\n"
+	DriftSummary        = "Drift detected: %d field(s) changed and %d resource(s) deleted on Azion Platform. Run 'azion config apply' to restore your configuration\n"
+	Deleted             = "(deleted)"
+)
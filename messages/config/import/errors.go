@@ -0,0 +1,11 @@
+package configimport
+
+import "errors"
+
+var (
+	ErrorNoResource        = errors.New("Inform the resources to import with --application-id, --workload-id or --firewall-id")
+	ErrorInvalidExtension  = errors.New("Invalid extension. Possible options: mjs, cjs, ts, js")
+	ErrorAzionConfigExists = errors.New("azion.config file already exists. Use --force to overwrite it with the imported resources")
+	ErrorProjectNotEmpty   = errors.New("azion.json already tracks resources of this project. Use --force to replace them with the imported resources")
+	ErrorWritingFunction   = "Failed to write the code of the function to %s: %w"
+)
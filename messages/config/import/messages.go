@@ -0,0 +1,16 @@
+package configimport
+
+const (
+	Usage            = "import"
+	ShortDescription = "Import resources created on Azion Platform into your project"
+	LongDescription  = "Fetch existing applications, workloads and firewalls from Azion Platform along with the resources they use, tracking their IDs in azion.json and generating an azion.config file that describes them, so that they can be managed through 'azion config apply' and 'azion deploy'. The code of each imported function is written to the functions directory"
+	FlagHelp         = "Displays more information about the import command"
+	FlagApplication  = "ID of an application to import. Can be informed more than once"
+	FlagWorkload     = "ID of a workload to import, along with the applications and firewalls it deploys"
+	FlagFirewall     = "ID of a firewall to import. Can be informed more than once"
+	FlagConfigDir    = "Path to the configuration directory where azion.json is written (default: current directory)"
+	FlagExtension    = "Extension used to generate the azion.config file. Possible options: mjs, cjs, ts, js"
+	FlagForce        = "Overwrite the azion.config file, azion.json and the function files of a project that already has them"
+	KeepingFunction  = "Function file %s already exists and was kept\n"
+	Imported         = "Resources imported successfully. Your configuration was written to %s\n"
+)
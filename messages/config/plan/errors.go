@@ -0,0 +1,9 @@
+package plan
+
+import "errors"
+
+var (
+	ErrorReadingManifest     = errors.New("Failed to read manifest.json file")
+	ErrorAzionConfigNotFound = errors.New("azion.config file not found. Create an azion.config file to define your application configuration")
+	ErrorSavingPlan          = errors.New("Failed to save the plan")
+)
@@ -0,0 +1,14 @@
+package plan
+
+const (
+	Usage               = "plan"
+	ShortDescription    = "Show the changes applying the configuration would make on Azion Platform"
+	LongDescription     = "Compare the resources defined in azion.config file with the ones found on Azion Platform and list the resources that would be created, updated or deleted by 'azion config apply'. Updates list the fields that differ, and resources that already match the configuration are left unchanged"
+	FlagHelp            = "Displays more information about the plan command"
+	FlagConfigDir       = "Path to the configuration directory containing azion.json and azion.config (default: current directory)"
+	FlagSave            = "Path to a file where the plan is saved, to be executed later with 'azion config apply --plan'"
+	AzionConfigNotFound = "azion.config file not found. Please create an azion.config file to define your application configuration before running 'azion config plan'\n"
+	NoChanges           = "No changes. The resources on Azion Platform match your configuration\n"
+	PlanSummary         = "Plan: %d to create, %d to update, %d to delete, %d unchanged\n"
+	PlanSaved           = "Plan saved to %s. Run 'azion config apply --plan %s' to execute it\n"
+)
@@ -0,0 +1,12 @@
+package unlock
+
+const (
+	Usage            = "unlock"
+	ShortDescription = "Remove the lock left on your project by an interrupted command"
+	LongDescription  = "Remove the lock file created next to azion.json by deploy, sync, config apply and config delete while they change your project. Locks left by processes no longer running on this machine are removed; use --force to remove a lock held by another machine or by a command still running"
+	FlagHelp         = "Displays more information about the unlock command"
+	FlagConfigDir    = "Path to the configuration directory containing azion.json (default: current directory)"
+	FlagForce        = "Remove the lock even if the command holding it may still be running"
+	NotLocked        = "The project is not locked\n"
+	Unlocked         = "Removed the lock held by '%s' (PID %d on %s, started at %s)\n"
+)
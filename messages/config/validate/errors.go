@@ -0,0 +1,9 @@
+package validate
+
+import "errors"
+
+var (
+	ErrorManifestNotFound = errors.New("manifest.json file not found. Please build your project with 'azion build' or inform the file with --manifest")
+	ErrorReadingFile      = "Failed to read the %s file: %w"
+	ErrorInvalid          = "Validation failed: %d problem(s) found"
+)
@@ -0,0 +1,13 @@
+package validate
+
+const (
+	Usage            = "validate"
+	ShortDescription = "Validate manifest.json and azion.json without reaching Azion Platform"
+	LongDescription  = "Check manifest.json and azion.json against the schemas shipped with the CLI and check that the resources referred to by name, such as the functions of function instances, the cache settings and connectors of rules and the applications and firewalls of workload deployments, are declared. Every problem is reported with its JSON pointer and line number"
+	FlagHelp         = "Displays more information about the validate command"
+	FlagConfigDir    = "Path to the configuration directory containing azion.json (default: current directory)"
+	FlagManifest     = "Path to the manifest.json file to validate (default: .edge/manifest.json)"
+	Valid            = "manifest.json and azion.json are valid\n"
+	ValidManifest    = "manifest.json is valid. azion.json was not found, so it was not validated\n"
+	ProblemsFound    = "Found %d problem(s)\n"
+)
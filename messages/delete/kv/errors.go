@@ -0,0 +1,5 @@
+package kv
+
+var (
+	ErrorDeleteNamespace = "Error while deleting the namespace: %s"
+)
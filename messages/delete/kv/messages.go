@@ -0,0 +1,11 @@
+package kv
+
+var (
+	Usage               = "kv"
+	ShortDescription    = "Deletes a namespace"
+	LongDescription     = "Deletes a KV namespace along with all of its keys"
+	FlagNamespace       = "Name of the namespace"
+	HelpFlag            = "Displays more information about the delete kv command"
+	AskInputNamespace   = "Enter the namespace:"
+	DeleteOutputSuccess = "Namespace '%s' deleted successfully"
+)
@@ -16,3 +16,25 @@ var (
 	ErrorUpdateDomain      = errors.New("Failed to update the Domain: %s. Check your settings and try again. If the error persists, contact Azion support")
 	ErrorInvalidToken      = errors.New("The configured token is invalid. You must create a new token and configure it to use with the CLI.")
 )
+
+var (
+	ErrorCanaryPercentage  = errors.New("Invalid --canary flag provided. The value must be the percentage of the traffic sent to the new version, between 1 and 99")
+	ErrorCanaryInProgress  = errors.New("A canary deployment is already in progress. Run the command 'azion promote' to finish it or 'azion abort' to revert it, and try again")
+	ErrorCanaryNotDeployed = errors.New("A canary deployment needs a previous version deployed to a workload deployment. Run the command 'azion deploy' without the flag '--canary' first")
+	ErrorNoCanary          = errors.New("There is no canary deployment in progress for this project")
+	ErrorCanaryUnsupported = errors.New("Canary deployments are not available: the strategy of workload deployments does not carry a share of the traffic, so the new version would receive all of it or none. Run the command again without the flag '--canary'")
+	ErrorCreateCanary      = "Failed to create the deployment of the new version: %w. Run the command 'azion abort' to remove the resources created for it"
+	ErrorRemoveCanary      = "Failed to remove the %s with ID %d of the canary deployment: %w. Run the command again to remove the remaining resources"
+)
+
+var (
+	ErrorHook              = "The %s hook '%s' failed: %w"
+	ErrorRollbackDeploy    = "%w. Rolling the deploy back also failed: %s. Run the command 'azion rollback' or 'azion abort' to restore the previous version"
+	ErrorNoPreviousVersion = errors.New("there is no previous version of the static files to roll back to")
+)
+
+var (
+	ErrorHealthCheck  = "The health check of %s failed after %d attempts: %w"
+	ErrorHealthStatus = "expected the status %d, found %d"
+	ErrorHealthBody   = "expected the response to contain '%s'"
+)
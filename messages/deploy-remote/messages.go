@@ -52,3 +52,30 @@ Create a Cache Settings configuration with the above specifications? (y/N)`
 	NameInUseApplication = "Application name is already in use. Trying to create Application with the following name: %s\n"
 	NameInUseDomain      = "Domain name is already in use. Trying to create Domain with the following name: %s\n"
 )
+
+var (
+	CanaryDeploying = "Deploying the new version next to the current one\n"
+	CanaryCreated   = "Created the deployment '%s' with ID %d, receiving %d%% of the traffic of the workload\n"
+	CanaryNext      = "Run the command 'azion promote' to send all the traffic to the new version or 'azion abort' to revert it\n"
+	CanaryPromoting = "Applying the new version to the current deployment\n"
+	CanaryPromoted  = "The new version now receives all the traffic of the workload\n"
+	CanaryAborted   = "The canary deployment was reverted and the previous version receives all the traffic of the workload\n"
+	CanaryRemoved   = "Removed the %s with ID %d of the canary deployment\n"
+)
+
+var (
+	DeployVersionsRemoved = "Removing %d versions older than the last %d from the bucket\n"
+	DeployPruneFailed     = "Failed to remove the old versions from the bucket: %s. The removal is retried on the next deploy\n"
+)
+
+var (
+	HookRunning               = "Running the %s hook: %s\n"
+	DeployRollingBack         = "Rolling the static files back to the version %s\n"
+	DeployRollingBackCanary   = "Removing the canary deployment\n"
+	DeployRollbackPurgeFailed = "Failed to purge the cache of the domains of the workload: %s. The previous version is served once the cache expires\n"
+)
+
+var (
+	HealthChecking = "Checking the health of %s\n"
+	HealthPassed   = "The health check of the deploy passed\n"
+)
@@ -19,6 +19,7 @@ var (
 	ErrorUnableSDKConfig   = "Unable to load SDK config, "
 	ErrorOpenFile          = "Failed to open file %s: %w"
 	ErrorUploadFileBucket  = "Failed to upload file to bucket %s: %w"
+	ErrorCopyFileBucket    = "Failed to copy object %s in bucket %s: %w"
 	ErrorGetFileInfo       = "Failed to get file info for %s: %v"
 	ErrorCreateZip         = "Failed to create zip file %s: %w"
 	ErrorAddFileZip        = "Failed to add file to zip %s: %w"
@@ -31,5 +32,21 @@ var (
 	ErrorCopyContentFile   = "Error copying contents of file %s to ZIP: %v"
 	ERRORMARSHALMANIFEST   = errors.New("Failed to marshal manifest structure.")
 	ERRORWRITEMANIFEST     = errors.New("Failed to write manifest.json file.")
+	ErrorNoUploadToResume  = errors.New("There is no interrupted upload to resume in this project. Run the command again without the flag '--resume'")
+	ErrorResumeRemote      = errors.New("The flag '--resume' is only available for local deploys. Run the command again with the flag '--local'")
+	ErrorCanaryRemote      = errors.New("The flag '--canary' is only available for local deploys. Run the command again with the flag '--local'")
+	ErrorListUploaded      = "Failed to verify the files uploaded before the interruption: %w"
 	ERRORCAPTURELOGS       = "Failed to capture deploy logs: %s. Please check your account to verify if the resources were successfully created."
+
+	ErrorCompressionEncoding = "Invalid compression '%s' for the storage of the directory '%s'. Use 'br' or 'gzip'"
+	ErrorUnknownEncoding     = "Unknown compression encoding '%s'"
+	ErrorCompressFile        = "Failed to compress file %s with %s: %w"
+	ErrorObjectPattern       = "Invalid pattern '%s' in the object settings of the storage: %w"
+	ErrorReadIgnoreFile      = "Failed to read the %s file: %w"
+)
+
+var (
+	ErrorKeepVersions       = errors.New("Invalid --keep-versions flag provided. The value must be a positive number of versions")
+	ErrorKeepVersionsRemote = errors.New("The flag '--keep-versions' is only available for local deploys. Run the command again with the flag '--local'")
+	ErrorHooksRemote        = errors.New("The 'post-build' hooks and the option 'rollback-on-failure' are only available for local deploys, as other deploys build the project on Azion Platform. Run the command again with the flag '--local'")
 )
@@ -28,6 +28,12 @@ var (
 	WritableBucketFlag                   = "If sent, the project bucket will be created with read-write access"
 	EnvFlag                              = "Relative path to where your custom .env file is stored"
 	WorkersFlag                          = "Number of concurrent upload workers (default: auto-calculated based on CPU cores, max 20)"
+	MultipartThresholdFlag               = "Size in MB from which static files are uploaded in resumable parts on local deploys. Use 0 to always upload files in a single request"
+	ListFilesFlag                        = "Lists the files that would be uploaded, following the .azionignore file and the include and exclude globs of the manifest, without deploying"
+	ResumeFlag                           = "Resumes the upload of static files interrupted in the last local deploy, keeping its version and uploading only the missing files"
+	CanaryFlag                           = "Deploys the new version next to the current one on local deploys, sending it the given percentage of the traffic of the workload until it is promoted with 'azion promote' or reverted with 'azion abort'"
+	KeepVersionsFlag                     = "Number of deployed versions kept in the bucket on local deploys, removing older ones after the deploy. Overrides the keep_versions of the storage in the manifest"
+	NoRollbackFlag                       = "Keeps the resources created or updated so far when a local deploy fails, instead of rolling them back. Useful for debugging"
 	OriginsSuccessful                    = "Created Origin for Application\n"
 	OriginsUpdateSuccessful              = "Updated Origin for Application %v with ID %v \n"
 	CacheSettingsSuccessful              = "Created Cache Settings for Application\n"
@@ -40,6 +46,11 @@ var (
 	DeployPropagation                    = "Your application is being deployed to all Azion Locations and it might take a few minutes.\n"
 	UploadStart                          = "Uploading source files\n"
 	UploadSuccessful                     = "\nUpload completed successfully!\n"
+	UploadIncremental                    = "%d file(s) uploaded, %d copied from the previous deploy and %d unchanged\n"
+	UploadResuming                       = "Resuming the interrupted upload of version %s\n"
+	UploadResumed                        = "%d file(s) were already uploaded before the interruption\n"
+	ListFilesEntry                       = "%s (%d bytes)\n"
+	ListFilesTotal                       = "%d file(s) would be uploaded\n"
 	BucketInUse                          = "This bucket's name is already in use, please try another one\n"
 	AppInUse                             = "This Application's name is already in use, please try another one\n"
 	DomainInUse                          = "This domain's name is already in use, please try another one\n"
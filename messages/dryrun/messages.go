@@ -18,6 +18,9 @@ var (
 	DeletingRuleEngine     = "Deleting Rule Engine with ID '%d', named '%s'"
 	DeletingOrigin         = "Deleting Origin with ID '%d' and Key '%s', named '%s'"
 	DeletingCacheSetting   = "Deleting Cache Setting with ID '%d', named '%s'"
+	CreateResource         = "Creating %s named '%s'\n"
+	UpdateResource         = "Updating %s with ID '%d', named '%s'\n"
+	DeleteResource         = "Deleting %s with ID '%d', named '%s'\n"
 	CreateRulesCache       = "Presenting the option to create Cache Setting (details below) and Rule Engine setting said Cache Setting\n"
 	AskCreateCacheSettings = `Cache Settings specifications:
   - Browser Cache Settings: Override Cache Settings
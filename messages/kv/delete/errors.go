@@ -0,0 +1,5 @@
+package delete
+
+var (
+	ErrorDeleteItem = "Failed to delete the key '%s': %w"
+)
@@ -0,0 +1,13 @@
+package delete
+
+const (
+	Usage             = "delete"
+	ShortDescription  = "Deletes a key"
+	LongDescription   = "Deletes a key and its value from a KV namespace"
+	FlagNamespace     = "Name of the namespace"
+	FlagKey           = "The key to be deleted"
+	FlagHelp          = "Displays more information about the kv delete command"
+	AskInputNamespace = "Enter the namespace:"
+	AskInputKey       = "Enter the key:"
+	OutputSuccess     = "Key '%s' deleted from the namespace '%s'\n"
+)
@@ -0,0 +1,15 @@
+package kv
+
+import "errors"
+
+var (
+	ErrorUnknownFormat    = "Could not tell the format of the file %s from its extension. Use the flag '--file-format' with json, ndjson or csv"
+	ErrorInvalidFormat    = "Invalid file format '%s'. The supported formats are json, ndjson and csv"
+	ErrorReadRecords      = "Failed to read the records of the file: %w"
+	ErrorJSONArray        = errors.New("Invalid JSON file. The file must contain an array of records with the fields key, value, expiration and metadata")
+	ErrorCSVColumn        = "Invalid CSV column '%s'. The supported columns are key, value, expiration and metadata"
+	ErrorCSVMissingColumn = "The CSV file is missing the column '%s'"
+	ErrorMissingKey       = errors.New("the record has no key")
+	ErrorExpiration       = "invalid expiration '%s', expected a Unix time in seconds"
+	ErrorMetadata         = "invalid metadata, expected a JSON object: %w"
+)
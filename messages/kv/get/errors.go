@@ -0,0 +1,5 @@
+package get
+
+var (
+	ErrorGetItem = "Failed to get the key '%s': %w"
+)
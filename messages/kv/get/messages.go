@@ -0,0 +1,13 @@
+package get
+
+const (
+	Usage             = "get"
+	ShortDescription  = "Returns the value of a key"
+	LongDescription   = "Returns the value stored under a key of a KV namespace. Use --details to display its expiration and metadata as well"
+	FlagNamespace     = "Name of the namespace"
+	FlagKey           = "The key to be read"
+	FlagDetails       = "Displays the expiration and metadata of the key along with its value"
+	FlagHelp          = "Displays more information about the kv get command"
+	AskInputNamespace = "Enter the namespace:"
+	AskInputKey       = "Enter the key:"
+)
@@ -0,0 +1,8 @@
+package kvexport
+
+var (
+	ErrorCreateFile = "Failed to create the file %s: %w"
+	ErrorListItems  = "Failed to list the keys of the namespace '%s': %w"
+	ErrorWriteFile  = "Failed to write the keys to the file: %w"
+	ErrorExport     = "Failed to export %d keys of the namespace. Run the command again to export them"
+)
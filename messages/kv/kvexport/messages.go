@@ -0,0 +1,16 @@
+package kvexport
+
+const (
+	Usage             = "export"
+	ShortDescription  = "Exports the keys of a namespace"
+	LongDescription   = "Writes the keys of a KV namespace, along with their values, expiration and metadata, to a JSON, NDJSON or CSV file. Without --file they are written to the standard output as NDJSON"
+	FlagNamespace     = "Name of the namespace"
+	FlagFile          = "Path to the file the keys are written to"
+	FlagFileFormat    = "Format of the file: json, ndjson or csv. By default it is told by the extension of the file"
+	FlagPrefix        = "Exports only the keys starting with the prefix"
+	FlagWorkers       = "Number of keys read at a time. By default it is calculated from the number of CPUs"
+	FlagHelp          = "Displays more information about the kv export command"
+	AskInputNamespace = "Enter the namespace:"
+	RecordFailed      = "Key '%s': %s\n"
+	OutputSuccess     = "Exported %d keys of the namespace '%s' to %s\n"
+)
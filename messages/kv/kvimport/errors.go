@@ -0,0 +1,6 @@
+package kvimport
+
+var (
+	ErrorOpenFile = "Failed to open the file %s: %w"
+	ErrorImport   = "Failed to import %d of the records of the file. Fix the records listed above and run the command again"
+)
@@ -0,0 +1,23 @@
+package kvimport
+
+const (
+	Usage             = "import"
+	ShortDescription  = "Imports keys from a file"
+	LongDescription   = "Writes the records of a JSON, NDJSON or CSV file to a KV namespace, several at a time. With --diff only the keys whose value, expiration or metadata differ from the namespace are written"
+	FlagNamespace     = "Name of the namespace"
+	FlagFile          = "Path to the file with the records to be imported"
+	FlagFileFormat    = "Format of the file: json, ndjson or csv. By default it is told by the extension of the file"
+	FlagWorkers       = "Number of keys written at a time. By default it is calculated from the number of CPUs"
+	FlagDryRun        = "Reports the keys that would be written, without writing them"
+	FlagDiff          = "Compares the records with the keys of the namespace and writes only the ones that changed"
+	FlagHelp          = "Displays more information about the kv import command"
+	AskInputNamespace = "Enter the namespace:"
+	AskInputFile      = "Enter the path to the file:"
+	RecordFailed      = "Line %d, key '%s': %s\n"
+	RecordPlanned     = "%s '%s'\n"
+	PlannedNew        = "new"
+	PlannedChanged    = "changed"
+	PlannedWrite      = "write"
+	OutputSuccess     = "Imported %d keys to the namespace '%s', %d unchanged and %d failed\n"
+	OutputDryRun      = "%d keys would be imported to the namespace '%s', %d unchanged and %d failed\n"
+)
@@ -0,0 +1,8 @@
+package list
+
+import "errors"
+
+var (
+	ErrorListItems = "Failed to list the keys of the namespace '%s': %w"
+	ErrorLimit     = errors.New("Invalid --limit flag provided. The value must be a positive number of keys")
+)
@@ -0,0 +1,15 @@
+package list
+
+const (
+	Usage             = "list"
+	ShortDescription  = "Lists the keys of a namespace"
+	LongDescription   = "Lists the keys of a KV namespace, page by page, optionally only the ones starting with a prefix"
+	FlagNamespace     = "Name of the namespace"
+	FlagPrefix        = "Lists only the keys starting with the prefix"
+	FlagLimit         = "Maximum number of keys returned in a page"
+	FlagCursor        = "Cursor returned by the previous page, to list the next one"
+	FlagDetails       = "Displays the expiration and metadata of the keys"
+	FlagHelp          = "Displays more information about the kv list command"
+	AskInputNamespace = "Enter the namespace:"
+	NextPage          = "\nMore keys are available. Run the command again with '--cursor %s' to list them\n"
+)
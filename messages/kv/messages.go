@@ -0,0 +1,8 @@
+package kv
+
+const (
+	Usage            = "kv"
+	ShortDescription = "Manage the keys of your KV namespaces"
+	LongDescription  = "Reads, writes, lists, deletes, imports and exports the keys stored in the namespaces of Azion KV"
+	FlagHelp         = "Displays more information about the kv command"
+)
@@ -0,0 +1,12 @@
+package put
+
+import "errors"
+
+var (
+	ErrorPutItem          = "Failed to put the key '%s': %w"
+	ErrorReadFile         = "Failed to read the value from the file %s: %w"
+	ErrorValueAndFile     = errors.New("The flags '--value' and '--file' cannot be used together. Send only one of them")
+	ErrorTTLAndExpiration = errors.New("The flags '--ttl' and '--expiration' cannot be used together. Send only one of them")
+	ErrorExpiration       = errors.New("Invalid expiration. The values of '--ttl' and '--expiration' must be positive numbers of seconds")
+	ErrorMetadata         = errors.New("Invalid metadata. The value of '--metadata' must be a JSON object")
+)
@@ -0,0 +1,19 @@
+package put
+
+const (
+	Usage             = "put"
+	ShortDescription  = "Sets the value of a key"
+	LongDescription   = "Creates or replaces a key of a KV namespace, optionally expiring it after a TTL or at a given time and attaching metadata to it"
+	FlagNamespace     = "Name of the namespace"
+	FlagKey           = "The key to be written"
+	FlagValue         = "The value of the key"
+	FlagFile          = "Path to a file whose content is used as the value of the key"
+	FlagTTL           = "Number of seconds after which the key expires"
+	FlagExpiration    = "Unix time, in seconds, at which the key expires"
+	FlagMetadata      = "JSON object stored as the metadata of the key"
+	FlagHelp          = "Displays more information about the kv put command"
+	AskInputNamespace = "Enter the namespace:"
+	AskInputKey       = "Enter the key:"
+	AskInputValue     = "Enter the value:"
+	OutputSuccess     = "Key '%s' written to the namespace '%s'\n"
+)
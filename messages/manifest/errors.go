@@ -24,5 +24,34 @@ var (
 	ErrorApplicationIDRequired  = errors.New("Application ID is required for this operation")
 	ErrorWorkloadIDRequired     = errors.New("Workload ID is required for this operation")
 	ErrorConnectorTypeNotFound  = errors.New("Failed to determine connector type")
+	ErrorSeedKV                 = "Failed to write the data file %s to the KV Namespace %s: %w"
+	ErrorSeedKVRecord           = "line %d, key '%s': %w"
 	ErrorReadManifest           = "Failed to read the manifest.json file: %w. Please remember to install dependencies and build your project before running the deploy command"
 )
+
+var (
+	ErrorDuplicateApplication      = "The Application name '%s' is declared more than once in your azion.config file. Please make sure each Application has a unique name"
+	ErrorDuplicateFunctionInstance = "The Function Instance name '%s' is declared more than once in your azion.config file. Please make sure each Function Instance has a unique name across all Applications"
+)
+
+var (
+	ErrorUnknownDependency = "The resource group '%s' depends on '%s', which is not part of the manifest"
+	ErrorDependencyCycle   = "Failed to apply the manifest: the following resource groups depend on each other: %s"
+	ErrorRollback          = "%w. The following changes could not be rolled back, please review them on Azion Console: %s"
+)
+
+var (
+	ErrorReferenceFunction         = "Function '%s' is not declared in functions"
+	ErrorReferenceFunctionInstance = "Function Instance '%s' is not declared in the functions_instances of %s"
+	ErrorReferenceCacheSetting     = "Cache Setting '%s' is not declared in the cache_settings of Application '%s'"
+	ErrorReferenceConnector        = "Connector '%s' is not declared in connectors"
+	ErrorReferenceApplication      = "Application '%s' is not declared in applications"
+	ErrorReferenceFirewall         = "Firewall '%s' is not declared in firewall"
+	ErrorReferenceKVNamespace      = "KV Namespace '%s' is not declared in kv"
+	ErrorDuplicateName             = "%s name '%s' is already declared at %s"
+)
+
+var (
+	ErrorImportResource = "Failed to import %s with id %d: %w"
+	ErrorImportRule     = "Failed to import the rule '%s': %w"
+)
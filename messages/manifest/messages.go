@@ -41,9 +41,27 @@ const (
 	ManifestUpdateWorkloadDeployment       = "Workload Deployment %s with id %d successfully updated\n"
 	ManifestCreateStorage                  = "Storage Bucket %s successfully created\n"
 	ManifestUpdateStorage                  = "Storage Bucket %s successfully updated\n"
+	ManifestCreateKV                       = "KV Namespace %s successfully created\n"
+	ManifestExistingKV                     = "KV Namespace %s already exists\n"
+	ManifestSeedKV                         = "%d keys of %s written to the KV Namespace %s\n"
 	ManifestPurgeSuccess                   = "Purge of type %s successfully executed\n"
+	ManifestDeleteFunction                 = "Function %s with id %d successfully deleted\n"
+	ManifestDeleteFunctionInstance         = "Function Instance %s with id %d successfully deleted\n"
+	ManifestDeleteEdgeApplication          = "Edge Application %s with id %d successfully deleted\n"
+	ManifestDeleteConnector                = "Connector %s with id %d successfully deleted\n"
+	ManifestDeleteWorkloadDeployment       = "Workload Deployment %s with id %d successfully deleted\n"
+	ManifestDeleteFirewall                 = "Firewall %s with id %d successfully deleted\n"
+	ManifestDeleteFirewallRule             = "Firewall Rule %s with id %d successfully deleted\n"
+	ManifestDeleteFirewallFunctionInstance = "Firewall Function Instance %s with id %d successfully deleted\n"
 	MessageDeleteResource                  = `It seems this resource was deleted from a previous version of the application.
 One cause may be that the resource is not being used in any rule.
 To avoid deleting resources that are not being used, you can add the field 'skip-deletion' to your azion.json file.`
 	UpdateAzionConfig = "Updating azion.config file with new resource name\n"
+
+	RollingBack     = "Failed to apply the manifest, rolling back the changes made so far\n"
+	RollbackDelete  = "Rollback: %s %s with id %d deleted\n"
+	RollbackRestore = "Rollback: %s %s with id %d restored\n"
+	RollbackDone    = "Every change was rolled back and azion.json was restored\n"
+
+	ManifestImportResource = "%s %s with id %d successfully imported\n"
 )
@@ -0,0 +1,9 @@
+package promote
+
+const (
+	USAGE            = "promote"
+	SHORTDESCRIPTION = "Sends all the traffic to the version deployed with --canary"
+	LONGDESCRIPTION  = "Applies the version deployed with 'azion deploy --local --canary' to the resources of the current version, so that it receives all the traffic of the workload, and removes the resources created for the canary deployment"
+	FLAGHELP         = "Displays more information about the promote command"
+	CONFFLAG         = "Relative path to where your custom azion.json and args.json files are stored"
+)
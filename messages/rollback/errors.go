@@ -9,3 +9,8 @@ var (
 	ERRORNOPREVIOUS         = errors.New("No previous deployment found to roll back to. You need at least two deployments to use the rollback command")
 	ERRORCONVERTCONNECTORID = errors.New("Invalid --connector-id flag provided. The value must be an integer. Run the command 'azion rollback --help' to display more information and try again")
 )
+
+var (
+	ERRORVERSIONNOTFOUND = "The version %s was not found in the bucket %s. Run the command 'azion versions list' to list the versions of the project"
+	ERRORCURRENTVERSION  = "The version %s is already the current version of the project"
+)
@@ -3,10 +3,14 @@ package rollback
 const (
 	USAGE            = "rollback"
 	SHORTDESCRIPTION = "Sets static files from a previous deploy"
-	LONGDESCRIPTION  = "Sets static files from a previous deploy within the same bucket"
+	LONGDESCRIPTION  = "Sets static files from a previous deploy within the same bucket. By default, the version deployed right before the current one is used"
 	FLAGHELP         = "Displays more information about the rollback command"
-	FLAGCONNECTORID  = "Connector ID of the storage connector used for static files"
+	FLAGCONNECTORID  = "Connector ID of the storage connector used for static files. By default, the storage connectors of the project bucket in azion.json are used"
+	FLAGTO           = "Timestamp of the version to roll back to. Run the command 'azion versions list' to list the versions of the project"
 	CONFFLAG         = "Relative path to where your custom azion.json and args.json files are stored"
 	ASKCONNECTOR     = "Enter the ID of the Connector you wish to update:"
 	SUCCESS          = "Static files rolled back successfully"
+	ROLLEDBACK       = "Connector %d now serves the version %s\n"
+	PURGED           = "Purged the cache of the domain %s\n"
+	PURGEFAILED      = "Failed to purge the cache of the domain %s: %s. Run the command 'azion purge --wildcard' to serve the version right away\n"
 )
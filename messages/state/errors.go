@@ -0,0 +1,19 @@
+package state
+
+import "errors"
+
+var (
+	ErrorStateConflict       = errors.New("The state of this project kept in Azion Storage was changed by someone else since it was read. Run the command again to work on the latest state")
+	ErrorStateBucketRequired = errors.New("The 'bucket' of the state settings in the azion.json file is required by the storage backend")
+	ErrorUnknownBackend      = "Unknown state backend '%s'. Use 'local' or 'storage'"
+	ErrorReadingState        = "Failed to read the state of the project from Azion Storage: %w"
+	ErrorWritingState        = "Failed to write the state of the project to Azion Storage: %w"
+)
+
+var (
+	ErrorLocked         = "The project is locked by '%s' (PID %d on %s, started at %s). Wait for it to finish or, if it is no longer running, run 'azion config unlock --force'"
+	ErrorLockContention = errors.New("Failed to lock the project, as other commands keep locking it. Try again in a moment")
+	ErrorCreatingLock   = "Failed to create the lock file of the project: %w"
+	ErrorReadingLock    = "Failed to read the lock file of the project: %w"
+	ErrorRemovingLock   = "Failed to remove the lock file of the project: %w"
+)
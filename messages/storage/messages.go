@@ -15,6 +15,8 @@ const (
 	LONG_DESCRIPTION_DELETE          = "Allows users to delete a bucket or object in Storage"
 	LONG_DESCRIPTION_UPDATE          = "Allows users to update a bucket or object in Storage"
 	LONG_DESCRIPTION_DESCRIBE        = "Allows users to describe a bucket or object in Storage"
+	SHORT_DESCRIPTION_STORAGE        = "Transfers files between your machine and Storage"
+	LONG_DESCRIPTION_STORAGE         = "Allows users to copy whole directories between their machine and the buckets of Storage"
 	SHORT_DESCRIPTION_CREATE_BUCKET  = "Creates a bucket in Storage"
 	SHORT_DESCRIPTION_LIST_BUCKET    = "List the buckets in Storage"
 	SHORT_DESCRIPTION_LIST_OBJECT    = "List the objects in Storage"
@@ -30,6 +32,7 @@ const (
 	LONG_DESCRIPTION_DELETE_OBJECTS = "Allows users to delete their objects in Storage"
 
 	EXAMPLE_CREATE          = "$ azion create storage\n$ azion create storage --help"
+	EXAMPLE_STORAGE         = "$ azion storage sync ./public my-bucket/assets\n$ azion storage sync my-bucket/assets ./public --delete\n$ azion storage download --bucket my-bucket --prefix logs/ --dest ./out\n$ azion storage --help"
 	EXAMPLE_CREATE_BUCKET   = "$ azion create storage bucket --name 'zorosola' --workloads-access 'read_only'\n$ azion create storage bucket --help"
 	EXAMPLE_UPDATE_BUCKET   = "$ azion update storage bucket --name 'zorosola' --workloads-access 'read_only'\n$ azion update storage bucket --help"
 	EXAMPLE_LIST            = "$ azion list storage bucket\n$ azion list storage object --bucket-name mybucket\n$ azion list storage --help"
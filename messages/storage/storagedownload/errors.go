@@ -0,0 +1,9 @@
+package storagedownload
+
+var (
+	ErrorNotDirectory = "'%s' is not a directory"
+	ErrorListObjects  = "Failed to list the objects of the bucket '%s': %w"
+	ErrorUnsafeKey    = "the key is not a relative path inside the destination directory"
+	ErrorSize         = "received %d bytes instead of %d. The object may have been replaced while it was downloaded"
+	ErrorDownload     = "Failed to download %d objects. Run the command again to resume them"
+)
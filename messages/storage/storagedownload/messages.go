@@ -0,0 +1,15 @@
+package storagedownload
+
+const (
+	Usage            = "download [flags]"
+	ShortDescription = "Downloads the objects of a bucket to a local directory"
+	LongDescription  = "Downloads every object of a bucket whose key starts with the prefix, several at a time, keeping the hierarchy of their keys under the destination directory. Objects are written to disk as they are received, and the ones interrupted are resumed by running the command again; objects already downloaded, with the same size and modification time, are skipped"
+	FlagBucket       = "The name of the Storage bucket"
+	FlagPrefix       = "Downloads only the objects whose key starts with the prefix"
+	FlagDest         = "Directory where the objects are written"
+	FlagWorkers      = "Number of objects downloaded at a time. By default it is calculated from the number of CPUs"
+	FlagHelp         = "Displays more information about the storage download command"
+	AskInputBucket   = "Enter the name of the bucket: "
+	ObjectFailed     = "Failed to download '%s': %s\n"
+	OutputSuccess    = "Downloaded %d objects to %s and skipped %d already downloaded\n"
+)
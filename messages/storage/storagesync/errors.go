@@ -0,0 +1,11 @@
+package storagesync
+
+var (
+	ErrorRemote         = "Failed to find the bucket of '%s'. Inform it as <bucket> or <bucket>/<prefix>"
+	ErrorNotDirectory   = "'%s' is not a directory"
+	ErrorReadIgnoreFile = "Failed to read the %s file: %w"
+	ErrorListFiles      = "Failed to list the files of '%s': %w"
+	ErrorListObjects    = "Failed to list the objects of the bucket '%s': %w"
+	ErrorSync           = "Failed to sync %d files. Run the command again to retry them"
+	ErrorUnsafeKey      = "the key is not a relative path inside the destination directory"
+)
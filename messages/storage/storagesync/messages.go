@@ -0,0 +1,20 @@
+package storagesync
+
+const (
+	Usage            = "sync <source> <destination>"
+	ShortDescription = "Synchronizes a local directory with a bucket"
+	LongDescription  = "Copies the files that differ between a local directory and a prefix of a bucket, several at a time. When the source is a local directory its files are uploaded to <bucket>/<prefix>, otherwise the objects of <bucket>/<prefix> are downloaded to the destination directory. Files of the same size are compared by content, using the ETag of the object, which is read with the S3 credentials of the bucket and creates them the first time the bucket is synced. Objects uploaded in parts, or whose ETag cannot be read, are compared by modification time instead, and transferred only when the source is newer than its copy. The files listed in the .azionignore file of the local directory are never synced"
+	FlagDelete       = "Deletes the files of the destination that are not found in the source"
+	FlagDryRun       = "Lists the files that would be transferred or deleted, without changing them"
+	FlagInclude      = "Syncs only the files matching the glob, in the syntax of .gitignore. Can be repeated"
+	FlagExclude      = "Never syncs the files matching the glob, in the syntax of .gitignore. Can be repeated"
+	FlagWorkers      = "Number of files transferred at a time. By default it is calculated from the number of CPUs"
+	FlagHelp         = "Displays more information about the storage sync command"
+	PlannedUpload    = "upload %s\n"
+	PlannedDownload  = "download %s\n"
+	PlannedDelete    = "delete %s\n"
+	OperationFailed  = "Failed to %s '%s': %s\n"
+	OutputUpload     = "Uploaded %d files to %s, deleted %d and left %d unchanged\n"
+	OutputDownload   = "Downloaded %d files to %s, deleted %d and left %d unchanged\n"
+	OutputDryRun     = "%d files would be transferred and %d deleted, %d unchanged\n"
+)
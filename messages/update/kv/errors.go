@@ -0,0 +1,8 @@
+package kv
+
+import "errors"
+
+var (
+	ErrorUpdateNamespace = "Error while updating the namespace: %s"
+	ErrorNoChanges       = errors.New("No changes were sent. Use the flag '--name' to rename the namespace")
+)
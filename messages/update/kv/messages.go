@@ -0,0 +1,12 @@
+package kv
+
+var (
+	Usage               = "kv"
+	ShortDescription    = "Updates a namespace"
+	LongDescription     = "Updates a KV namespace based on given values"
+	FlagNamespace       = "Name of the namespace to be updated"
+	FlagName            = "The new name of the namespace"
+	HelpFlag            = "Displays more information about the update kv command"
+	AskInputNamespace   = "Enter the namespace:"
+	UpdateOutputSuccess = "Namespace '%s' updated successfully"
+)
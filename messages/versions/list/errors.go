@@ -0,0 +1,8 @@
+package list
+
+import "errors"
+
+var (
+	ErrorNeedsDeploy  = errors.New("You cannot list the versions of a project that was not deployed yet. Please check if you are in the correct working directory")
+	ErrorListVersions = "Failed to list the versions of the bucket %s: %w"
+)
@@ -0,0 +1,11 @@
+package list
+
+const (
+	Usage            = "list"
+	ShortDescription = "List the versions of your project"
+	LongDescription  = "List every version of your project kept in its bucket, with its number of files and size, and which one is served by the storage connectors"
+	FlagHelp         = "Displays more information about the versions list command"
+	FlagConfigDir    = "Relative path to where your custom azion.json and args.json files are stored"
+	StatusLive       = "live"
+	StatusCanary     = "canary (%d%%)"
+)
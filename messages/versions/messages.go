@@ -0,0 +1,8 @@
+package versions
+
+const (
+	Usage            = "versions"
+	ShortDescription = "Manage the versions of your project"
+	LongDescription  = "Manage the versions of your project, kept in its bucket under the timestamp prefix of each deploy"
+	FlagHelp         = "Displays more information about the versions command"
+)
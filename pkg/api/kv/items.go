@@ -0,0 +1,124 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/utils"
+	sdk "github.com/aziontech/azionapi-v4-go-sdk-dev/kv-api"
+	"go.uber.org/zap"
+)
+
+func itemFrom(item *sdk.Item) Item {
+	return Item{
+		Key:        item.GetKey(),
+		Value:      item.GetValue(),
+		Expiration: item.GetExpiration(),
+		Metadata:   item.GetMetadata(),
+	}
+}
+
+func (c *Client) ListItems(ctx context.Context, namespace string, opts ListItemsOptions) (*ItemPage, error) {
+	logger.Debug("List items", zap.String("namespace", namespace))
+	request := c.apiClient.KVItemsAPI.ListItems(ctx, namespace)
+	if opts.Prefix != "" {
+		request = request.Prefix(opts.Prefix)
+	}
+	if opts.Limit > 0 {
+		request = request.Limit(opts.Limit)
+	}
+	if opts.Cursor != "" {
+		request = request.Cursor(opts.Cursor)
+	}
+
+	resp, httpResp, err := request.Execute()
+	if err != nil {
+		errBody := ""
+		if httpResp != nil {
+			logger.Debug("Error while listing the items of a namespace", zap.Error(err))
+			errBody, err = utils.LogAndRewindBodyV4(httpResp)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, utils.ErrorPerStatusCodeV4(errBody, httpResp, err)
+	}
+
+	page := &ItemPage{Items: []Item{}, Cursor: resp.GetCursor()}
+	for _, item := range resp.GetResults() {
+		page.Items = append(page.Items, itemFrom(&item))
+	}
+	return page, nil
+}
+
+func (c *Client) GetItem(ctx context.Context, namespace, key string) (*Item, error) {
+	logger.Debug("Retrieve item", zap.String("namespace", namespace), zap.String("key", key))
+	request := c.apiClient.KVItemsAPI.RetrieveItem(ctx, namespace, key)
+
+	res, httpResp, err := request.Execute()
+	if err != nil {
+		errBody := ""
+		if httpResp != nil {
+			logger.Debug("Error while retrieving an item", zap.Error(err))
+			errBody, err = utils.LogAndRewindBodyV4(httpResp)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, utils.ErrorPerStatusCodeV4(errBody, httpResp, err)
+	}
+
+	item := itemFrom(res)
+	return &item, nil
+}
+
+func (c *Client) PutItem(ctx context.Context, namespace, key string, req PutItemRequest) error {
+	logger.Debug("Put item", zap.String("namespace", namespace), zap.String("key", key))
+
+	// the request of the SDK is built from the JSON of the one of the CLI, which carries the same fields
+	var body sdk.ItemRequest
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+
+	request := c.apiClient.KVItemsAPI.UpsertItem(ctx, namespace, key).ItemRequest(body)
+	_, httpResp, err := request.Execute()
+	if err != nil {
+		errBody := ""
+		if httpResp != nil {
+			logger.Debug("Error while putting an item", zap.Error(err))
+			errBody, err = utils.LogAndRewindBodyV4(httpResp)
+			if err != nil {
+				return err
+			}
+		}
+		return utils.ErrorPerStatusCodeV4(errBody, httpResp, err)
+	}
+
+	return nil
+}
+
+func (c *Client) DeleteItem(ctx context.Context, namespace, key string) error {
+	logger.Debug("Delete item", zap.String("namespace", namespace), zap.String("key", key))
+	request := c.apiClient.KVItemsAPI.DeleteItem(ctx, namespace, key)
+
+	httpResp, err := request.Execute()
+	if err != nil {
+		errBody := ""
+		if httpResp != nil {
+			logger.Debug("Error while deleting an item", zap.Error(err))
+			errBody, err = utils.LogAndRewindBodyV4(httpResp)
+			if err != nil {
+				return err
+			}
+		}
+		return utils.ErrorPerStatusCodeV4(errBody, httpResp, err)
+	}
+
+	return nil
+}
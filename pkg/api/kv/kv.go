@@ -75,3 +75,43 @@ func (c *Client) Get(ctx context.Context, namespace string) (*sdk.Namespace, err
 
 	return res, nil
 }
+
+func (c *Client) Update(ctx context.Context, namespace string, req UpdateRequest) (*sdk.Namespace, error) {
+	logger.Debug("Update namespace")
+	request := c.apiClient.KVNamespacesAPI.UpdateNamespace(ctx, namespace).NamespaceUpdateRequest(req.NamespaceUpdateRequest)
+
+	res, httpResp, err := request.Execute()
+	if err != nil {
+		errBody := ""
+		if httpResp != nil {
+			logger.Debug("Error while updating a namespace", zap.Error(err))
+			errBody, err = utils.LogAndRewindBodyV4(httpResp)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, utils.ErrorPerStatusCodeV4(errBody, httpResp, err)
+	}
+
+	return res, nil
+}
+
+func (c *Client) Delete(ctx context.Context, namespace string) error {
+	logger.Debug("Delete namespace")
+	request := c.apiClient.KVNamespacesAPI.DeleteNamespace(ctx, namespace)
+
+	httpResp, err := request.Execute()
+	if err != nil {
+		errBody := ""
+		if httpResp != nil {
+			logger.Debug("Error while deleting a namespace", zap.Error(err))
+			errBody, err = utils.LogAndRewindBodyV4(httpResp)
+			if err != nil {
+				return err
+			}
+		}
+		return utils.ErrorPerStatusCodeV4(errBody, httpResp, err)
+	}
+
+	return nil
+}
@@ -7,3 +7,36 @@ import (
 type CreateRequest struct {
 	sdk.NamespaceCreateRequest
 }
+
+type UpdateRequest struct {
+	sdk.NamespaceUpdateRequest
+}
+
+// Item is a key of a namespace along with its value
+type Item struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+	// Expiration is the Unix time, in seconds, after which the key is removed
+	Expiration int64                  `json:"expiration,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// ItemPage is a page of the keys of a namespace. Cursor is empty on the last page.
+type ItemPage struct {
+	Items  []Item `json:"items"`
+	Cursor string `json:"cursor,omitempty"`
+}
+
+type ListItemsOptions struct {
+	Prefix string
+	Limit  int64
+	Cursor string
+}
+
+// PutItemRequest sets the value of a key. Expiration and ExpirationTTL are exclusive, and zero keeps the key forever.
+type PutItemRequest struct {
+	Value         string                 `json:"value"`
+	Expiration    int64                  `json:"expiration,omitempty"`
+	ExpirationTTL int64                  `json:"expiration_ttl,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+}
@@ -0,0 +1,289 @@
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	msg "github.com/aziontech/azion-cli/messages/deploy"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/workers"
+	"go.uber.org/zap"
+)
+
+const (
+	// MinPartSize is the smallest part accepted by S3, except for the last one
+	MinPartSize int64 = 5 * 1024 * 1024
+	// DefaultPartSize is the size of each part sent by UploadMultipart
+	DefaultPartSize int64 = 16 * 1024 * 1024
+	// DefaultMultipartThreshold is the size from which files are uploaded in parts
+	DefaultMultipartThreshold int64 = 100 * 1024 * 1024
+	// MultipartStateDir keeps the progress of multipart uploads, so that they resume where they stopped
+	MultipartStateDir = ".edge/uploads"
+
+	maxParts = 10000
+)
+
+// MultipartOptions sets when and how files are uploaded in parts
+type MultipartOptions struct {
+	// Threshold is the size from which files are uploaded in parts. Zero or less disables multipart uploads.
+	Threshold int64
+	PartSize  int64
+	StateDir  string
+}
+
+// Enabled reports whether a file of the given size is uploaded in parts
+func (opts MultipartOptions) Enabled(size int64) bool {
+	return opts.Threshold > 0 && size >= opts.Threshold
+}
+
+// MultipartState is the progress of a multipart upload. It is written after every part sent, and the source file
+// it refers to is identified by its size and modification time, so a changed file starts a new upload. Size is the
+// size of the content uploaded, which differs from the source for compressed variants.
+type MultipartState struct {
+	Bucket     string          `json:"bucket"`
+	Key        string          `json:"key"`
+	UploadID   string          `json:"upload_id"`
+	Size       int64           `json:"size"`
+	SourceSize int64           `json:"source_size"`
+	ModTime    time.Time       `json:"mod_time"`
+	PartSize   int64           `json:"part_size"`
+	Parts      []CompletedPart `json:"parts"`
+}
+
+// CompletedPart is a part already stored by the multipart upload
+type CompletedPart struct {
+	PartNumber int32  `json:"part_number"`
+	ETag       string `json:"etag"`
+}
+
+// multipartClient is the part of the S3 client used by multipart uploads
+type multipartClient interface {
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+	ListParts(ctx context.Context, params *s3.ListPartsInput, optFns ...func(*s3.Options)) (*s3.ListPartsOutput, error)
+}
+
+// UploadMultipart uploads a large file in parts, retrying each part on its own. The progress is kept under
+// opts.StateDir, so an upload interrupted by a failure or by the user resumes from the parts already stored.
+// source is the file the content was read from; for compressed variants, whose content is a new temporary file on
+// every run, it is the uncompressed file. The content itself is used when source is nil.
+func UploadMultipart(ctx context.Context, cfg aws.Config, fileOps *contracts.FileOps, source os.FileInfo, bucketName, prefix string,
+	opts MultipartOptions) error {
+	return uploadMultipart(ctx, s3.NewFromConfig(cfg), fileOps, source, bucketName, prefix, opts)
+}
+
+func uploadMultipart(ctx context.Context, client multipartClient, fileOps *contracts.FileOps, source os.FileInfo, bucketName, prefix string,
+	opts MultipartOptions) error {
+	key := fileOps.Path
+	if prefix != "" {
+		key = path.Join(prefix, fileOps.Path)
+	}
+
+	info, err := fileOps.FileContent.Stat()
+	if err != nil {
+		return err
+	}
+	if source == nil {
+		source = info
+	}
+	partSize := opts.PartSize
+	if partSize < MinPartSize {
+		partSize = MinPartSize
+	}
+	// S3 accepts up to 10000 parts, larger files are sent in larger parts
+	if info.Size() > partSize*maxParts {
+		partSize = (info.Size() + maxParts - 1) / maxParts
+	}
+
+	statePath := multipartStatePath(opts.StateDir, bucketName, key)
+	state := resumeMultipart(ctx, client, statePath, bucketName, key, info.Size(), source, partSize)
+	if state == nil {
+		created, err := client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+			Bucket:          aws.String(bucketName),
+			Key:             aws.String(key),
+			ContentType:     &fileOps.MimeType,
+			ContentEncoding: optionalString(fileOps.ContentEncoding),
+			CacheControl:    optionalString(fileOps.CacheControl),
+			Metadata:        fileOps.Metadata,
+		})
+		if err != nil {
+			logger.Debug("Error while creating multipart upload", zap.String("key", key), zap.Error(err))
+			return fmt.Errorf(msg.ErrorUploadFileBucket, bucketName, err)
+		}
+		state = &MultipartState{
+			Bucket:     bucketName,
+			Key:        key,
+			UploadID:   aws.ToString(created.UploadId),
+			Size:       info.Size(),
+			SourceSize: source.Size(),
+			ModTime:    source.ModTime(),
+			PartSize:   partSize,
+			Parts:      []CompletedPart{},
+		}
+		if err := writeMultipartState(statePath, state); err != nil {
+			return err
+		}
+	}
+
+	stored := make(map[int32]bool, len(state.Parts))
+	for _, part := range state.Parts {
+		stored[part.PartNumber] = true
+	}
+
+	totalParts := int32((info.Size() + partSize - 1) / partSize)
+	for partNumber := int32(1); partNumber <= totalParts; partNumber++ {
+		if stored[partNumber] {
+			continue
+		}
+		offset := int64(partNumber-1) * partSize
+		size := min(partSize, info.Size()-offset)
+
+		etag, err := uploadPart(ctx, client, state, fileOps.FileContent, partNumber, offset, size)
+		if err != nil {
+			logger.Debug("Multipart upload interrupted, it resumes on the next deploy", zap.String("key", key), zap.Error(err))
+			return fmt.Errorf(msg.ErrorUploadFileBucket, bucketName, err)
+		}
+		state.Parts = append(state.Parts, CompletedPart{PartNumber: partNumber, ETag: etag})
+		if err := writeMultipartState(statePath, state); err != nil {
+			return err
+		}
+	}
+
+	sort.Slice(state.Parts, func(i, j int) bool { return state.Parts[i].PartNumber < state.Parts[j].PartNumber })
+	completed := make([]types.CompletedPart, 0, len(state.Parts))
+	for _, part := range state.Parts {
+		completed = append(completed, types.CompletedPart{PartNumber: aws.Int32(part.PartNumber), ETag: aws.String(part.ETag)})
+	}
+	_, err = client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bucketName),
+		Key:             aws.String(key),
+		UploadId:        aws.String(state.UploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completed},
+	})
+	if err != nil {
+		logger.Debug("Error while completing multipart upload", zap.String("key", key), zap.Error(err))
+		return fmt.Errorf(msg.ErrorUploadFileBucket, bucketName, err)
+	}
+
+	if err := os.Remove(statePath); err != nil && !os.IsNotExist(err) {
+		logger.Debug("Error while removing multipart upload state", zap.String("path", statePath), zap.Error(err))
+	}
+	return nil
+}
+
+// uploadPart sends a part of the file, retrying it with backoff. It returns the ETag of the stored part.
+func uploadPart(ctx context.Context, client multipartClient, state *MultipartState, file io.ReaderAt, partNumber int32, offset, size int64) (string, error) {
+	var lastErr error
+	for retryCount := 0; retryCount < workers.MaxRetries; retryCount++ {
+		if retryCount > 0 {
+			isRateLimit := workers.IsRateLimitError(lastErr)
+			delay := workers.RetryDelay(retryCount, isRateLimit)
+			logger.Debug("Retrying part upload",
+				zap.String("key", state.Key),
+				zap.Int32("part", partNumber),
+				zap.Int("retry", retryCount),
+				zap.Duration("delay", delay),
+				zap.Error(lastErr))
+			time.Sleep(delay)
+		}
+
+		resp, err := client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:        aws.String(state.Bucket),
+			Key:           aws.String(state.Key),
+			UploadId:      aws.String(state.UploadID),
+			PartNumber:    aws.Int32(partNumber),
+			Body:          io.NewSectionReader(file, offset, size),
+			ContentLength: aws.Int64(size),
+		})
+		if err == nil {
+			return aws.ToString(resp.ETag), nil
+		}
+		lastErr = err
+	}
+	return "", lastErr
+}
+
+// resumeMultipart returns the saved progress of the upload of a file, confirming the parts already stored with
+// the API. Progress saved for another version of the source file, or for an upload that no longer exists, is
+// discarded.
+func resumeMultipart(ctx context.Context, client multipartClient, statePath, bucketName, key string, size int64, source os.FileInfo,
+	partSize int64) *MultipartState {
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		return nil
+	}
+	state := &MultipartState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		logger.Debug("Error while reading multipart upload state", zap.String("path", statePath), zap.Error(err))
+		return nil
+	}
+	if state.Bucket != bucketName || state.Key != key || state.Size != size || state.SourceSize != source.Size() ||
+		!state.ModTime.Equal(source.ModTime()) || state.PartSize != partSize {
+		logger.Debug("File changed since its multipart upload started, starting over", zap.String("key", key))
+		_, err := client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(state.Bucket),
+			Key:      aws.String(state.Key),
+			UploadId: aws.String(state.UploadID),
+		})
+		if err != nil {
+			logger.Debug("Error while aborting multipart upload", zap.String("key", state.Key), zap.Error(err))
+		}
+		return nil
+	}
+
+	parts := []CompletedPart{}
+	paginator := s3.NewListPartsPaginator(client, &s3.ListPartsInput{
+		Bucket:   aws.String(bucketName),
+		Key:      aws.String(key),
+		UploadId: aws.String(state.UploadID),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			var noSuchUpload *types.NoSuchUpload
+			if !errors.As(err, &noSuchUpload) {
+				logger.Debug("Error while listing the parts of a multipart upload", zap.String("key", key), zap.Error(err))
+			}
+			return nil
+		}
+		for _, part := range page.Parts {
+			parts = append(parts, CompletedPart{PartNumber: aws.ToInt32(part.PartNumber), ETag: aws.ToString(part.ETag)})
+		}
+	}
+
+	logger.Debug("Resuming multipart upload", zap.String("key", key), zap.Int("parts", len(parts)))
+	state.Parts = parts
+	return state
+}
+
+func multipartStatePath(stateDir, bucketName, key string) string {
+	if stateDir == "" {
+		stateDir = MultipartStateDir
+	}
+	return path.Join(stateDir, fmt.Sprintf("%x.json", sha256.Sum256([]byte(bucketName+"/"+key))))
+}
+
+func writeMultipartState(statePath string, state *MultipartState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(path.Dir(statePath), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(statePath, data, 0644)
+}
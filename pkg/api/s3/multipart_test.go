@@ -0,0 +1,197 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeMultipartClient keeps the parts stored by each upload in memory
+type fakeMultipartClient struct {
+	uploads   map[string][]int32
+	listErr   error
+	created   int
+	uploaded  []int32
+	aborted   []string
+	completed []types.CompletedPart
+}
+
+func (c *fakeMultipartClient) CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	c.created++
+	uploadID := fmt.Sprintf("upload-%d", c.created)
+	c.uploads[uploadID] = nil
+	return &s3.CreateMultipartUploadOutput{UploadId: aws.String(uploadID)}, nil
+}
+
+func (c *fakeMultipartClient) UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+	size, err := io.Copy(io.Discard, params.Body)
+	if err != nil {
+		return nil, err
+	}
+	if size != aws.ToInt64(params.ContentLength) {
+		return nil, fmt.Errorf("part %d has %d bytes, expected %d", aws.ToInt32(params.PartNumber), size, aws.ToInt64(params.ContentLength))
+	}
+	partNumber := aws.ToInt32(params.PartNumber)
+	c.uploaded = append(c.uploaded, partNumber)
+	c.uploads[aws.ToString(params.UploadId)] = append(c.uploads[aws.ToString(params.UploadId)], partNumber)
+	return &s3.UploadPartOutput{ETag: aws.String(fmt.Sprintf("etag-%d", partNumber))}, nil
+}
+
+func (c *fakeMultipartClient) CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	c.completed = params.MultipartUpload.Parts
+	return &s3.CompleteMultipartUploadOutput{}, nil
+}
+
+func (c *fakeMultipartClient) AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+	c.aborted = append(c.aborted, aws.ToString(params.UploadId))
+	delete(c.uploads, aws.ToString(params.UploadId))
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
+func (c *fakeMultipartClient) ListParts(ctx context.Context, params *s3.ListPartsInput, optFns ...func(*s3.Options)) (*s3.ListPartsOutput, error) {
+	if c.listErr != nil {
+		return nil, c.listErr
+	}
+	parts, ok := c.uploads[aws.ToString(params.UploadId)]
+	if !ok {
+		return nil, &types.NoSuchUpload{}
+	}
+	output := &s3.ListPartsOutput{}
+	for _, partNumber := range parts {
+		output.Parts = append(output.Parts, types.Part{PartNumber: aws.Int32(partNumber), ETag: aws.String(fmt.Sprintf("etag-%d", partNumber))})
+	}
+	return output, nil
+}
+
+func TestUploadMultipart(t *testing.T) {
+	logger.New(zapcore.DebugLevel)
+	// three parts, the last one smaller than the others
+	size := 2*MinPartSize + 1024
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		// state returns the progress saved by a previous run, if any
+		state func(content, source os.FileInfo) *MultipartState
+		// uploads are the uploads started by a previous run, along with their stored parts
+		uploads    map[string][]int32
+		listErr    error
+		compressed bool
+		created    int
+		uploaded   []int32
+		aborted    []string
+	}{
+		{
+			name:     "fresh upload",
+			created:  1,
+			uploaded: []int32{1, 2, 3},
+		},
+		{
+			name: "resume after partial parts",
+			state: func(content, source os.FileInfo) *MultipartState {
+				return &MultipartState{UploadID: "previous", Size: content.Size(), SourceSize: source.Size(), ModTime: source.ModTime(),
+					Parts: []CompletedPart{{PartNumber: 1, ETag: "etag-1"}}}
+			},
+			uploads:  map[string][]int32{"previous": {1, 2}},
+			uploaded: []int32{3},
+		},
+		{
+			name: "compressed variant resumes by its source file",
+			state: func(content, source os.FileInfo) *MultipartState {
+				return &MultipartState{UploadID: "previous", Size: content.Size(), SourceSize: source.Size(), ModTime: source.ModTime()}
+			},
+			uploads:    map[string][]int32{"previous": {1}},
+			compressed: true,
+			uploaded:   []int32{2, 3},
+		},
+		{
+			name: "changed file aborts the previous upload",
+			state: func(content, source os.FileInfo) *MultipartState {
+				return &MultipartState{UploadID: "previous", Size: content.Size(), SourceSize: source.Size(),
+					ModTime: source.ModTime().Add(-time.Hour)}
+			},
+			uploads:  map[string][]int32{"previous": {1, 2}},
+			created:  1,
+			uploaded: []int32{1, 2, 3},
+			aborted:  []string{"previous"},
+		},
+		{
+			name: "upload no longer exists",
+			state: func(content, source os.FileInfo) *MultipartState {
+				return &MultipartState{UploadID: "previous", Size: content.Size(), SourceSize: source.Size(), ModTime: source.ModTime()}
+			},
+			listErr:  &types.NoSuchUpload{},
+			created:  1,
+			uploaded: []int32{1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			contentPath := path.Join(dir, "video.mp4")
+			require.NoError(t, os.WriteFile(contentPath, make([]byte, size), 0644))
+			require.NoError(t, os.Chtimes(contentPath, modTime, modTime))
+			content, err := os.Open(contentPath)
+			require.NoError(t, err)
+			defer content.Close()
+			contentInfo, err := content.Stat()
+			require.NoError(t, err)
+
+			var source os.FileInfo
+			sourceInfo := contentInfo
+			if tt.compressed {
+				// the compressed copy is written on every run, only its source keeps the same modification time
+				sourcePath := path.Join(dir, "video.mp4.source")
+				require.NoError(t, os.WriteFile(sourcePath, make([]byte, size*2), 0644))
+				require.NoError(t, os.Chtimes(sourcePath, modTime, modTime))
+				require.NoError(t, os.Chtimes(contentPath, time.Now(), time.Now()))
+				source, err = os.Stat(sourcePath)
+				require.NoError(t, err)
+				sourceInfo = source
+			}
+
+			opts := MultipartOptions{Threshold: MinPartSize, PartSize: MinPartSize, StateDir: path.Join(dir, "uploads")}
+			statePath := multipartStatePath(opts.StateDir, "bucket", "prefix/video.mp4")
+			if tt.state != nil {
+				state := tt.state(contentInfo, sourceInfo)
+				state.Bucket, state.Key, state.PartSize = "bucket", "prefix/video.mp4", MinPartSize
+				require.NoError(t, writeMultipartState(statePath, state))
+			}
+
+			uploads := map[string][]int32{}
+			for uploadID, parts := range tt.uploads {
+				uploads[uploadID] = parts
+			}
+			client := &fakeMultipartClient{uploads: uploads, listErr: tt.listErr}
+			fileOps := &contracts.FileOps{Path: "video.mp4", MimeType: "video/mp4", FileContent: content}
+
+			require.NoError(t, uploadMultipart(context.Background(), client, fileOps, source, "bucket", "prefix", opts))
+			require.Equal(t, tt.created, client.created)
+			require.Equal(t, tt.uploaded, client.uploaded)
+			require.Equal(t, tt.aborted, client.aborted)
+
+			// every part is completed, in order, whichever run stored it
+			require.Len(t, client.completed, 3)
+			for i, part := range client.completed {
+				require.Equal(t, int32(i+1), aws.ToInt32(part.PartNumber))
+				require.Equal(t, fmt.Sprintf("etag-%d", i+1), aws.ToString(part.ETag))
+			}
+
+			_, err = os.Stat(statePath)
+			require.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
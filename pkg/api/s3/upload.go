@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"path"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	msg "github.com/aziontech/azion-cli/messages/deploy"
 	"github.com/aziontech/azion-cli/pkg/contracts"
 	"github.com/aziontech/azion-cli/pkg/logger"
@@ -63,10 +66,13 @@ func UploadFile(ctx context.Context, cfg aws.Config, fileOps *contracts.FileOps,
 
 	logger.Debug("Object_key: " + file)
 	uploadInput := &s3.PutObjectInput{
-		Bucket:      aws.String(bucketName),
-		Key:         aws.String(file), // Name of the file in the bucket
-		Body:        fileOps.FileContent,
-		ContentType: &fileOps.MimeType,
+		Bucket:          aws.String(bucketName),
+		Key:             aws.String(file), // Name of the file in the bucket
+		Body:            fileOps.FileContent,
+		ContentType:     &fileOps.MimeType,
+		ContentEncoding: optionalString(fileOps.ContentEncoding),
+		CacheControl:    optionalString(fileOps.CacheControl),
+		Metadata:        fileOps.Metadata,
 	}
 
 	_, err := s3Client.PutObject(ctx, uploadInput)
@@ -77,3 +83,60 @@ func UploadFile(ctx context.Context, cfg aws.Config, fileOps *contracts.FileOps,
 
 	return nil
 }
+
+// CopyFile copies fileOps.CopySource, an object already in the bucket, to the key of the file, so that
+// content already stored is not transferred again
+func CopyFile(ctx context.Context, cfg aws.Config, fileOps *contracts.FileOps, bucketName, prefix string) error {
+	file := fileOps.Path
+	if prefix != "" {
+		file = path.Join(prefix, fileOps.Path)
+	}
+
+	s3Client := s3.NewFromConfig(cfg)
+
+	logger.Debug("Copying object " + fileOps.CopySource + " to " + file)
+	source := &url.URL{Path: path.Join(bucketName, fileOps.CopySource)}
+	copyInput := &s3.CopyObjectInput{
+		Bucket:      aws.String(bucketName),
+		Key:         aws.String(file),
+		CopySource:  aws.String(source.EscapedPath()),
+		ContentType: &fileOps.MimeType,
+		// the headers of the file are set instead of the ones of the source object
+		MetadataDirective: types.MetadataDirectiveReplace,
+		ContentEncoding:   optionalString(fileOps.ContentEncoding),
+		CacheControl:      optionalString(fileOps.CacheControl),
+		Metadata:          fileOps.Metadata,
+	}
+
+	_, err := s3Client.CopyObject(ctx, copyInput)
+	if err != nil {
+		logger.Debug("Error while copying object <"+fileOps.CopySource+"> in storage api", zap.Error(err))
+		return fmt.Errorf(msg.ErrorCopyFileBucket, fileOps.CopySource, bucketName, err)
+	}
+
+	return nil
+}
+
+// ObjectETag returns the ETag of an object without its quotes. For objects stored in a single request it is
+// the MD5 of their content; objects uploaded in parts have an ETag ending in -<number of parts> instead.
+func ObjectETag(ctx context.Context, cfg aws.Config, bucketName, key string) (string, error) {
+	s3Client := s3.NewFromConfig(cfg)
+
+	resp, err := s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		logger.Debug("Error while reading the headers of object <"+key+"> in storage api", zap.Error(err))
+		return "", err
+	}
+	return strings.Trim(aws.ToString(resp.ETag), `"`), nil
+}
+
+// optionalString leaves unset the headers without value
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return aws.String(value)
+}
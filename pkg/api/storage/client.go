@@ -10,6 +10,10 @@ import (
 
 type Client struct {
 	apiClient *sdk.APIClient
+	// objects are downloaded without the SDK, which reads whole responses in memory
+	httpClient *http.Client
+	url        string
+	token      string
 }
 
 func NewClient(c *http.Client, url string, token string) *Client {
@@ -21,6 +25,9 @@ func NewClient(c *http.Client, url string, token string) *Client {
 		{URL: url},
 	}
 	return &Client{
-		apiClient: sdk.NewAPIClient(conf),
+		apiClient:  sdk.NewAPIClient(conf),
+		httpClient: c,
+		url:        url,
+		token:      token,
 	}
 }
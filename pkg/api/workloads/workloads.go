@@ -197,3 +197,23 @@ func (c *Client) UpdateDeployment(ctx context.Context, req sdk.PatchedWorkloadDe
 	}
 	return &workloadDeploymentsResponse.Data, nil
 }
+
+func (c *Client) DeleteDeployment(ctx context.Context, id int64, deploymentid int64) error {
+	logger.Debug("Delete Workload Deployment")
+	request := c.apiClient.WorkloadDeploymentsAPI.DeleteWorkloadDeployment(ctx, deploymentid, id)
+
+	_, httpResp, err := request.Execute()
+	if err != nil {
+		errBody := ""
+		if httpResp != nil {
+			logger.Debug("Error while deleting a workload deployment", zap.Error(err), zap.Any("ID", deploymentid))
+			errBody, err = utils.LogAndRewindBodyV4(httpResp)
+			if err != nil {
+				return err
+			}
+		}
+		return utils.ErrorPerStatusCodeV4(errBody, httpResp, err)
+	}
+
+	return nil
+}
@@ -1,11 +1,13 @@
 package apply
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc"
 	msg "github.com/aziontech/azion-cli/messages/config/apply"
@@ -15,6 +17,7 @@ import (
 	"github.com/aziontech/azion-cli/pkg/logger"
 	"github.com/aziontech/azion-cli/pkg/manifest"
 	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/state"
 	vulcanPkg "github.com/aziontech/azion-cli/pkg/vulcan"
 	"github.com/aziontech/azion-cli/utils"
 	"github.com/spf13/cobra"
@@ -35,15 +38,18 @@ type ApplyCmd struct {
 type Fields struct {
 	ConfigDir       string
 	AzionConfigName string
+	PlanFile        string
+	NoRollback      bool
 }
 
 func NewApplyCmd(f *cmdutil.Factory) *ApplyCmd {
+	store := state.New(f)
 	return &ApplyCmd{
 		GetWorkDir:            utils.GetWorkingDir,
 		FileReader:            os.ReadFile,
 		WriteFile:             os.WriteFile,
-		GetAzionJsonContent:   utils.GetAzionJsonContent,
-		WriteAzionJsonContent: utils.WriteAzionJsonContent,
+		GetAzionJsonContent:   store.Read,
+		WriteAzionJsonContent: store.Write,
 		Interpreter:           manifest.NewManifestInterpreter,
 		F:                     f,
 		CommandRunInteractive: command.CommandRunInteractive,
@@ -62,6 +68,8 @@ func NewCobraCmd(apply *ApplyCmd) *cobra.Command {
 		Example: heredoc.Doc(`
         $ azion config apply
         $ azion config apply --config-dir ./my-project
+        $ azion config apply --plan plan.json
+        $ azion config apply --no-rollback
         `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return apply.Run(fields)
@@ -69,6 +77,8 @@ func NewCobraCmd(apply *ApplyCmd) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&fields.ConfigDir, "config-dir", ".", msg.FlagConfigDir)
+	cmd.Flags().StringVar(&fields.PlanFile, "plan", "", msg.FlagPlan)
+	cmd.Flags().BoolVar(&fields.NoRollback, "no-rollback", false, msg.FlagNoRollback)
 	cmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
 
 	return cmd
@@ -89,6 +99,12 @@ func (cmd *ApplyCmd) Run(fields *Fields) error {
 
 	configDir := fields.ConfigDir
 
+	lock, err := state.Acquire(configDir, "config apply")
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
 	validConfigExtensions := []string{".js", ".ts", ".mjs", ".cjs"}
 	azionConfigPath := ""
 	for _, ext := range validConfigExtensions {
@@ -140,6 +156,10 @@ func (cmd *ApplyCmd) Run(fields *Fields) error {
 		return msg.ErrorReadingManifest
 	}
 
+	if err := manifest.ValidateApplications(manifestStructure.Applications); err != nil {
+		return err
+	}
+
 	rc := manifest.NewResourceContext(
 		cmd.F,
 		conf,
@@ -148,84 +168,20 @@ func (cmd *ApplyCmd) Run(fields *Fields) error {
 		&msgs,
 		cmd.WriteAzionJsonContent,
 	)
+	rc.SkipRollback = fields.NoRollback
 
-	resourceCount := 0
-
-	if len(manifestStructure.Storage) > 0 {
-		if err := rc.ApplyStorage(manifestStructure.Storage); err != nil {
-			return err
-		}
-		resourceCount++
-	}
-
-	if len(manifestStructure.Connectors) > 0 {
-		if err := rc.ApplyConnectors(manifestStructure.Connectors); err != nil {
-			return err
-		}
-		resourceCount++
-	}
-
-	if len(manifestStructure.Functions) > 0 {
-		if err := rc.ApplyFunctions(manifestStructure.Functions); err != nil {
-			return err
-		}
-		resourceCount++
-	}
-
-	if len(manifestStructure.Applications) > 0 {
-		app := manifestStructure.Applications[0]
-
-		if err := rc.ApplyEdgeApplication(app); err != nil {
-			return err
-		}
-		resourceCount++
-
-		if len(app.FunctionsInstances) > 0 {
-			if err := rc.ApplyFunctionInstances(app.FunctionsInstances); err != nil {
-				return err
-			}
-			resourceCount++
-		}
-
-		if len(app.CacheSettings) > 0 {
-			if err := rc.ApplyCacheSettings(app.CacheSettings); err != nil {
-				return err
-			}
-		}
-
-		if len(app.Rules) > 0 {
-			if err := rc.ApplyRulesEngine(app.Rules); err != nil {
-				return err
-			}
-		}
-	}
-
-	if len(manifestStructure.Workloads) > 0 {
-		if err := rc.ApplyWorkloads(manifestStructure.Workloads); err != nil {
-			return err
-		}
-		resourceCount++
-	}
-
-	if len(manifestStructure.WorkloadDeployments) > 0 {
-		if err := rc.ApplyWorkloadDeployments(manifestStructure.WorkloadDeployments); err != nil {
-			return err
-		}
-		resourceCount++
-	}
-
-	if len(manifestStructure.Firewalls) > 0 {
-		if err := rc.ApplyFirewalls(manifestStructure.Firewalls); err != nil {
+	if fields.PlanFile != "" {
+		if err := cmd.verifyPlan(rc, fields.PlanFile); err != nil {
 			return err
 		}
-		resourceCount++
+		msgf := fmt.Sprintf(msg.PlanVerified, fields.PlanFile)
+		logger.FInfoFlags(cmd.F.IOStreams.Out, msgf, cmd.F.Format, cmd.F.Out)
+		msgs = append(msgs, msgf)
 	}
 
-	if len(manifestStructure.Purge) > 0 {
-		if err := rc.ApplyPurge(manifestStructure.Purge); err != nil {
-			return err
-		}
-		resourceCount++
+	resourceCount, err := rc.ApplyManifest(true)
+	if err != nil {
+		return err
 	}
 
 	if resourceCount == 0 {
@@ -251,3 +207,41 @@ func (cmd *ApplyCmd) Run(fields *Fields) error {
 
 	return output.Print(&outSlice)
 }
+
+// verifyPlan checks that applying the manifest would make exactly the changes found in the saved plan.
+// The plan is refused if the configuration changed since it was created, or if the remote state drifted.
+func (cmd *ApplyCmd) verifyPlan(rc *manifest.ResourceContext, planFile string) error {
+	data, err := cmd.FileReader(planFile)
+	if err != nil {
+		logger.Debug("Error while reading the plan file", zap.Error(err))
+		return fmt.Errorf("%s: %w", msg.ErrorReadingPlan, err)
+	}
+
+	saved := &manifest.Plan{}
+	if err := json.Unmarshal(data, saved); err != nil {
+		logger.Debug("Error while parsing the plan file", zap.Error(err))
+		return fmt.Errorf("%s: %w", msg.ErrorReadingPlan, err)
+	}
+	if saved.Version != manifest.PlanVersion {
+		return msg.ErrorPlanVersion
+	}
+
+	current, err := rc.Plan(true)
+	if err != nil {
+		return err
+	}
+	if current.ManifestHash != saved.ManifestHash {
+		return msg.ErrorPlanManifestChanged
+	}
+
+	drifted := manifest.DriftedChanges(saved, current)
+	if len(drifted) > 0 {
+		resources := make([]string, 0, len(drifted))
+		for _, change := range drifted {
+			resources = append(resources, fmt.Sprintf(msg.DriftedResource, change.Action, change.KindName(), change.Name))
+		}
+		return fmt.Errorf(msg.ErrorPlanDrift, strings.Join(resources, ", "))
+	}
+
+	return nil
+}
@@ -5,7 +5,12 @@ import (
 	msg "github.com/aziontech/azion-cli/messages/config"
 	"github.com/aziontech/azion-cli/pkg/cmd/config/apply"
 	configdelete "github.com/aziontech/azion-cli/pkg/cmd/config/delete"
+	"github.com/aziontech/azion-cli/pkg/cmd/config/drift"
+	configimport "github.com/aziontech/azion-cli/pkg/cmd/config/import"
 	configinit "github.com/aziontech/azion-cli/pkg/cmd/config/init"
+	"github.com/aziontech/azion-cli/pkg/cmd/config/plan"
+	"github.com/aziontech/azion-cli/pkg/cmd/config/unlock"
+	"github.com/aziontech/azion-cli/pkg/cmd/config/validate"
 	"github.com/aziontech/azion-cli/pkg/cmdutil"
 	"github.com/spf13/cobra"
 )
@@ -20,8 +25,15 @@ func NewCmd(f *cmdutil.Factory) *cobra.Command {
 		$ azion config init
 		$ azion config apply
 		$ azion config apply --config-dir ./my-project
+		$ azion config plan
+		$ azion config plan --save plan.json
+		$ azion config apply --plan plan.json
+		$ azion config validate
+		$ azion config drift
+		$ azion config import --application-id 1673
 		$ azion config delete
 		$ azion config delete --force
+		$ azion config unlock --force
         `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
@@ -31,6 +43,11 @@ func NewCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.AddCommand(apply.NewCmd(f))
 	cmd.AddCommand(configinit.NewCmd(f))
 	cmd.AddCommand(configdelete.NewCmd(f))
+	cmd.AddCommand(plan.NewCmd(f))
+	cmd.AddCommand(validate.NewCmd(f))
+	cmd.AddCommand(drift.NewCmd(f))
+	cmd.AddCommand(configimport.NewCmd(f))
+	cmd.AddCommand(unlock.NewCmd(f))
 
 	return cmd
 }
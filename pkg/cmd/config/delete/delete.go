@@ -27,6 +27,7 @@ import (
 	"github.com/aziontech/azion-cli/pkg/iostreams"
 	"github.com/aziontech/azion-cli/pkg/logger"
 	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/state"
 	"github.com/aziontech/azion-cli/utils"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -40,6 +41,7 @@ type DeleteCmd struct {
 	Io         *iostreams.IOStreams
 	f          *cmdutil.Factory
 	GetAzion   func(confPath string) (*contracts.AzionApplicationOptions, error)
+	WriteAzion func(conf *contracts.AzionApplicationOptions, confPath string) error
 	AskInput   func(string) (string, error)
 	WriteFile  func(filename string, data []byte, perm fs.FileMode) error
 	GetWorkDir func() (string, error)
@@ -51,14 +53,18 @@ type Fields struct {
 }
 
 func NewDeleteCmd(f *cmdutil.Factory) *DeleteCmd {
-	return &DeleteCmd{
+	del := &DeleteCmd{
 		Io:         f.IOStreams,
 		f:          f,
-		GetAzion:   utils.GetAzionJsonContent,
 		AskInput:   utils.AskInput,
 		WriteFile:  os.WriteFile,
 		GetWorkDir: os.Getwd,
 	}
+	store := state.New(f)
+	store.Local.WriteAzionJsonContent = del.writeAzionJson
+	del.GetAzion = store.Read
+	del.WriteAzion = store.Write
+	return del
 }
 
 func NewCobraCmd(delete *DeleteCmd) *cobra.Command {
@@ -95,6 +101,12 @@ func (del *DeleteCmd) Run(fields *Fields) error {
 	ctx := context.Background()
 	logger.Debug("Running config delete command")
 
+	lock, err := state.Acquire(fields.ConfigDir, "config delete")
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
 	azionJson, err := del.GetAzion(fields.ConfigDir)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
@@ -103,7 +115,9 @@ func (del *DeleteCmd) Run(fields *Fields) error {
 		return err
 	}
 
-	if azionJson.Application.ID == 0 && len(azionJson.Firewalls) == 0 && len(azionJson.Function) == 0 && azionJson.Workloads.Id == 0 {
+	applications := applicationsToDelete(azionJson)
+
+	if len(applications) == 0 && len(azionJson.Firewalls) == 0 && len(azionJson.Function) == 0 && azionJson.Workloads.Id == 0 {
 		logger.FInfo(del.Io.Out, "No resources found in azion.json to delete\n")
 		return nil
 	}
@@ -136,16 +150,16 @@ func (del *DeleteCmd) Run(fields *Fields) error {
 	successCount := 0
 	failCount := 0
 
-	if azionJson.Application.ID != 0 {
+	for _, application := range applications {
 		listOpts := &contracts.ListOptions{PageSize: 100}
 		for {
-			requestRules, err := clientApp.ListRulesEngineRequest(ctx, listOpts, azionJson.Application.ID)
+			requestRules, err := clientApp.ListRulesEngineRequest(ctx, listOpts, application.ID)
 			if err != nil {
 				if isNotFoundError(err) {
-					logger.Debug("Application not found, skipping request phase rules deletion", zap.Int64("applicationID", azionJson.Application.ID))
+					logger.Debug("Application not found, skipping request phase rules deletion", zap.Int64("applicationID", application.ID))
 					break
 				}
-				errs = append(errs, fmt.Sprintf("Failed to list request phase rules for application %d: %v", azionJson.Application.ID, err))
+				errs = append(errs, fmt.Sprintf("Failed to list request phase rules for application %d: %v", application.ID, err))
 				break
 			}
 			if requestRules == nil || len(requestRules.Results) == 0 {
@@ -155,7 +169,7 @@ func (del *DeleteCmd) Run(fields *Fields) error {
 				ruleName := rule.GetName()
 				ruleId := rule.GetId()
 				logger.FInfo(del.Io.Out, fmt.Sprintf(msg.DeletingRulesEngineApp, ruleName, ruleId))
-				err := clientRulesEngine.DeleteRequest(ctx, azionJson.Application.ID, ruleId)
+				err := clientRulesEngine.DeleteRequest(ctx, application.ID, ruleId)
 				if err != nil {
 					errs = append(errs, fmt.Sprintf("Failed to delete Rules Engine rule '%s' (ID: %d): %v", ruleName, ruleId, err))
 					logger.FInfo(del.Io.Out, fmt.Sprintf("Failed to delete Rules Engine rule '%s': %v\n", ruleName, err))
@@ -172,13 +186,13 @@ func (del *DeleteCmd) Run(fields *Fields) error {
 
 		listOpts = &contracts.ListOptions{PageSize: 100}
 		for {
-			responseRules, err := clientApp.ListRulesEngineResponse(ctx, listOpts, azionJson.Application.ID)
+			responseRules, err := clientApp.ListRulesEngineResponse(ctx, listOpts, application.ID)
 			if err != nil {
 				if isNotFoundError(err) {
-					logger.Debug("Application not found, skipping response phase rules deletion", zap.Int64("applicationID", azionJson.Application.ID))
+					logger.Debug("Application not found, skipping response phase rules deletion", zap.Int64("applicationID", application.ID))
 					break
 				}
-				errs = append(errs, fmt.Sprintf("Failed to list response phase rules for application %d: %v", azionJson.Application.ID, err))
+				errs = append(errs, fmt.Sprintf("Failed to list response phase rules for application %d: %v", application.ID, err))
 				break
 			}
 			if responseRules == nil || len(responseRules.Results) == 0 {
@@ -188,7 +202,7 @@ func (del *DeleteCmd) Run(fields *Fields) error {
 				ruleName := rule.GetName()
 				ruleId := rule.GetId()
 				logger.FInfo(del.Io.Out, fmt.Sprintf(msg.DeletingRulesEngineApp, ruleName, ruleId))
-				err := clientRulesEngine.DeleteResponse(ctx, azionJson.Application.ID, ruleId)
+				err := clientRulesEngine.DeleteResponse(ctx, application.ID, ruleId)
 				if err != nil {
 					errs = append(errs, fmt.Sprintf("Failed to delete Rules Engine rule '%s' (ID: %d): %v", ruleName, ruleId, err))
 					logger.FInfo(del.Io.Out, fmt.Sprintf("Failed to delete Rules Engine rule '%s': %v\n", ruleName, err))
@@ -241,16 +255,16 @@ func (del *DeleteCmd) Run(fields *Fields) error {
 		}
 	}
 
-	if azionJson.Application.ID != 0 {
+	for _, application := range applications {
 		listOpts := &contracts.ListOptions{PageSize: 100}
 		for {
-			funcInstances, err := clientApp.EdgeFuncInstancesList(ctx, listOpts, azionJson.Application.ID)
+			funcInstances, err := clientApp.EdgeFuncInstancesList(ctx, listOpts, application.ID)
 			if err != nil {
 				if isNotFoundError(err) {
-					logger.Debug("Application not found, skipping function instances deletion", zap.Int64("applicationID", azionJson.Application.ID))
+					logger.Debug("Application not found, skipping function instances deletion", zap.Int64("applicationID", application.ID))
 					break
 				}
-				errs = append(errs, fmt.Sprintf("Failed to list function instances for application %d: %v", azionJson.Application.ID, err))
+				errs = append(errs, fmt.Sprintf("Failed to list function instances for application %d: %v", application.ID, err))
 				break
 			}
 			if funcInstances == nil || len(funcInstances.Results) == 0 {
@@ -260,7 +274,7 @@ func (del *DeleteCmd) Run(fields *Fields) error {
 				fnName := fn.GetName()
 				fnId := fn.GetId()
 				logger.FInfo(del.Io.Out, fmt.Sprintf(msg.DeletingFuncInstanceApp, fnName, fnId))
-				err := clientFuncInstance.Delete(ctx, azionJson.Application.ID, fnId)
+				err := clientFuncInstance.Delete(ctx, application.ID, fnId)
 				if err != nil {
 					errs = append(errs, fmt.Sprintf("Failed to delete Function Instance '%s' (ID: %d): %v", fnName, fnId, err))
 					logger.FInfo(del.Io.Out, fmt.Sprintf("Failed to delete Function Instance '%s': %v\n", fnName, err))
@@ -276,16 +290,16 @@ func (del *DeleteCmd) Run(fields *Fields) error {
 		}
 	}
 
-	if azionJson.Application.ID != 0 {
+	for _, application := range applications {
 		listOpts := &contracts.ListOptions{PageSize: 100}
 		for {
-			cacheSettings, err := clientCacheSetting.List(ctx, listOpts, azionJson.Application.ID)
+			cacheSettings, err := clientCacheSetting.List(ctx, listOpts, application.ID)
 			if err != nil {
 				if isNotFoundError(err) {
-					logger.Debug("Application not found, skipping cache settings deletion", zap.Int64("applicationID", azionJson.Application.ID))
+					logger.Debug("Application not found, skipping cache settings deletion", zap.Int64("applicationID", application.ID))
 					break
 				}
-				errs = append(errs, fmt.Sprintf("Failed to list cache settings for application %d: %v", azionJson.Application.ID, err))
+				errs = append(errs, fmt.Sprintf("Failed to list cache settings for application %d: %v", application.ID, err))
 				break
 			}
 			if cacheSettings == nil {
@@ -299,7 +313,7 @@ func (del *DeleteCmd) Run(fields *Fields) error {
 				csName := cs.GetName()
 				csId := cs.GetId()
 				logger.FInfo(del.Io.Out, fmt.Sprintf(msg.DeletingCacheSetting, csName, csId))
-				_, err := clientCacheSetting.Delete(ctx, azionJson.Application.ID, csId)
+				_, err := clientCacheSetting.Delete(ctx, application.ID, csId)
 				if err != nil {
 					errs = append(errs, fmt.Sprintf("Failed to delete Cache Setting '%s' (ID: %d): %v", csName, csId, err))
 					logger.FInfo(del.Io.Out, fmt.Sprintf("Failed to delete Cache Setting '%s': %v\n", csName, err))
@@ -315,12 +329,12 @@ func (del *DeleteCmd) Run(fields *Fields) error {
 		}
 	}
 
-	if azionJson.Application.ID != 0 {
-		logger.FInfo(del.Io.Out, fmt.Sprintf(msg.DeletingApplication, azionJson.Application.Name, azionJson.Application.ID))
-		err := clientApp.Delete(ctx, azionJson.Application.ID)
+	for _, application := range applications {
+		logger.FInfo(del.Io.Out, fmt.Sprintf(msg.DeletingApplication, application.Name, application.ID))
+		err := clientApp.Delete(ctx, application.ID)
 		if err != nil {
-			errs = append(errs, fmt.Sprintf("Failed to delete Application '%s' (ID: %d): %v", azionJson.Application.Name, azionJson.Application.ID, err))
-			logger.FInfo(del.Io.Out, fmt.Sprintf("Failed to delete Application '%s': %v\n", azionJson.Application.Name, err))
+			errs = append(errs, fmt.Sprintf("Failed to delete Application '%s' (ID: %d): %v", application.Name, application.ID, err))
+			logger.FInfo(del.Io.Out, fmt.Sprintf("Failed to delete Application '%s': %v\n", application.Name, err))
 			failCount++
 		} else {
 			successCount++
@@ -398,7 +412,7 @@ func (del *DeleteCmd) Run(fields *Fields) error {
 	}
 
 	logger.FInfo(del.Io.Out, msg.ResettingConfig)
-	err = del.resetAzionJson(fields.ConfigDir)
+	err = del.resetAzionJson(fields.ConfigDir, azionJson)
 	if err != nil {
 		errs = append(errs, fmt.Sprintf("Failed to reset azion.json: %v", err))
 		logger.FInfo(del.Io.Out, fmt.Sprintf("Failed to reset azion.json: %v\n", err))
@@ -427,7 +441,34 @@ func (del *DeleteCmd) Run(fields *Fields) error {
 	return output.Print(&deleteOut)
 }
 
-func (del *DeleteCmd) resetAzionJson(configDir string) error {
+// applicationsToDelete returns every application tracked in azion.json, without duplicates
+func applicationsToDelete(azionJson *contracts.AzionApplicationOptions) []contracts.AzionJsonDataApplication {
+	applications := []contracts.AzionJsonDataApplication{}
+	seen := make(map[int64]bool)
+	if azionJson.Application.ID != 0 {
+		applications = append(applications, azionJson.Application)
+		seen[azionJson.Application.ID] = true
+	}
+	for _, appConf := range azionJson.Applications {
+		if appConf.ID == 0 || seen[appConf.ID] {
+			continue
+		}
+		seen[appConf.ID] = true
+		applications = append(applications, contracts.AzionJsonDataApplication{
+			ID:   appConf.ID,
+			Name: appConf.Name,
+		})
+	}
+	return applications
+}
+
+// resetAzionJson empties azion.json, keeping only where it is kept so that a shared state is reset as well
+func (del *DeleteCmd) resetAzionJson(configDir string, azionJson *contracts.AzionApplicationOptions) error {
+	return del.WriteAzion(&contracts.AzionApplicationOptions{State: azionJson.State}, configDir)
+}
+
+// writeAzionJson writes the local azion.json of the config dir, which may be an absolute path
+func (del *DeleteCmd) writeAzionJson(azionJson *contracts.AzionApplicationOptions, configDir string) error {
 	wd, err := del.GetWorkDir()
 	if err != nil {
 		return err
@@ -440,7 +481,6 @@ func (del *DeleteCmd) resetAzionJson(configDir string) error {
 		configPath = path.Join(wd, configDir, "azion.json")
 	}
 
-	azionJson := &contracts.AzionApplicationOptions{}
 	data, err := json.MarshalIndent(azionJson, "", "  ")
 	if err != nil {
 		logger.Debug("Error marshaling azion.json", zap.Error(err))
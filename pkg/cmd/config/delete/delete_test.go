@@ -390,7 +390,7 @@ func TestResetAzionJson(t *testing.T) {
 			deleteCmd.WriteFile = tt.mockWriteFile
 			deleteCmd.GetWorkDir = tt.mockWorkDir
 
-			err := deleteCmd.resetAzionJson("azion")
+			err := deleteCmd.resetAzionJson("azion", &contracts.AzionApplicationOptions{})
 			if tt.expectError {
 				require.Error(t, err)
 			} else {
@@ -399,3 +399,36 @@ func TestResetAzionJson(t *testing.T) {
 		})
 	}
 }
+
+func TestResetSharedAzionJson(t *testing.T) {
+	f, _, _ := testutils.NewFactory(nil)
+	settings := &contracts.AzionJsonState{Backend: "storage", Bucket: "project-state"}
+	var written *contracts.AzionApplicationOptions
+
+	deleteCmd := NewDeleteCmd(f)
+	deleteCmd.WriteAzion = func(conf *contracts.AzionApplicationOptions, confPath string) error {
+		written = conf
+		return nil
+	}
+
+	azionJson := &contracts.AzionApplicationOptions{Name: "project", State: settings, Application: contracts.AzionJsonDataApplication{ID: 1234}}
+	require.NoError(t, deleteCmd.resetAzionJson("azion", azionJson))
+	require.Equal(t, &contracts.AzionApplicationOptions{State: settings}, written)
+}
+
+func TestApplicationsToDelete(t *testing.T) {
+	azionJson := &contracts.AzionApplicationOptions{
+		Application: contracts.AzionJsonDataApplication{ID: 1234, Name: "api"},
+		Applications: []contracts.AzionJsonDataApplications{
+			{ID: 1234, Name: "api"},
+			{ID: 5678, Name: "site"},
+			{Name: "not-created"},
+		},
+	}
+
+	applications := applicationsToDelete(azionJson)
+	require.Len(t, applications, 2)
+	assert.Equal(t, int64(1234), applications[0].ID)
+	assert.Equal(t, int64(5678), applications[1].ID)
+	assert.Equal(t, "site", applications[1].Name)
+}
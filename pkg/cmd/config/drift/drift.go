@@ -0,0 +1,208 @@
+package drift
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/config/drift"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/command"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/manifest"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/state"
+	vulcanPkg "github.com/aziontech/azion-cli/pkg/vulcan"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type DriftCmd struct {
+	GetWorkDir            func() (string, error)
+	Stat                  func(name string) (fs.FileInfo, error)
+	GetAzionJsonContent   func(confPath string) (*contracts.AzionApplicationOptions, error)
+	WriteAzionJsonContent func(conf *contracts.AzionApplicationOptions, confPath string) error
+	Interpreter           func() *manifest.ManifestInterpreter
+	F                     *cmdutil.Factory
+	CommandRunInteractive func(f *cmdutil.Factory, comm string) error
+	// DetectDrift compares the manifest with the remote state, replaced in tests
+	DetectDrift func(rc *manifest.ResourceContext) ([]manifest.FieldDrift, error)
+}
+
+type Fields struct {
+	ConfigDir string
+}
+
+// Report is what the drift command prints when a format is requested
+type Report struct {
+	Drifted bool                  `json:"drifted"`
+	Drifts  []manifest.FieldDrift `json:"drifts"`
+}
+
+func NewDriftCmd(f *cmdutil.Factory) *DriftCmd {
+	store := state.New(f)
+	return &DriftCmd{
+		GetWorkDir:            utils.GetWorkingDir,
+		Stat:                  os.Stat,
+		GetAzionJsonContent:   store.Read,
+		WriteAzionJsonContent: store.Write,
+		Interpreter:           manifest.NewManifestInterpreter,
+		F:                     f,
+		CommandRunInteractive: command.CommandRunInteractive,
+		DetectDrift: func(rc *manifest.ResourceContext) ([]manifest.FieldDrift, error) {
+			return rc.DetectDrift()
+		},
+	}
+}
+
+func NewCobraCmd(drift *DriftCmd) *cobra.Command {
+	fields := &Fields{}
+
+	cmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+        $ azion config drift
+        $ azion config drift --format json
+        $ azion config drift --config-dir ./my-project
+        `),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return drift.Run(fields)
+		},
+	}
+
+	cmd.Flags().StringVar(&fields.ConfigDir, "config-dir", ".", msg.FlagConfigDir)
+	cmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewDriftCmd(f))
+}
+
+func (cmd *DriftCmd) Run(fields *Fields) error {
+	msgs := []string{}
+	logger.Debug("Running config drift command")
+
+	wd, err := cmd.GetWorkDir()
+	if err != nil {
+		return err
+	}
+
+	azionConfigFound := false
+	for _, ext := range []string{".js", ".ts", ".mjs", ".cjs"} {
+		if _, err := cmd.Stat(path.Join(wd, "azion.config"+ext)); err == nil {
+			azionConfigFound = true
+			break
+		}
+	}
+	if !azionConfigFound {
+		logger.FInfoFlags(cmd.F.IOStreams.Out, msg.AzionConfigNotFound, cmd.F.Format, cmd.F.Out)
+		return msg.ErrorAzionConfigNotFound
+	}
+
+	conf, err := cmd.GetAzionJsonContent(fields.ConfigDir)
+	if err != nil {
+		if errors.Is(err, utils.ErrorOpeningAzionJsonFile) {
+			return msg.ErrorAzionJsonNotFound
+		}
+		return err
+	}
+
+	vul := vulcanPkg.NewVulcan()
+	command := vul.Command("", "manifest generate", cmd.F)
+	logger.Debug("Running the following command", zap.Any("Command", command))
+	if err := cmd.CommandRunInteractive(cmd.F, command); err != nil {
+		return err
+	}
+
+	interpreter := cmd.Interpreter()
+	manifestPath, err := interpreter.ManifestPath()
+	if err != nil {
+		return err
+	}
+
+	manifestStructure, err := interpreter.ReadManifest(manifestPath, cmd.F, &msgs)
+	if err != nil {
+		logger.Debug("Error reading manifest", zap.Error(err))
+		return msg.ErrorReadingManifest
+	}
+
+	if err := manifest.ValidateApplications(manifestStructure.Applications); err != nil {
+		return err
+	}
+
+	rc := manifest.NewResourceContext(cmd.F, conf, manifestStructure, fields.ConfigDir, &msgs, cmd.WriteAzionJsonContent)
+	drifts, err := cmd.DetectDrift(rc)
+	if err != nil {
+		return err
+	}
+
+	if err := output.Print(driftOutput(cmd.F, drifts)); err != nil {
+		return err
+	}
+
+	if len(drifts) > 0 {
+		return msg.ErrorDriftDetected
+	}
+	return nil
+}
+
+// driftOutput lists the drifted fields as a table followed by a summary
+func driftOutput(f *cmdutil.Factory, drifts []manifest.FieldDrift) *output.ChangeSetOutput {
+	changed, deleted := 0, 0
+	lines := make([][]string, 0, len(drifts))
+	for _, drift := range drifts {
+		application := drift.Application
+		if application == "" || drift.Kind == "application" {
+			application = "-"
+		}
+		field, expected, actual := drift.Field, formatValue(drift.Expected), formatValue(drift.Actual)
+		if drift.Missing {
+			deleted++
+			field, expected, actual = "-", "-", msg.Deleted
+		} else {
+			changed++
+		}
+		lines = append(lines, []string{drift.KindName(), drift.Name, strconv.FormatInt(drift.ID, 10), application, field, expected, actual})
+	}
+
+	summary := msg.NoDrift
+	if len(drifts) > 0 {
+		summary = fmt.Sprintf(msg.DriftSummary, changed, deleted)
+	}
+
+	return &output.ChangeSetOutput{
+		GeneralOutput: output.GeneralOutput{
+			Msg:   summary,
+			Out:   f.IOStreams.Out,
+			Flags: f.Flags,
+		},
+		ChangeSet: &Report{Drifted: len(drifts) > 0, Drifts: drifts},
+		Columns:   []string{"KIND", "NAME", "ID", "APPLICATION", "FIELD", "EXPECTED", "ACTUAL"},
+		Lines:     lines,
+	}
+}
+
+// formatValue prints a field value the way it is written in JSON
+func formatValue(value any) string {
+	if value == nil {
+		return "null"
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
@@ -0,0 +1,93 @@
+package drift
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"testing"
+
+	msg "github.com/aziontech/azion-cli/messages/config/drift"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/manifest"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+func newTestDriftCmd(f *cmdutil.Factory, drifts []manifest.FieldDrift) *DriftCmd {
+	cmd := NewDriftCmd(f)
+	cmd.GetWorkDir = func() (string, error) { return "/project", nil }
+	cmd.Stat = func(name string) (fs.FileInfo, error) {
+		if name == "/project/azion.config.js" {
+			return nil, nil
+		}
+		return nil, os.ErrNotExist
+	}
+	cmd.GetAzionJsonContent = func(confPath string) (*contracts.AzionApplicationOptions, error) {
+		return &contracts.AzionApplicationOptions{Name: "project"}, nil
+	}
+	cmd.CommandRunInteractive = func(f *cmdutil.Factory, comm string) error { return nil }
+	cmd.Interpreter = func() *manifest.ManifestInterpreter {
+		interpreter := manifest.NewManifestInterpreter()
+		interpreter.GetWorkDir = cmd.GetWorkDir
+		interpreter.FileReader = func(path string) ([]byte, error) { return []byte(`{}`), nil }
+		return interpreter
+	}
+	cmd.DetectDrift = func(rc *manifest.ResourceContext) ([]manifest.FieldDrift, error) {
+		return drifts, nil
+	}
+	return cmd
+}
+
+func TestDrift(t *testing.T) {
+	t.Run("no drift", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		err := newTestDriftCmd(f, []manifest.FieldDrift{}).Run(&Fields{ConfigDir: "."})
+		require.NoError(t, err)
+		require.Contains(t, stdout.String(), msg.NoDrift)
+	})
+
+	t.Run("drift exits with an error", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		drifts := []manifest.FieldDrift{
+			{Kind: "application", Name: "api", ID: 10, Application: "api", Field: "debug", Expected: false, Actual: true},
+			{Kind: "workload", Name: "api", ID: 30, Missing: true},
+		}
+
+		err := newTestDriftCmd(f, drifts).Run(&Fields{ConfigDir: "."})
+		require.ErrorIs(t, err, msg.ErrorDriftDetected)
+		require.Contains(t, stdout.String(), "debug")
+		require.Contains(t, stdout.String(), msg.Deleted)
+		require.Contains(t, stdout.String(), "Drift detected: 1 field(s) changed and 1 resource(s) deleted")
+	})
+
+	t.Run("drift is printed in the requested format", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		f.Flags.Format = "json"
+		drifts := []manifest.FieldDrift{{Kind: "workload", Name: "api", ID: 30, Missing: true}}
+
+		err := newTestDriftCmd(f, drifts).Run(&Fields{ConfigDir: "."})
+		require.ErrorIs(t, err, msg.ErrorDriftDetected)
+
+		report := Report{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+		require.True(t, report.Drifted)
+		require.Equal(t, drifts, report.Drifts)
+	})
+
+	t.Run("nothing deployed yet", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(nil)
+		cmd := newTestDriftCmd(f, nil)
+		cmd.GetAzionJsonContent = func(confPath string) (*contracts.AzionApplicationOptions, error) {
+			return nil, utils.ErrorOpeningAzionJsonFile
+		}
+		require.ErrorIs(t, cmd.Run(&Fields{ConfigDir: "."}), msg.ErrorAzionJsonNotFound)
+	})
+}
@@ -0,0 +1,221 @@
+package configimport
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/config/import"
+	"github.com/aziontech/azion-cli/pkg/cmd/sync"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/command"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/manifest"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/state"
+	vulcanPkg "github.com/aziontech/azion-cli/pkg/vulcan"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type ImportCmd struct {
+	F                     *cmdutil.Factory
+	GetWorkDir            func() (string, error)
+	Stat                  func(name string) (fs.FileInfo, error)
+	MkdirAll              func(path string, perm fs.FileMode) error
+	WriteFile             func(name string, data []byte, perm fs.FileMode) error
+	GetAzionJsonContent   func(confPath string) (*contracts.AzionApplicationOptions, error)
+	WriteAzionJsonContent func(conf *contracts.AzionApplicationOptions, confPath string) error
+	WriteManifest         func(manifest *contracts.ManifestV4, pathMan string) error
+	CommandRunInteractive func(f *cmdutil.Factory, comm string) error
+	// Import walks the resources on Azion Platform, replaced in tests
+	Import func(rc *manifest.ResourceContext, ids manifest.ImportIDs) (*manifest.ImportResult, error)
+}
+
+type Fields struct {
+	ApplicationIDs []int64
+	WorkloadID     int64
+	FirewallIDs    []int64
+	ConfigDir      string
+	Extension      string
+	Force          bool
+}
+
+func NewImportCmd(f *cmdutil.Factory) *ImportCmd {
+	store := state.New(f)
+	return &ImportCmd{
+		F:                     f,
+		GetWorkDir:            utils.GetWorkingDir,
+		Stat:                  os.Stat,
+		MkdirAll:              os.MkdirAll,
+		WriteFile:             os.WriteFile,
+		GetAzionJsonContent:   store.Read,
+		WriteAzionJsonContent: store.Write,
+		WriteManifest:         sync.WriteManifest,
+		CommandRunInteractive: command.CommandRunInteractive,
+		Import: func(rc *manifest.ResourceContext, ids manifest.ImportIDs) (*manifest.ImportResult, error) {
+			return rc.ImportResources(ids)
+		},
+	}
+}
+
+func NewCobraCmd(importCmd *ImportCmd) *cobra.Command {
+	fields := &Fields{}
+
+	cmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+        $ azion config import --application-id 1673
+        $ azion config import --workload-id 2890 --extension ts
+        $ azion config import --application-id 1673 --firewall-id 4121 --force
+        `),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return importCmd.Run(fields)
+		},
+	}
+
+	cmd.Flags().Int64SliceVar(&fields.ApplicationIDs, "application-id", nil, msg.FlagApplication)
+	cmd.Flags().Int64Var(&fields.WorkloadID, "workload-id", 0, msg.FlagWorkload)
+	cmd.Flags().Int64SliceVar(&fields.FirewallIDs, "firewall-id", nil, msg.FlagFirewall)
+	cmd.Flags().StringVar(&fields.ConfigDir, "config-dir", ".", msg.FlagConfigDir)
+	cmd.Flags().StringVar(&fields.Extension, "extension", "mjs", msg.FlagExtension)
+	cmd.Flags().BoolVar(&fields.Force, "force", false, msg.FlagForce)
+	cmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewImportCmd(f))
+}
+
+func (cmd *ImportCmd) Run(fields *Fields) error {
+	msgs := []string{}
+	logger.Debug("Running config import command")
+
+	if len(fields.ApplicationIDs) == 0 && fields.WorkloadID == 0 && len(fields.FirewallIDs) == 0 {
+		return msg.ErrorNoResource
+	}
+	if !slices.Contains([]string{"mjs", "cjs", "js", "ts"}, fields.Extension) {
+		return msg.ErrorInvalidExtension
+	}
+
+	wd, err := cmd.GetWorkDir()
+	if err != nil {
+		return err
+	}
+
+	if !fields.Force {
+		for _, ext := range []string{".js", ".ts", ".mjs", ".cjs"} {
+			if _, err := cmd.Stat(path.Join(wd, "azion.config"+ext)); err == nil {
+				return msg.ErrorAzionConfigExists
+			}
+		}
+	}
+
+	lock, err := state.Acquire(fields.ConfigDir, "config import")
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	conf, err := cmd.GetAzionJsonContent(fields.ConfigDir)
+	if err != nil {
+		if !errors.Is(err, utils.ErrorOpeningAzionJsonFile) {
+			return err
+		}
+		conf = &contracts.AzionApplicationOptions{}
+	}
+	if tracksResources(conf) {
+		if !fields.Force {
+			return msg.ErrorProjectNotEmpty
+		}
+		conf = &contracts.AzionApplicationOptions{
+			Name:   conf.Name,
+			Bucket: conf.Bucket,
+			Preset: conf.Preset,
+			Env:    conf.Env,
+			Prefix: conf.Prefix,
+			State:  conf.State,
+		}
+	}
+
+	rc := manifest.NewResourceContext(cmd.F, conf, &contracts.ManifestV4{}, fields.ConfigDir, &msgs, cmd.WriteAzionJsonContent)
+	result, err := cmd.Import(rc, manifest.ImportIDs{
+		Applications: fields.ApplicationIDs,
+		Workload:     fields.WorkloadID,
+		Firewalls:    fields.FirewallIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.writeFunctions(wd, result.Code, fields.Force); err != nil {
+		return err
+	}
+
+	if err := cmd.WriteAzionJsonContent(rc.Conf, fields.ConfigDir); err != nil {
+		logger.Debug("Error while writing azion.json file", zap.Error(err))
+		return err
+	}
+
+	if err := cmd.WriteManifest(result.Manifest, wd); err != nil {
+		return err
+	}
+	defer os.Remove(path.Join(wd, "manifesttoconvert.json"))
+
+	fileName := fmt.Sprintf("azion.config.%s", fields.Extension)
+	vul := vulcanPkg.NewVulcan()
+	command := vul.Command("", "manifest transform --output %s --entry %s", cmd.F)
+	if err := cmd.CommandRunInteractive(cmd.F, fmt.Sprintf(command, fileName, "manifesttoconvert.json")); err != nil {
+		return err
+	}
+
+	importOut := output.GeneralOutput{
+		Msg:   fmt.Sprintf(msg.Imported, fileName),
+		Out:   cmd.F.IOStreams.Out,
+		Flags: cmd.F.Flags,
+	}
+	return output.Print(&importOut)
+}
+
+// writeFunctions writes the code of the imported functions. Existing files are kept unless force is set,
+// since they may hold the source the deployed code was built from.
+func (cmd *ImportCmd) writeFunctions(wd string, code map[string]string, force bool) error {
+	paths := make([]string, 0, len(code))
+	for p := range code {
+		paths = append(paths, p)
+	}
+	slices.Sort(paths)
+
+	for _, p := range paths {
+		fileName := path.Join(wd, p)
+		if _, err := cmd.Stat(fileName); err == nil && !force {
+			logger.FInfoFlags(cmd.F.IOStreams.Out, fmt.Sprintf(msg.KeepingFunction, p), cmd.F.Format, cmd.F.Out)
+			continue
+		}
+		if err := cmd.MkdirAll(path.Dir(fileName), os.ModePerm); err != nil {
+			return fmt.Errorf(msg.ErrorWritingFunction, p, err)
+		}
+		if err := cmd.WriteFile(fileName, []byte(code[p]), 0644); err != nil {
+			return fmt.Errorf(msg.ErrorWritingFunction, p, err)
+		}
+	}
+	return nil
+}
+
+// tracksResources reports whether azion.json already refers to resources created on Azion Platform
+func tracksResources(conf *contracts.AzionApplicationOptions) bool {
+	return conf.Application.ID > 0 || len(conf.Applications) > 0 || len(conf.Function) > 0 ||
+		conf.Workloads.Id > 0 || len(conf.Firewalls) > 0 || len(conf.Connectors) > 0
+}
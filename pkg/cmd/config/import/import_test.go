@@ -0,0 +1,125 @@
+package configimport
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	msg "github.com/aziontech/azion-cli/messages/config/import"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/manifest"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+type project struct {
+	files    map[string]string
+	conf     *contracts.AzionApplicationOptions
+	commands []string
+	ids      manifest.ImportIDs
+}
+
+func newTestImportCmd(f *cmdutil.Factory, p *project) *ImportCmd {
+	cmd := NewImportCmd(f)
+	cmd.GetWorkDir = func() (string, error) { return "/project", nil }
+	cmd.Stat = func(name string) (fs.FileInfo, error) {
+		if _, ok := p.files[name]; ok {
+			return nil, nil
+		}
+		return nil, os.ErrNotExist
+	}
+	cmd.MkdirAll = func(path string, perm fs.FileMode) error { return nil }
+	cmd.WriteFile = func(name string, data []byte, perm fs.FileMode) error {
+		p.files[name] = string(data)
+		return nil
+	}
+	cmd.GetAzionJsonContent = func(confPath string) (*contracts.AzionApplicationOptions, error) {
+		if p.conf == nil {
+			return nil, utils.ErrorOpeningAzionJsonFile
+		}
+		return p.conf, nil
+	}
+	cmd.WriteAzionJsonContent = func(conf *contracts.AzionApplicationOptions, confPath string) error {
+		p.conf = conf
+		return nil
+	}
+	cmd.WriteManifest = func(manifest *contracts.ManifestV4, pathMan string) error { return nil }
+	cmd.CommandRunInteractive = func(f *cmdutil.Factory, comm string) error {
+		p.commands = append(p.commands, comm)
+		return nil
+	}
+	cmd.Import = func(rc *manifest.ResourceContext, ids manifest.ImportIDs) (*manifest.ImportResult, error) {
+		p.ids = ids
+		rc.Conf.Name = "site"
+		rc.Conf.Application = contracts.AzionJsonDataApplication{ID: 10, Name: "site"}
+		return &manifest.ImportResult{
+			Manifest: &contracts.ManifestV4{},
+			Code:     map[string]string{"./functions/handler.js": "export default {}"},
+		}, nil
+	}
+	return cmd
+}
+
+func TestImport(t *testing.T) {
+	t.Run("import application", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		p := &project{files: map[string]string{}}
+
+		cmd := NewCobraCmd(newTestImportCmd(f, p))
+		cmd.SetArgs([]string{"--application-id", "10", "--workload-id", "30", "--extension", "ts", "--config-dir", t.TempDir()})
+		require.NoError(t, cmd.Execute())
+
+		require.Equal(t, manifest.ImportIDs{Applications: []int64{10}, Workload: 30}, p.ids)
+		require.Equal(t, int64(10), p.conf.Application.ID)
+		require.Equal(t, "export default {}", p.files["/project/functions/handler.js"])
+		require.Len(t, p.commands, 1)
+		require.True(t, strings.Contains(p.commands[0], "manifest transform --output azion.config.ts --entry manifesttoconvert.json"))
+		require.Contains(t, stdout.String(), "azion.config.ts")
+	})
+
+	t.Run("existing function files are kept", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		p := &project{files: map[string]string{"/project/functions/handler.js": "source"}}
+
+		err := newTestImportCmd(f, p).Run(&Fields{ApplicationIDs: []int64{10}, ConfigDir: t.TempDir(), Extension: "mjs"})
+		require.NoError(t, err)
+		require.Equal(t, "source", p.files["/project/functions/handler.js"])
+		require.Contains(t, stdout.String(), "already exists")
+	})
+
+	t.Run("nothing to import", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(nil)
+		err := newTestImportCmd(f, &project{}).Run(&Fields{ConfigDir: ".", Extension: "mjs"})
+		require.ErrorIs(t, err, msg.ErrorNoResource)
+	})
+
+	t.Run("azion.config is not overwritten", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(nil)
+		p := &project{files: map[string]string{"/project/azion.config.js": ""}}
+		err := newTestImportCmd(f, p).Run(&Fields{ApplicationIDs: []int64{10}, ConfigDir: t.TempDir(), Extension: "mjs"})
+		require.ErrorIs(t, err, msg.ErrorAzionConfigExists)
+	})
+
+	t.Run("project already tracks resources", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(nil)
+		p := &project{files: map[string]string{}, conf: &contracts.AzionApplicationOptions{
+			Name:        "project",
+			Application: contracts.AzionJsonDataApplication{ID: 5, Name: "old"},
+		}}
+		fields := &Fields{ApplicationIDs: []int64{10}, ConfigDir: t.TempDir(), Extension: "mjs"}
+		require.ErrorIs(t, newTestImportCmd(f, p).Run(fields), msg.ErrorProjectNotEmpty)
+
+		fields.Force = true
+		require.NoError(t, newTestImportCmd(f, p).Run(fields))
+		require.Equal(t, int64(10), p.conf.Application.ID)
+	})
+}
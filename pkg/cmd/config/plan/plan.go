@@ -0,0 +1,208 @@
+package plan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/config/plan"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/command"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/manifest"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/state"
+	vulcanPkg "github.com/aziontech/azion-cli/pkg/vulcan"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type PlanCmd struct {
+	GetWorkDir            func() (string, error)
+	Stat                  func(name string) (fs.FileInfo, error)
+	WriteFile             func(filename string, data []byte, perm fs.FileMode) error
+	GetAzionJsonContent   func(confPath string) (*contracts.AzionApplicationOptions, error)
+	WriteAzionJsonContent func(conf *contracts.AzionApplicationOptions, confPath string) error
+	Interpreter           func() *manifest.ManifestInterpreter
+	F                     *cmdutil.Factory
+	CommandRunInteractive func(f *cmdutil.Factory, comm string) error
+}
+
+type Fields struct {
+	ConfigDir string
+	Save      string
+}
+
+func NewPlanCmd(f *cmdutil.Factory) *PlanCmd {
+	store := state.New(f)
+	return &PlanCmd{
+		GetWorkDir:            utils.GetWorkingDir,
+		Stat:                  os.Stat,
+		WriteFile:             os.WriteFile,
+		GetAzionJsonContent:   store.Read,
+		WriteAzionJsonContent: store.Write,
+		Interpreter:           manifest.NewManifestInterpreter,
+		F:                     f,
+		CommandRunInteractive: command.CommandRunInteractive,
+	}
+}
+
+func NewCobraCmd(plan *PlanCmd) *cobra.Command {
+	fields := &Fields{}
+
+	cmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+        $ azion config plan
+        $ azion config plan --format json
+        $ azion config plan --save plan.json
+        `),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return plan.Run(fields)
+		},
+	}
+
+	cmd.Flags().StringVar(&fields.ConfigDir, "config-dir", ".", msg.FlagConfigDir)
+	cmd.Flags().StringVar(&fields.Save, "save", "", msg.FlagSave)
+	cmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewPlanCmd(f))
+}
+
+func (cmd *PlanCmd) Run(fields *Fields) error {
+	msgs := []string{}
+	logger.Debug("Running config plan command")
+
+	wd, err := cmd.GetWorkDir()
+	if err != nil {
+		return err
+	}
+
+	azionConfigFound := false
+	for _, ext := range []string{".js", ".ts", ".mjs", ".cjs"} {
+		if _, err := cmd.Stat(path.Join(wd, "azion.config"+ext)); err == nil {
+			azionConfigFound = true
+			break
+		}
+	}
+	if !azionConfigFound {
+		logger.FInfoFlags(cmd.F.IOStreams.Out, msg.AzionConfigNotFound, cmd.F.Format, cmd.F.Out)
+		return msg.ErrorAzionConfigNotFound
+	}
+
+	vul := vulcanPkg.NewVulcan()
+	command := vul.Command("", "manifest generate", cmd.F)
+	logger.Debug("Running the following command", zap.Any("Command", command))
+	if err := cmd.CommandRunInteractive(cmd.F, command); err != nil {
+		return err
+	}
+
+	conf, err := cmd.GetAzionJsonContent(fields.ConfigDir)
+	if err != nil {
+		if !errors.Is(err, utils.ErrorOpeningAzionJsonFile) {
+			return err
+		}
+		// nothing was applied yet, so every resource is going to be created
+		conf = &contracts.AzionApplicationOptions{}
+	}
+
+	interpreter := cmd.Interpreter()
+	manifestPath, err := interpreter.ManifestPath()
+	if err != nil {
+		return err
+	}
+
+	manifestStructure, err := interpreter.ReadManifest(manifestPath, cmd.F, &msgs)
+	if err != nil {
+		logger.Debug("Error reading manifest", zap.Error(err))
+		return msg.ErrorReadingManifest
+	}
+
+	if err := manifest.ValidateApplications(manifestStructure.Applications); err != nil {
+		return err
+	}
+
+	rc := manifest.NewResourceContext(cmd.F, conf, manifestStructure, fields.ConfigDir, &msgs, cmd.WriteAzionJsonContent)
+	plan, err := rc.Plan(true)
+	if err != nil {
+		return err
+	}
+
+	if fields.Save != "" {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := cmd.WriteFile(fields.Save, data, 0644); err != nil {
+			logger.Debug("Error while saving the plan", zap.Error(err))
+			return fmt.Errorf("%s: %w", msg.ErrorSavingPlan, err)
+		}
+	}
+
+	return output.Print(planOutput(cmd.F, plan, fields.Save))
+}
+
+// planOutput lists the changes of a plan as a table followed by a summary. Resources left as they are
+// are only counted.
+func planOutput(f *cmdutil.Factory, plan *manifest.Plan, savedTo string) *output.ChangeSetOutput {
+	counts := make(map[string]int)
+	lines := make([][]string, 0, len(plan.Changes))
+	for _, change := range plan.Changes {
+		counts[change.Action]++
+		if change.Action == manifest.ActionNoop {
+			continue
+		}
+		id := "-"
+		if change.ID > 0 {
+			id = strconv.FormatInt(change.ID, 10)
+		}
+		application := change.Application
+		if application == "" || change.Kind == "application" {
+			application = "-"
+		}
+		fields := "-"
+		if len(change.Fields) > 0 {
+			names := make([]string, 0, len(change.Fields))
+			for _, field := range change.Fields {
+				names = append(names, field.Field)
+			}
+			fields = strings.Join(names, ", ")
+		}
+		lines = append(lines, []string{change.Action, change.KindName(), change.Name, id, application, fields})
+	}
+
+	summary := msg.NoChanges
+	if len(lines) > 0 {
+		summary = fmt.Sprintf(msg.PlanSummary, counts[manifest.ActionCreate], counts[manifest.ActionUpdate], counts[manifest.ActionDelete], counts[manifest.ActionNoop])
+	}
+	if savedTo != "" {
+		summary += fmt.Sprintf(msg.PlanSaved, savedTo, savedTo)
+	}
+
+	return &output.ChangeSetOutput{
+		GeneralOutput: output.GeneralOutput{
+			Msg:   summary,
+			Out:   f.IOStreams.Out,
+			Flags: f.Flags,
+		},
+		ChangeSet: plan,
+		Columns:   []string{"ACTION", "KIND", "NAME", "ID", "APPLICATION", "FIELDS"},
+		Lines:     lines,
+	}
+}
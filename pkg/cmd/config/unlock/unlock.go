@@ -0,0 +1,82 @@
+package unlock
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/config/unlock"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/state"
+	"github.com/spf13/cobra"
+)
+
+type UnlockCmd struct {
+	F      *cmdutil.Factory
+	Unlock func(confPath string, force bool) (*state.LockInfo, error)
+}
+
+type Fields struct {
+	ConfigDir string
+	Force     bool
+}
+
+func NewUnlockCmd(f *cmdutil.Factory) *UnlockCmd {
+	return &UnlockCmd{
+		F:      f,
+		Unlock: state.Unlock,
+	}
+}
+
+func NewCobraCmd(unlock *UnlockCmd) *cobra.Command {
+	fields := &Fields{}
+
+	cmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+        $ azion config unlock
+        $ azion config unlock --force
+        $ azion config unlock --config-dir azion
+        `),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return unlock.Run(fields)
+		},
+	}
+
+	cmd.Flags().StringVar(&fields.ConfigDir, "config-dir", ".", msg.FlagConfigDir)
+	cmd.Flags().BoolVar(&fields.Force, "force", false, msg.FlagForce)
+	cmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewUnlockCmd(f))
+}
+
+func (cmd *UnlockCmd) Run(fields *Fields) error {
+	logger.Debug("Running config unlock command")
+
+	holder, err := cmd.Unlock(fields.ConfigDir, fields.Force)
+	if err != nil {
+		return err
+	}
+
+	message := msg.NotLocked
+	if holder != nil {
+		message = fmt.Sprintf(msg.Unlocked, holder.Command, holder.PID, holder.Hostname, holder.StartedAt.Format(time.RFC3339))
+	}
+
+	unlockOut := output.GeneralOutput{
+		Msg:   message,
+		Out:   cmd.F.IOStreams.Out,
+		Flags: cmd.F.Flags,
+	}
+	return output.Print(&unlockOut)
+}
@@ -0,0 +1,45 @@
+package unlock
+
+import (
+	"testing"
+	"time"
+
+	msg "github.com/aziontech/azion-cli/messages/config/unlock"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/state"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+func TestUnlock(t *testing.T) {
+	t.Run("removes the lock", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		unlockCmd := NewUnlockCmd(f)
+		unlockCmd.Unlock = func(confPath string, force bool) (*state.LockInfo, error) {
+			require.Equal(t, "azion", confPath)
+			require.True(t, force)
+			return &state.LockInfo{PID: 42, Hostname: "ci-runner-2", Command: "deploy", StartedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}, nil
+		}
+
+		cmd := NewCobraCmd(unlockCmd)
+		cmd.SetArgs([]string{"--config-dir", "azion", "--force"})
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, stdout.String(), "Removed the lock held by 'deploy' (PID 42 on ci-runner-2, started at 2026-01-02T03:04:05Z)")
+	})
+
+	t.Run("not locked", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		unlockCmd := NewUnlockCmd(f)
+		unlockCmd.Unlock = func(confPath string, force bool) (*state.LockInfo, error) {
+			return nil, nil
+		}
+
+		require.NoError(t, unlockCmd.Run(&Fields{ConfigDir: "."}))
+		require.Contains(t, stdout.String(), msg.NotLocked)
+	})
+}
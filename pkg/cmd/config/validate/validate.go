@@ -0,0 +1,169 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/config/validate"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/manifest"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/schema"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type ValidateCmd struct {
+	GetWorkDir  func() (string, error)
+	ReadFile    func(name string) ([]byte, error)
+	Interpreter func() *manifest.ManifestInterpreter
+	F           *cmdutil.Factory
+}
+
+type Fields struct {
+	ConfigDir string
+	Manifest  string
+}
+
+// FileProblem is a problem found in one of the validated files
+type FileProblem struct {
+	File string `json:"file"`
+	schema.Problem
+}
+
+// Result is what the validate command reports when a format is requested
+type Result struct {
+	Valid    bool          `json:"valid"`
+	Problems []FileProblem `json:"problems"`
+}
+
+func NewValidateCmd(f *cmdutil.Factory) *ValidateCmd {
+	return &ValidateCmd{
+		GetWorkDir:  utils.GetWorkingDir,
+		ReadFile:    os.ReadFile,
+		Interpreter: manifest.NewManifestInterpreter,
+		F:           f,
+	}
+}
+
+func NewCobraCmd(validate *ValidateCmd) *cobra.Command {
+	fields := &Fields{}
+
+	cmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+        $ azion config validate
+        $ azion config validate --format json
+        $ azion config validate --manifest ./dist/manifest.json --config-dir ./my-project
+        `),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return validate.Run(fields)
+		},
+	}
+
+	cmd.Flags().StringVar(&fields.ConfigDir, "config-dir", ".", msg.FlagConfigDir)
+	cmd.Flags().StringVar(&fields.Manifest, "manifest", "", msg.FlagManifest)
+	cmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewValidateCmd(f))
+}
+
+func (cmd *ValidateCmd) Run(fields *Fields) error {
+	logger.Debug("Running config validate command")
+
+	manifestPath := fields.Manifest
+	if manifestPath == "" {
+		var err error
+		manifestPath, err = cmd.Interpreter().ManifestPath()
+		if err != nil {
+			return err
+		}
+	}
+
+	manifestContent, err := cmd.ReadFile(manifestPath)
+	if err != nil {
+		logger.Debug("Error reading manifest", zap.String("path", manifestPath), zap.Error(err))
+		if errors.Is(err, fs.ErrNotExist) {
+			return msg.ErrorManifestNotFound
+		}
+		return fmt.Errorf(msg.ErrorReadingFile, "manifest.json", err)
+	}
+
+	result := Result{Problems: []FileProblem{}}
+	result.add("manifest.json", manifest.ValidateManifest(manifestContent))
+
+	wd, err := cmd.GetWorkDir()
+	if err != nil {
+		return err
+	}
+
+	// azion.json only exists once the project was deployed, so it is validated when found
+	summary := msg.Valid
+	azionContent, err := cmd.ReadFile(path.Join(wd, fields.ConfigDir, "azion.json"))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		summary = msg.ValidManifest
+	case err != nil:
+		return fmt.Errorf(msg.ErrorReadingFile, "azion.json", err)
+	default:
+		result.add("azion.json", manifest.ValidateAzionJson(azionContent))
+	}
+
+	result.Valid = len(result.Problems) == 0
+	if !result.Valid {
+		summary = fmt.Sprintf(msg.ProblemsFound, len(result.Problems))
+	}
+
+	if err := output.Print(resultOutput(cmd.F, &result, summary)); err != nil {
+		return err
+	}
+
+	if !result.Valid {
+		return fmt.Errorf(msg.ErrorInvalid, len(result.Problems))
+	}
+	return nil
+}
+
+func (r *Result) add(file string, problems []schema.Problem) {
+	for _, problem := range problems {
+		r.Problems = append(r.Problems, FileProblem{File: file, Problem: problem})
+	}
+}
+
+// resultOutput lists the problems found as a table followed by a summary
+func resultOutput(f *cmdutil.Factory, result *Result, summary string) *output.ChangeSetOutput {
+	lines := make([][]string, 0, len(result.Problems))
+	for _, problem := range result.Problems {
+		pointer := problem.Pointer
+		if pointer == "" {
+			pointer = "/"
+		}
+		lines = append(lines, []string{problem.File, strconv.Itoa(problem.Line), pointer, problem.Message})
+	}
+
+	return &output.ChangeSetOutput{
+		GeneralOutput: output.GeneralOutput{
+			Msg:   summary,
+			Out:   f.IOStreams.Out,
+			Flags: f.Flags,
+		},
+		ChangeSet: result,
+		Columns:   []string{"FILE", "LINE", "POINTER", "PROBLEM"},
+		Lines:     lines,
+	}
+}
@@ -0,0 +1,117 @@
+package validate
+
+import (
+	"encoding/json"
+	"io/fs"
+	"path"
+	"testing"
+
+	msg "github.com/aziontech/azion-cli/messages/config/validate"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/manifest"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+const validManifest = `{"functions": [{"name": "handler", "path": "./handler.js"}]}`
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		args        []string
+		expectedErr string
+		output      []string
+	}{
+		{
+			name: "valid files",
+			files: map[string]string{
+				"/project/.edge/manifest.json": validManifest,
+				"/project/azion.json":          `{"name": "project"}`,
+			},
+			output: []string{msg.Valid},
+		},
+		{
+			name:   "azion.json not found",
+			files:  map[string]string{"/project/.edge/manifest.json": validManifest},
+			output: []string{msg.ValidManifest},
+		},
+		{
+			name:        "manifest.json not found",
+			files:       map[string]string{},
+			expectedErr: msg.ErrorManifestNotFound.Error(),
+		},
+		{
+			name: "problems of both files are listed",
+			files: map[string]string{
+				"/project/dist/manifest.json": "{\n  \"functions\": [{\"name\": \"handler\"}]\n}",
+				"/project/azion.json":         `{"name": 1}`,
+			},
+			args:        []string{"--manifest", "/project/dist/manifest.json"},
+			expectedErr: "Validation failed: 2 problem(s) found",
+			output:      []string{"/functions/0", `missing required property "path"`, "/name", "expected string, found integer"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, stdout, _ := testutils.NewFactory(nil)
+
+			validateCmd := NewValidateCmd(f)
+			validateCmd.GetWorkDir = func() (string, error) { return "/project", nil }
+			validateCmd.ReadFile = func(name string) ([]byte, error) {
+				content, ok := tt.files[path.Clean(name)]
+				if !ok {
+					return nil, fs.ErrNotExist
+				}
+				return []byte(content), nil
+			}
+			validateCmd.Interpreter = func() *manifest.ManifestInterpreter {
+				interpreter := manifest.NewManifestInterpreter()
+				interpreter.GetWorkDir = validateCmd.GetWorkDir
+				return interpreter
+			}
+
+			cmd := NewCobraCmd(validateCmd)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			for _, expected := range tt.output {
+				require.Contains(t, stdout.String(), expected)
+			}
+		})
+	}
+
+	t.Run("problems are printed in the requested format", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		f.Flags.Format = "json"
+
+		validateCmd := NewValidateCmd(f)
+		validateCmd.ReadFile = func(name string) ([]byte, error) {
+			if path.Base(name) == "azion.json" {
+				return nil, fs.ErrNotExist
+			}
+			return []byte(`{"functions": [{"name": "handler"}]}`), nil
+		}
+
+		err := validateCmd.Run(&Fields{ConfigDir: ".", Manifest: "manifest.json"})
+		require.Error(t, err)
+
+		result := Result{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		require.False(t, result.Valid)
+		require.Len(t, result.Problems, 1)
+		require.Equal(t, "manifest.json", result.Problems[0].File)
+		require.Equal(t, "/functions/0", result.Problems[0].Pointer)
+	})
+}
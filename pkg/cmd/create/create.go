@@ -14,6 +14,7 @@ import (
 	firewallRules "github.com/aziontech/azion-cli/pkg/cmd/create/firewall_rules"
 	edgeFunction "github.com/aziontech/azion-cli/pkg/cmd/create/function"
 	functionInstance "github.com/aziontech/azion-cli/pkg/cmd/create/function_instance"
+	kv "github.com/aziontech/azion-cli/pkg/cmd/create/kv"
 	networkList "github.com/aziontech/azion-cli/pkg/cmd/create/network_list"
 	origin "github.com/aziontech/azion-cli/pkg/cmd/create/origin"
 	token "github.com/aziontech/azion-cli/pkg/cmd/create/personal_token"
@@ -60,7 +61,7 @@ func NewCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.AddCommand(functionInstance.NewCmd(f))
 	cmd.AddCommand(profile.NewCmd(f))
 	cmd.AddCommand(networkList.NewCmd(f))
-	// cmd.AddCommand(kv.NewCmd(f))
+	cmd.AddCommand(kv.NewCmd(f))
 	cmd.AddCommand(firewall.NewCmd(f))
 	cmd.AddCommand(firewallInstance.NewCmd(f))
 	cmd.AddCommand(firewallRules.NewCmd(f))
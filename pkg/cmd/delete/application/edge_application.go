@@ -15,6 +15,7 @@ import (
 	"github.com/aziontech/azion-cli/pkg/iostreams"
 	"github.com/aziontech/azion-cli/pkg/logger"
 	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/state"
 	"github.com/aziontech/azion-cli/utils"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -38,15 +39,16 @@ func NewCmd(f *cmdutil.Factory) *cobra.Command {
 }
 
 func NewDeleteCmd(f *cmdutil.Factory) *DeleteCmd {
+	store := state.New(f)
 	return &DeleteCmd{
 		Io:                    f.IOStreams,
-		GetAzion:              utils.GetAzionJsonContent,
+		GetAzion:              store.Read,
 		f:                     f,
 		UpdateJson:            updateAzionJson,
 		Cascade:               CascadeDelete,
 		AskInput:              utils.AskInput,
 		ReadFile:              os.ReadFile,
-		WriteAzionJsonContent: utils.WriteAzionJsonContent,
+		WriteAzionJsonContent: store.Write,
 	}
 }
 
@@ -14,6 +14,7 @@ import (
 	firewallRules "github.com/aziontech/azion-cli/pkg/cmd/delete/firewall_rules"
 	function "github.com/aziontech/azion-cli/pkg/cmd/delete/function"
 	functionInstance "github.com/aziontech/azion-cli/pkg/cmd/delete/function_instance"
+	kv "github.com/aziontech/azion-cli/pkg/cmd/delete/kv"
 	networkList "github.com/aziontech/azion-cli/pkg/cmd/delete/network_list"
 	origin "github.com/aziontech/azion-cli/pkg/cmd/delete/origin"
 	token "github.com/aziontech/azion-cli/pkg/cmd/delete/personal_token"
@@ -66,6 +67,7 @@ func NewCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.AddCommand(digitalCertificate.NewCmd(f))
 	cmd.AddCommand(csr.NewCmd(f))
 	cmd.AddCommand(crl.NewCmd(f))
+	cmd.AddCommand(kv.NewCmd(f))
 
 	cmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
 	return cmd
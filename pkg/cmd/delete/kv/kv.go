@@ -0,0 +1,83 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/delete/kv"
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/iostreams"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	namespace string
+)
+
+type DeleteCmd struct {
+	Io       *iostreams.IOStreams
+	AskInput func(string) (string, error)
+	Delete   func(ctx context.Context, namespace string) error
+}
+
+func NewDeleteCmd(f *cmdutil.Factory) *DeleteCmd {
+	return &DeleteCmd{
+		Io: f.IOStreams,
+		AskInput: func(prompt string) (string, error) {
+			return utils.AskInput(prompt)
+		},
+		Delete: func(ctx context.Context, namespace string) error {
+			client := api.NewClient(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
+			return client.Delete(ctx, namespace)
+		},
+	}
+}
+
+func NewCobraCmd(delete *DeleteCmd, f *cmdutil.Factory) *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+		$ azion delete kv --namespace "my-namespace"
+		`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !cmd.Flags().Changed("namespace") {
+				answer, err := delete.AskInput(msg.AskInputNamespace)
+				if err != nil {
+					logger.Debug("Error while parsing answer", zap.Error(err))
+					return utils.ErrorParseResponse
+				}
+				namespace = answer
+			}
+
+			if err := delete.Delete(context.Background(), namespace); err != nil {
+				return fmt.Errorf(msg.ErrorDeleteNamespace, err.Error())
+			}
+
+			deleteOut := output.GeneralOutput{
+				Msg:   fmt.Sprintf(msg.DeleteOutputSuccess, namespace),
+				Out:   f.IOStreams.Out,
+				Flags: f.Flags,
+			}
+			return output.Print(&deleteOut)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&namespace, "namespace", "", msg.FlagNamespace)
+	cobraCmd.Flags().BoolP("help", "h", false, msg.HelpFlag)
+
+	return cobraCmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewDeleteCmd(f), f)
+}
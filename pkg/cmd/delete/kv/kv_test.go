@@ -0,0 +1,78 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	msg "github.com/aziontech/azion-cli/messages/delete/kv"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestDelete(t *testing.T) {
+	logger.New(zapcore.DebugLevel)
+
+	tests := []struct {
+		name           string
+		args           []string
+		askInput       func(string) (string, error)
+		deleteErr      error
+		expectedOutput string
+		expectedErr    string
+	}{
+		{
+			name:           "delete namespace",
+			args:           []string{"--namespace", "my-namespace"},
+			expectedOutput: fmt.Sprintf(msg.DeleteOutputSuccess, "my-namespace"),
+		},
+		{
+			name: "ask for the namespace",
+			askInput: func(string) (string, error) {
+				return "my-namespace", nil
+			},
+			expectedOutput: fmt.Sprintf(msg.DeleteOutputSuccess, "my-namespace"),
+		},
+		{
+			name: "error in input",
+			askInput: func(string) (string, error) {
+				return "", errors.New("interrupted")
+			},
+			expectedErr: utils.ErrorParseResponse.Error(),
+		},
+		{
+			name:        "namespace not found",
+			args:        []string{"--namespace", "my-namespace"},
+			deleteErr:   utils.ErrorNotFound404,
+			expectedErr: fmt.Sprintf(msg.ErrorDeleteNamespace, utils.ErrorNotFound404.Error()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, stdout, _ := testutils.NewFactory(nil)
+
+			deleteCmd := NewDeleteCmd(f)
+			deleteCmd.AskInput = tt.askInput
+			deleteCmd.Delete = func(ctx context.Context, name string) error {
+				require.Equal(t, "my-namespace", name)
+				return tt.deleteErr
+			}
+
+			cobraCmd := NewCobraCmd(deleteCmd, f)
+			cobraCmd.SetArgs(tt.args)
+
+			err := cobraCmd.Execute()
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedOutput, stdout.String())
+		})
+	}
+}
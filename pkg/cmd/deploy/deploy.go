@@ -14,6 +14,7 @@ import (
 
 	"github.com/MakeNowJust/heredoc"
 	msg "github.com/aziontech/azion-cli/messages/deploy"
+	"github.com/aziontech/azion-cli/pkg/api/s3"
 	"github.com/aziontech/azion-cli/pkg/api/storage"
 	"github.com/aziontech/azion-cli/pkg/cmd/build"
 	deploy "github.com/aziontech/azion-cli/pkg/cmd/deploy_remote"
@@ -23,6 +24,7 @@ import (
 	"github.com/aziontech/azion-cli/pkg/iostreams"
 	"github.com/aziontech/azion-cli/pkg/logger"
 	manifestInt "github.com/aziontech/azion-cli/pkg/manifest"
+	"github.com/aziontech/azion-cli/pkg/state"
 	"github.com/aziontech/azion-cli/pkg/token"
 	"github.com/aziontech/azion-cli/utils"
 	storagesdk "github.com/aziontech/azionapi-v4-go-sdk-dev/storage-api"
@@ -54,6 +56,7 @@ type DeployCmd struct {
 	ReadSettings          func(path string) (token.Settings, error)
 	UploadFiles           func(f *cmdutil.Factory, conf *contracts.AzionApplicationOptions, msgs *[]string, pathStatic, bucket string, cmd *DeployCmd, settings token.Settings) error
 	OpenBrowserFunc       func(input string) error
+	RunHooks              func(stage string, hooks *contracts.AzionJsonHooks, conf *contracts.AzionApplicationOptions, vars []string, msgs *[]string) error
 }
 
 var (
@@ -69,21 +72,30 @@ var (
 	Env           string
 	SkipFramework bool
 	Workers       int
+	NoRollback    bool
 	Logs          = contracts.Logs{}
 	Result        = contracts.ResultsV4{}
 	DeployURL     = "https://console.azion.com"
 	ScriptID      = "17ac912d-5ce9-4806-9fa7-480779e43f58"
+
+	// MultipartThreshold is the size in MB from which static files are uploaded in parts by local deploys
+	MultipartThreshold int64
+	Resume             bool
+	ListFiles          bool
+	Canary             int64
+	KeepVersions       int
 )
 
 func NewDeployCmd(f *cmdutil.Factory) *DeployCmd {
+	store := state.New(f)
 	return &DeployCmd{
 		Io:                    f.IOStreams,
 		GetWorkDir:            utils.GetWorkingDir,
 		FileReader:            os.ReadFile,
 		WriteFile:             os.WriteFile,
 		BuildCmd:              build.NewBuildCmd,
-		GetAzionJsonContent:   utils.GetAzionJsonContent,
-		WriteAzionJsonContent: utils.WriteAzionJsonContent,
+		GetAzionJsonContent:   store.Read,
+		WriteAzionJsonContent: store.Write,
 		WriteAzionConfig:      utils.WriteAzionConfig,
 		Open:                  os.Open,
 		FilepathWalk:          filepath.Walk,
@@ -98,6 +110,7 @@ func NewDeployCmd(f *cmdutil.Factory) *DeployCmd {
 		CheckToken:            checkToken,
 		OpenBrowserFunc:       open.Run,
 		ReadSettings:          token.ReadSettings,
+		RunHooks:              deploy.NewDeployCmd(f).RunHooks,
 	}
 }
 
@@ -130,6 +143,12 @@ func NewCobraCmd(deploy *DeployCmd) *cobra.Command {
 	deployCmd.Flags().StringVar(&Env, "env", ".edge/.env", msg.EnvFlag)
 	deployCmd.Flags().BoolVar(&SkipFramework, "skip-framework-build", false, msg.SkipFrameworkBuild)
 	deployCmd.Flags().IntVar(&Workers, "workers", 0, msg.WorkersFlag)
+	deployCmd.Flags().BoolVar(&NoRollback, "no-rollback", false, msg.NoRollbackFlag)
+	deployCmd.Flags().BoolVar(&Resume, "resume", false, msg.ResumeFlag)
+	deployCmd.Flags().Int64Var(&Canary, "canary", 0, msg.CanaryFlag)
+	deployCmd.Flags().IntVar(&KeepVersions, "keep-versions", 0, msg.KeepVersionsFlag)
+	deployCmd.Flags().BoolVar(&ListFiles, "list-files", false, msg.ListFilesFlag)
+	deployCmd.Flags().Int64Var(&MultipartThreshold, "multipart-threshold", s3.DefaultMultipartThreshold/(1024*1024), msg.MultipartThresholdFlag)
 	return deployCmd
 }
 
@@ -160,9 +179,52 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 		return dryStructure.SimulateDeploy(pathWorkingDir, ProjectConf)
 	}
 
+	if ListFiles {
+		if Local {
+			return deploy.NewDeployCmd(f).ListFiles(f)
+		}
+		return cmd.listFiles(f)
+	}
+
+	lock, err := state.Acquire(ProjectConf, "deploy")
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	if KeepVersions < 0 {
+		return msg.ErrorKeepVersions
+	}
+
 	if Local {
 		deployLocal := deploy.NewDeployCmd(f)
-		return deployLocal.ExternalRun(f, ProjectConf, Env, Sync, Auto, SkipBuild, WriteBucket, SkipFramework, Workers)
+		return deployLocal.ExternalRun(f, deploy.RunOptions{
+			ConfigPath:         ProjectConf,
+			Env:                Env,
+			Sync:               Sync,
+			Auto:               Auto,
+			SkipBuild:          SkipBuild,
+			WriteBucket:        WriteBucket,
+			SkipFramework:      SkipFramework,
+			NoRollback:         NoRollback,
+			Workers:            Workers,
+			MultipartThreshold: MultipartThreshold,
+			Resume:             Resume,
+			Canary:             Canary,
+			KeepVersions:       KeepVersions,
+		})
+	}
+
+	if Resume {
+		return msg.ErrorResumeRemote
+	}
+
+	if Canary != 0 {
+		return msg.ErrorCanaryRemote
+	}
+
+	if KeepVersions != 0 {
+		return msg.ErrorKeepVersionsRemote
 	}
 
 	msgs := []string{}
@@ -170,7 +232,7 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 	msgs = append(msgs, "Running deploy command")
 	ctx := context.Background()
 
-	err := cmd.CheckToken(f)
+	err = cmd.CheckToken(f)
 	if err != nil {
 		return err
 	}
@@ -186,6 +248,13 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 		return err
 	}
 
+	// the project is built and deployed on Azion Platform, so hooks only run around its upload and after the
+	// deploy, which cannot be rolled back from here
+	hooks := deploy.ProjectHooks(conf, deploy.BuiltManifest(cmd.Interpreter()))
+	if hooks != nil && (len(hooks.PostBuild) > 0 || hooks.RollbackOnFailure) {
+		return msg.ErrorHooksRemote
+	}
+
 	//create credentials if they are not found on settings file
 	if settings.S3AccessKey == "" || settings.S3SecretKey == "" {
 		bucketStart := time.Now()
@@ -230,10 +299,19 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 		return err
 	}
 
+	// variables handed to the hooks along with the ones describing the project
+	hookVars := []string{"AZION_PREVIOUS_PREFIX=" + conf.Prefix}
+
 	if conf.Prefix == "" || conf.RotatePrefix == nil || *conf.RotatePrefix == true {
 		conf.Prefix = cmd.VersionID()
 	}
 
+	for _, stage := range []string{deploy.HookPreBuild, deploy.HookPreUpload} {
+		if err := cmd.RunHooks(stage, hooks, conf, hookVars, &msgs); err != nil {
+			return err
+		}
+	}
+
 	uploadStart := time.Now()
 	err = cmd.UploadFiles(f, conf, &msgs, localDir, settings.S3Bucket, cmd, settings)
 	if err != nil {
@@ -241,6 +319,10 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 	}
 	GlobalTimingSummary.UploadFilesTime = time.Since(uploadStart)
 
+	if err := cmd.RunHooks(deploy.HookPostUpload, hooks, conf, hookVars, &msgs); err != nil {
+		return err
+	}
+
 	scriptStart := time.Now()
 	id, err := cmd.CallScript(settings.Token, settings.S3AccessKey, settings.S3SecretKey, conf.Prefix, settings.S3Bucket, ProjectConf, cmd)
 	if err != nil {
@@ -272,6 +354,10 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 		return err
 	}
 
+	if err := cmd.RunHooks(deploy.HookPostDeploy, hooks, conf, hookVars, &msgs); err != nil {
+		return err
+	}
+
 	logger.FInfoFlags(cmd.F.IOStreams.Out, msg.DeploySuccessful, f.Format, f.Out)
 	msgs = append(msgs, msg.DeploySuccessful)
 
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	msg "github.com/aziontech/azion-cli/messages/deploy"
 	"github.com/aziontech/azion-cli/pkg/cmdutil"
 	"github.com/aziontech/azion-cli/pkg/contracts"
 	"github.com/aziontech/azion-cli/pkg/httpmock"
@@ -206,6 +207,59 @@ func TestDeploy_Run(t *testing.T) {
 	}
 }
 
+func TestDeployHooks(t *testing.T) {
+	logger.New(zapcore.DebugLevel)
+
+	newCmd := func(t *testing.T, hooks *contracts.AzionJsonHooks, stages *[]string) *DeployCmd {
+		f, _, _ := testutils.NewFactory(nil)
+		cmd := NewDeployCmd(f)
+		cmd.GetWorkDir = MockGetWorkDir
+		cmd.CheckToken = MockCheckToken
+		cmd.ReadSettings = MockReadSettings
+		cmd.GetAzionJsonContent = func(pathConfig string) (*contracts.AzionApplicationOptions, error) {
+			return &contracts.AzionApplicationOptions{Name: "MockApp", Prefix: "MockPrefix", Hooks: hooks}, nil
+		}
+		cmd.WriteAzionJsonContent = MockWriteAzionJsonContent
+		cmd.UploadFiles = func(f *cmdutil.Factory, conf *contracts.AzionApplicationOptions, msgs *[]string, pathStatic, bucket string, cmd *DeployCmd, settings token.Settings) error {
+			*stages = append(*stages, "upload")
+			return nil
+		}
+		cmd.CallScript = MockCallScript
+		cmd.OpenBrowser = MockOpenBrowser
+		cmd.CaptureLogs = func(execId string, token string, cmd *DeployCmd) error {
+			*stages = append(*stages, "deploy")
+			return nil
+		}
+		cmd.RunHooks = func(stage string, hooks *contracts.AzionJsonHooks, conf *contracts.AzionApplicationOptions, vars []string, msgs *[]string) error {
+			require.Equal(t, []string{"AZION_PREVIOUS_PREFIX=MockPrefix"}, vars)
+			*stages = append(*stages, stage)
+			return nil
+		}
+		return cmd
+	}
+
+	t.Run("hooks run around the upload and after the deploy", func(t *testing.T) {
+		stages := []string{}
+		cmd := newCmd(t, &contracts.AzionJsonHooks{PreBuild: []string{"npm test"}, PostDeploy: []string{"./smoke.sh"}}, &stages)
+
+		require.NoError(t, cmd.Run(cmd.F))
+		require.Equal(t, []string{"pre-build", "pre-upload", "upload", "post-upload", "deploy", "post-deploy"}, stages)
+	})
+
+	t.Run("hooks that need a local build are refused", func(t *testing.T) {
+		for _, hooks := range []*contracts.AzionJsonHooks{
+			{PostBuild: []string{"npm run check"}},
+			{PostDeploy: []string{"./smoke.sh"}, RollbackOnFailure: true},
+		} {
+			stages := []string{}
+			cmd := newCmd(t, hooks, &stages)
+
+			require.ErrorIs(t, cmd.Run(cmd.F), msg.ErrorHooksRemote)
+			require.Empty(t, stages)
+		}
+	})
+}
+
 func TestCaptureLogs(t *testing.T) {
 	logger.New(zapcore.DebugLevel)
 	tests := []struct {
@@ -3,6 +3,7 @@ package deploy
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,7 +15,9 @@ import (
 	"github.com/aziontech/azion-cli/pkg/api/s3"
 	"github.com/aziontech/azion-cli/pkg/cmdutil"
 	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/ignore"
 	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
 	"github.com/aziontech/azion-cli/pkg/token"
 	"github.com/aziontech/azion-cli/pkg/workers"
 	"github.com/schollz/progressbar/v3"
@@ -34,10 +37,16 @@ type FileInfo struct {
 	Size         int64
 }
 
-// CollectFileInfos walks the directory and collects file metadata without opening files
+// CollectFileInfos walks the directory and collects file metadata without opening files.
+// Files ignored by the .azionignore file of the directory are left out.
 func CollectFileInfos(pathStatic string, cmd *DeployCmd) ([]FileInfo, error) {
 	var fileInfos []FileInfo
 
+	matcher, err := ignore.New(pathStatic, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf(msg.ErrorReadIgnoreFile, ignore.FileName, err)
+	}
+
 	if err := cmd.FilepathWalk(pathStatic, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -56,6 +65,13 @@ func CollectFileInfos(pathStatic string, cmd *DeployCmd) ([]FileInfo, error) {
 			return filepath.SkipDir
 		}
 
+		if matcher.Ignored(strings.TrimPrefix(path, pathStatic), resolvedInfo.IsDir()) {
+			if resolvedInfo.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
 		// Process files only
 		if !resolvedInfo.IsDir() {
 			fileString := strings.TrimPrefix(path, pathStatic)
@@ -86,6 +102,11 @@ func CollectFileInfos(pathStatic string, cmd *DeployCmd) ([]FileInfo, error) {
 func ReadAllFiles(pathStatic string, cmd *DeployCmd) ([]contracts.FileOps, error) {
 	var listFiles []contracts.FileOps
 
+	matcher, err := ignore.New(pathStatic, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf(msg.ErrorReadIgnoreFile, ignore.FileName, err)
+	}
+
 	if err := cmd.FilepathWalk(pathStatic, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -98,6 +119,13 @@ func ReadAllFiles(pathStatic string, cmd *DeployCmd) ([]contracts.FileOps, error
 			return statErr
 		}
 
+		if matcher.Ignored(strings.TrimPrefix(path, pathStatic), resolvedInfo.IsDir()) {
+			if resolvedInfo.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
 		// Process files
 		if !resolvedInfo.IsDir() {
 			fileContent, err := cmd.Open(path)
@@ -282,3 +310,28 @@ func Worker(jobs <-chan contracts.FileOps, results chan<- error, currentFile *in
 		results <- nil
 	}
 }
+
+// listFiles prints the files of the project sent to the remote build
+func (cmd *DeployCmd) listFiles(f *cmdutil.Factory) error {
+	pathWorkingDir, err := cmd.GetWorkDir()
+	if err != nil {
+		return err
+	}
+	fileInfos, err := CollectFileInfos(pathWorkingDir, cmd)
+	if err != nil {
+		return err
+	}
+
+	var listed strings.Builder
+	for _, fileInfo := range fileInfos {
+		listed.WriteString(fmt.Sprintf(msg.ListFilesEntry, fileInfo.Path, fileInfo.Size))
+	}
+	listed.WriteString(fmt.Sprintf(msg.ListFilesTotal, len(fileInfos)))
+
+	listOut := output.GeneralOutput{
+		Msg:   listed.String(),
+		Out:   f.IOStreams.Out,
+		Flags: f.Flags,
+	}
+	return output.Print(&listOut)
+}
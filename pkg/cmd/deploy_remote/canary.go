@@ -0,0 +1,319 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	msg "github.com/aziontech/azion-cli/messages/deploy-remote"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	manifestInt "github.com/aziontech/azion-cli/pkg/manifest"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/state"
+	"github.com/aziontech/azion-cli/utils"
+	edgesdk "github.com/aziontech/azionapi-v4-go-sdk-dev/azion-api"
+	"go.uber.org/zap"
+)
+
+// CanarySuffix is appended to the names of the resources serving the new version of a canary deployment
+const CanarySuffix = "-canary"
+
+// checkCanary validates that a canary deployment can be started for the project. The only strategy of workload
+// deployments names the application, firewall and custom page serving the workload but no share of the traffic,
+// so the canary is refused before any resource is created, instead of deploying a version that would get all the
+// traffic or none.
+func checkCanary(conf *contracts.AzionApplicationOptions, percentage int64) error {
+	if percentage < 1 || percentage > 99 {
+		return msg.ErrorCanaryPercentage
+	}
+	if conf.Workloads.Canary != nil {
+		return msg.ErrorCanaryInProgress
+	}
+	if !conf.NotFirstRun || conf.Workloads.Id == 0 || len(conf.Workloads.Deployments) == 0 {
+		return msg.ErrorCanaryNotDeployed
+	}
+	return msg.ErrorCanaryUnsupported
+}
+
+// canaryDeploymentRequest returns the request of the workload deployment serving the application of the new
+// version. The share of the traffic is to be set here once a strategy carries it; until then checkCanary keeps
+// deploys from reaching it.
+func canaryDeploymentRequest(name string, applicationID int64) edgesdk.WorkloadDeploymentRequest {
+	request := edgesdk.WorkloadDeploymentRequest{}
+	request.SetName(name)
+	request.SetActive(true)
+	request.SetCurrent(false)
+
+	strategy := edgesdk.DeploymentStrategyDefaultDeploymentStrategyRequest{}
+	attributes := edgesdk.DefaultDeploymentStrategyAttrsRequest{}
+	strategy.SetType("default")
+	attributes.SetApplication(applicationID)
+	strategy.SetAttributes(attributes)
+	request.SetStrategy(strategy)
+
+	return request
+}
+
+// deployCanary creates the resources of the new version next to the current ones, named after CanarySuffix,
+// and a workload deployment sending the given share of the traffic to them. The current version is left untouched,
+// and its prefix is kept so that aborting the canary makes it the version of the project again.
+func (cmd *DeployCmd) deployCanary(
+	ctx context.Context, clients *Clients, interpreter *manifestInt.ManifestInterpreter,
+	conf *contracts.AzionApplicationOptions, manifest *contracts.ManifestV4, previousPrefix string, msgs *[]string) error {
+	logger.FInfoFlags(cmd.Io.Out, msg.CanaryDeploying, cmd.F.Format, cmd.F.Out)
+	*msgs = append(*msgs, msg.CanaryDeploying)
+
+	canaryManifest, err := manifestInt.CanaryManifest(manifest, CanarySuffix)
+	if err != nil {
+		return err
+	}
+
+	canary := &contracts.AzionJsonDataCanary{Percentage: Canary, Prefix: conf.Prefix, PreviousPrefix: previousPrefix}
+	conf.Workloads.Canary = canary
+
+	canaryConf := &contracts.AzionApplicationOptions{
+		Name:        conf.Name,
+		Bucket:      conf.Bucket,
+		Preset:      conf.Preset,
+		Env:         conf.Env,
+		Prefix:      conf.Prefix,
+		NotFirstRun: true,
+		Workloads: contracts.AzionJsonDataWorkload{
+			Id:      conf.Workloads.Id,
+			Name:    conf.Workloads.Name,
+			Domains: conf.Workloads.Domains,
+			Url:     conf.Workloads.Url,
+		},
+	}
+	// the resources of the new version are tracked inside the canary of the project, so that they can
+	// be removed on abort even if creating them fails halfway
+	track := func() {
+		canary.Applications = canaryConf.Applications
+		canary.Function = canaryConf.Function
+		canary.Connectors = canaryConf.Connectors
+	}
+	canaryInterpreter := *interpreter
+	canaryInterpreter.WriteAzionJsonContent = func(_ *contracts.AzionApplicationOptions, confPath string) error {
+		track()
+		return cmd.WriteAzionJsonContent(conf, confPath)
+	}
+
+	err = canaryInterpreter.CreateResources(canaryConf, canaryManifest, nil, cmd.F, ProjectConf, msgs)
+	track()
+	if err != nil {
+		if len(canary.Applications) == 0 && len(canary.Function) == 0 && len(canary.Connectors) == 0 {
+			conf.Workloads.Canary = nil
+		}
+		return err
+	}
+
+	applicationID := canaryConf.Application.ID
+	for _, deployment := range manifest.WorkloadDeployments {
+		if deployment.Strategy.Attributes.Application == nil {
+			continue
+		}
+		name := *deployment.Strategy.Attributes.Application + CanarySuffix
+		for _, app := range canary.Applications {
+			if app.Name == name {
+				applicationID = app.ID
+			}
+		}
+		break
+	}
+
+	name := fmt.Sprintf("%s%s-%s", conf.Workloads.Name, CanarySuffix, utils.Timestamp())
+	deployment, err := clients.Workload.CreateDeployment(ctx, canaryDeploymentRequest(name, applicationID), conf.Workloads.Id)
+	if err != nil {
+		logger.Debug("Error while creating the canary deployment", zap.Error(err))
+		return fmt.Errorf(msg.ErrorCreateCanary, err)
+	}
+	canary.DeploymentId = deployment.GetId()
+	canary.DeploymentName = deployment.GetName()
+
+	msgf := fmt.Sprintf(msg.CanaryCreated, canary.DeploymentName, canary.DeploymentId, canary.Percentage)
+	logger.FInfoFlags(cmd.Io.Out, msgf, cmd.F.Format, cmd.F.Out)
+	*msgs = append(*msgs, msgf)
+	logger.FInfoFlags(cmd.Io.Out, msg.CanaryNext, cmd.F.Format, cmd.F.Out)
+	*msgs = append(*msgs, msg.CanaryNext)
+
+	return cmd.WriteAzionJsonContent(conf, ProjectConf)
+}
+
+// removeCanary deletes the deployment and the resources of the canary of the project. Resources already
+// gone are skipped, and the ones removed are dropped from azion.json as it goes, so it can be run again.
+// Unless the canary was promoted, the prefix of the version it was deployed next to is restored.
+func (cmd *DeployCmd) removeCanary(
+	ctx context.Context, clients *Clients, conf *contracts.AzionApplicationOptions, promoted bool, msgs *[]string) error {
+	canary := conf.Workloads.Canary
+	removed := func(kind string, id int64, err error) error {
+		if err != nil && !errors.Is(err, utils.ErrorNotFound404) {
+			logger.Debug("Error while removing the canary deployment", zap.Error(err))
+			return fmt.Errorf(msg.ErrorRemoveCanary, kind, id, err)
+		}
+		msgf := fmt.Sprintf(msg.CanaryRemoved, kind, id)
+		logger.FInfoFlags(cmd.Io.Out, msgf, cmd.F.Format, cmd.F.Out)
+		*msgs = append(*msgs, msgf)
+		return nil
+	}
+
+	if canary.DeploymentId != 0 {
+		err := clients.Workload.DeleteDeployment(ctx, conf.Workloads.Id, canary.DeploymentId)
+		if err := removed("workload deployment", canary.DeploymentId, err); err != nil {
+			return err
+		}
+		canary.DeploymentId = 0
+	}
+
+	for len(canary.Applications) > 0 {
+		app := canary.Applications[0]
+		if err := removed("application", app.ID, clients.Application.Delete(ctx, app.ID)); err != nil {
+			return err
+		}
+		canary.Applications = canary.Applications[1:]
+	}
+
+	for len(canary.Function) > 0 {
+		id := canary.Function[0].ID
+		if err := removed("function", id, clients.Function.Delete(ctx, id)); err != nil {
+			return err
+		}
+		// functions are listed once per instance
+		canary.Function = slices.DeleteFunc(canary.Function, func(function contracts.AzionJsonDataFunction) bool {
+			return function.ID == id
+		})
+	}
+
+	for len(canary.Connectors) > 0 {
+		connector := canary.Connectors[0]
+		if err := removed("connector", connector.Id, clients.Connector.Delete(ctx, connector.Id)); err != nil {
+			return err
+		}
+		canary.Connectors = canary.Connectors[1:]
+	}
+
+	if !promoted && canary.PreviousPrefix != "" {
+		conf.Prefix = canary.PreviousPrefix
+	}
+	conf.Workloads.Canary = nil
+	return nil
+}
+
+// Promote sends all the traffic of the workload to the version deployed with --canary. The manifest of
+// the last build is applied to the resources of the current version, and the canary ones are removed.
+func (cmd *DeployCmd) Promote(f *cmdutil.Factory, configPath string) error {
+	ProjectConf = configPath
+	msgs := []string{}
+	ctx := context.Background()
+
+	lock, err := state.Acquire(ProjectConf, "promote")
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	conf, err := cmd.GetAzionJsonContent(ProjectConf)
+	if err != nil {
+		logger.Debug("Failed to get Azion JSON content", zap.Error(err))
+		return err
+	}
+	if conf.Workloads.Canary == nil {
+		return msg.ErrorNoCanary
+	}
+
+	defer func() {
+		if err := cmd.WriteAzionJsonContent(conf, ProjectConf); err != nil {
+			logger.Debug("Error while writing azion.json file", zap.Error(err))
+		}
+	}()
+
+	logger.FInfoFlags(cmd.Io.Out, msg.CanaryPromoting, f.Format, f.Out)
+	msgs = append(msgs, msg.CanaryPromoting)
+
+	clients := NewClients(f)
+	interpreter := cmd.Interpreter()
+	interpreter.SkipRollback = NoRollback
+	interpreter.WriteAzionJsonContent = cmd.WriteAzionJsonContent
+
+	pathManifest, err := interpreter.ManifestPath()
+	if err != nil {
+		return err
+	}
+	manifestStructure, err := interpreter.ReadManifest(pathManifest, f, &msgs)
+	if err != nil {
+		return err
+	}
+
+	err = interpreter.CreateResources(conf, manifestStructure, FunctionIds, f, ProjectConf, &msgs)
+	if err != nil {
+		return err
+	}
+
+	if len(manifestStructure.Workloads) == 0 || manifestStructure.Workloads[0].Name == "" {
+		err = cmd.doWorkload(clients.Workload, ctx, conf, &msgs)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := cmd.removeCanary(ctx, clients, conf, true, &msgs); err != nil {
+		return err
+	}
+
+	logger.FInfoFlags(cmd.Io.Out, msg.CanaryPromoted, f.Format, f.Out)
+	msgs = append(msgs, msg.CanaryPromoted)
+
+	outSlice := output.SliceOutput{
+		Messages: msgs,
+		GeneralOutput: output.GeneralOutput{
+			Out:   cmd.F.IOStreams.Out,
+			Flags: cmd.F.Flags,
+		},
+	}
+	return output.Print(&outSlice)
+}
+
+// Abort removes the version deployed with --canary, so that the current version receives all the traffic again
+func (cmd *DeployCmd) Abort(f *cmdutil.Factory, configPath string) error {
+	ProjectConf = configPath
+	msgs := []string{}
+
+	lock, err := state.Acquire(ProjectConf, "abort")
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	conf, err := cmd.GetAzionJsonContent(ProjectConf)
+	if err != nil {
+		logger.Debug("Failed to get Azion JSON content", zap.Error(err))
+		return err
+	}
+	if conf.Workloads.Canary == nil {
+		return msg.ErrorNoCanary
+	}
+
+	defer func() {
+		if err := cmd.WriteAzionJsonContent(conf, ProjectConf); err != nil {
+			logger.Debug("Error while writing azion.json file", zap.Error(err))
+		}
+	}()
+
+	if err := cmd.removeCanary(context.Background(), NewClients(f), conf, false, &msgs); err != nil {
+		return err
+	}
+
+	logger.FInfoFlags(cmd.Io.Out, msg.CanaryAborted, f.Format, f.Out)
+	msgs = append(msgs, msg.CanaryAborted)
+
+	outSlice := output.SliceOutput{
+		Messages: msgs,
+		GeneralOutput: output.GeneralOutput{
+			Out:   cmd.F.IOStreams.Out,
+			Flags: cmd.F.Flags,
+		},
+	}
+	return output.Print(&outSlice)
+}
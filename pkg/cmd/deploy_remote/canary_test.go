@@ -0,0 +1,162 @@
+package deploy
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	msg "github.com/aziontech/azion-cli/messages/deploy-remote"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/httpmock"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCanary(t *testing.T) {
+	deployed := func() *contracts.AzionApplicationOptions {
+		return &contracts.AzionApplicationOptions{
+			NotFirstRun: true,
+			Workloads: contracts.AzionJsonDataWorkload{
+				Id:          10,
+				Deployments: []contracts.Deployments{{Id: 20, Name: "production"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		conf       func() *contracts.AzionApplicationOptions
+		percentage int64
+		err        error
+	}{
+		{name: "deployed project", conf: deployed, percentage: 10, err: msg.ErrorCanaryUnsupported},
+		{name: "percentage too low", conf: deployed, percentage: -5, err: msg.ErrorCanaryPercentage},
+		{name: "percentage too high", conf: deployed, percentage: 100, err: msg.ErrorCanaryPercentage},
+		{
+			name: "canary in progress",
+			conf: func() *contracts.AzionApplicationOptions {
+				conf := deployed()
+				conf.Workloads.Canary = &contracts.AzionJsonDataCanary{DeploymentId: 30}
+				return conf
+			},
+			percentage: 10,
+			err:        msg.ErrorCanaryInProgress,
+		},
+		{
+			name: "first deploy",
+			conf: func() *contracts.AzionApplicationOptions {
+				return &contracts.AzionApplicationOptions{}
+			},
+			percentage: 10,
+			err:        msg.ErrorCanaryNotDeployed,
+		},
+		{
+			name: "workload without deployments",
+			conf: func() *contracts.AzionApplicationOptions {
+				conf := deployed()
+				conf.Workloads.Deployments = nil
+				return conf
+			},
+			percentage: 10,
+			err:        msg.ErrorCanaryNotDeployed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCanary(tt.conf(), tt.percentage)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDeployCanary(t *testing.T) {
+	mock := &httpmock.Registry{}
+	f, _, _ := testutils.NewFactory(mock)
+	written := false
+	cmd := &DeployCmd{
+		Io: f.IOStreams,
+		F:  f,
+		GetAzionJsonContent: func(string) (*contracts.AzionApplicationOptions, error) {
+			return &contracts.AzionApplicationOptions{
+				NotFirstRun: true,
+				Workloads: contracts.AzionJsonDataWorkload{
+					Id:          10,
+					Deployments: []contracts.Deployments{{Id: 20, Name: "production"}},
+				},
+			}, nil
+		},
+		WriteAzionJsonContent: func(*contracts.AzionApplicationOptions, string) error {
+			written = true
+			return nil
+		},
+	}
+	ProjectConf = filepath.Join(t.TempDir(), "azion")
+	Canary = 10
+	t.Cleanup(func() { Canary = 0 })
+
+	// no resource is created for a canary whose share of the traffic could not be sent
+	require.ErrorIs(t, cmd.Run(f), msg.ErrorCanaryUnsupported)
+	require.Empty(t, mock.Requests)
+	require.False(t, written)
+}
+
+func TestRemoveCanary(t *testing.T) {
+	canaryConf := func() *contracts.AzionApplicationOptions {
+		return &contracts.AzionApplicationOptions{
+			Prefix: "20240102",
+			Workloads: contracts.AzionJsonDataWorkload{
+				Id:     10,
+				Canary: &contracts.AzionJsonDataCanary{Percentage: 10, Prefix: "20240102", PreviousPrefix: "20240101"},
+			},
+		}
+	}
+
+	t.Run("abort restores the previous version", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(nil)
+		var written *contracts.AzionApplicationOptions
+		cmd := &DeployCmd{
+			Io: f.IOStreams,
+			F:  f,
+			GetAzionJsonContent: func(string) (*contracts.AzionApplicationOptions, error) {
+				return canaryConf(), nil
+			},
+			WriteAzionJsonContent: func(conf *contracts.AzionApplicationOptions, _ string) error {
+				written = conf
+				return nil
+			},
+		}
+
+		require.NoError(t, cmd.Abort(f, filepath.Join(t.TempDir(), "azion")))
+		require.Nil(t, written.Workloads.Canary)
+		require.Equal(t, "20240101", written.Prefix)
+	})
+
+	t.Run("rollback of a canary deploy restores the previous version", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(nil)
+		cmd := &DeployCmd{Io: f.IOStreams, F: f}
+		Canary = 10
+		t.Cleanup(func() { Canary = 0 })
+
+		conf := canaryConf()
+		msgs := []string{}
+		require.NoError(t, cmd.rollbackDeploy(context.Background(), &Clients{}, conf, "20240101", &msgs))
+		require.Nil(t, conf.Workloads.Canary)
+		require.Equal(t, "20240101", conf.Prefix)
+	})
+
+	t.Run("promote keeps the new version", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(nil)
+		cmd := &DeployCmd{Io: f.IOStreams, F: f}
+
+		conf := canaryConf()
+		msgs := []string{}
+		require.NoError(t, cmd.removeCanary(context.Background(), &Clients{}, conf, true, &msgs))
+		require.Nil(t, conf.Workloads.Canary)
+		require.Equal(t, "20240102", conf.Prefix)
+	})
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"time"
 
 	msg "github.com/aziontech/azion-cli/messages/deploy-remote"
+	"github.com/aziontech/azion-cli/pkg/api/s3"
 	"github.com/aziontech/azion-cli/pkg/cmd/build"
 	"github.com/aziontech/azion-cli/pkg/cmd/sync"
 	"github.com/aziontech/azion-cli/pkg/cmdutil"
@@ -19,6 +21,7 @@ import (
 	"github.com/aziontech/azion-cli/pkg/logger"
 	manifestInt "github.com/aziontech/azion-cli/pkg/manifest"
 	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/state"
 	"github.com/aziontech/azion-cli/pkg/token"
 	vulcanPkg "github.com/aziontech/azion-cli/pkg/vulcan"
 	"github.com/aziontech/azion-cli/utils"
@@ -35,6 +38,7 @@ type DeployCmd struct {
 	WriteAzionJsonContent    func(conf *contracts.AzionApplicationOptions, confConf string) error
 	commandRunInteractive    func(f *cmdutil.Factory, comm string) error
 	commandRunnerOutput      func(f *cmdutil.Factory, comm string, envVars []string) (string, error)
+	CommandRunnerStream      func(out io.Writer, envVars []string, comm string) error
 	WriteManifest            func(manifest *contracts.ManifestV4, pathMan string) error
 	EnvLoader                func(path string) ([]string, error)
 	BuildCmd                 func(f *cmdutil.Factory) *build.BuildCmd
@@ -48,6 +52,7 @@ type DeployCmd struct {
 	GetCredentialsForBucket  func(path string, bucketName string) (token.S3Credentials, bool, error)
 	SaveCredentialsForBucket func(path string, bucketName string, creds token.S3Credentials) error
 	CreateBucketCredentials  func(ctx context.Context, bucketName string, f *cmdutil.Factory, subdir string) (token.S3Credentials, error)
+	Sleep                    func(d time.Duration)
 }
 
 var (
@@ -62,9 +67,19 @@ var (
 	FunctionIds   map[string]contracts.AzionJsonDataFunction
 	WriteBucket   bool
 	Workers       int
+	NoRollback    bool
+	// MultipartThreshold is the size in MB from which static files are uploaded in parts
+	MultipartThreshold = s3.DefaultMultipartThreshold / (1024 * 1024)
+	// Resume continues the upload of static files interrupted in the last deploy
+	Resume bool
+	// Canary is the percentage of the traffic sent to the new version, deployed next to the current one
+	Canary int64
+	// KeepVersions is the number of deployed versions kept in the bucket, overriding the manifest
+	KeepVersions int
 )
 
 func NewDeployCmd(f *cmdutil.Factory) *DeployCmd {
+	store := state.New(f)
 	return &DeployCmd{
 		Io:                       f.IOStreams,
 		GetWorkDir:               utils.GetWorkingDir,
@@ -72,10 +87,11 @@ func NewDeployCmd(f *cmdutil.Factory) *DeployCmd {
 		WriteFile:                os.WriteFile,
 		EnvLoader:                utils.LoadEnvVarsFromFile,
 		BuildCmd:                 build.NewBuildCmd,
-		GetAzionJsonContent:      utils.GetAzionJsonContent,
-		WriteAzionJsonContent:    utils.WriteAzionJsonContent,
+		GetAzionJsonContent:      store.Read,
+		WriteAzionJsonContent:    store.Write,
 		commandRunInteractive:    command.CommandRunInteractive,
 		commandRunnerOutput:      command.CommandRunInteractiveWithOutput,
+		CommandRunnerStream:      command.RunCommandStreamOutput,
 		WriteManifest:            WriteManifest,
 		Open:                     os.Open,
 		FilepathWalk:             filepath.Walk,
@@ -87,6 +103,7 @@ func NewDeployCmd(f *cmdutil.Factory) *DeployCmd {
 		GetCredentialsForBucket:  token.GetCredentialsForBucket,
 		SaveCredentialsForBucket: token.SaveCredentialsForBucket,
 		CreateBucketCredentials:  CreateBucketCredentials,
+		Sleep:                    time.Sleep,
 	}
 }
 
@@ -115,15 +132,38 @@ func NewCmd(f *cmdutil.Factory) *cobra.Command {
 	return NewCobraCmd(NewDeployCmd(f))
 }
 
-func (cmd *DeployCmd) ExternalRun(f *cmdutil.Factory, configPath string, env string, shouldSync, auto, skipBuild, writeBucket, skipFramework bool, workers int) error {
-	ProjectConf = configPath
-	Sync = shouldSync
-	Env = env
-	Auto = auto
-	SkipBuild = skipBuild
-	SkipFramework = skipFramework
-	WriteBucket = writeBucket
-	Workers = workers
+// RunOptions are the settings of a deploy started by another command, one for each flag of the deploy command
+type RunOptions struct {
+	ConfigPath    string
+	Env           string
+	Sync          bool
+	Auto          bool
+	SkipBuild     bool
+	WriteBucket   bool
+	SkipFramework bool
+	NoRollback    bool
+	Workers       int
+	// MultipartThreshold is the size in MB from which static files are uploaded in parts
+	MultipartThreshold int64
+	Resume             bool
+	Canary             int64
+	KeepVersions       int
+}
+
+func (cmd *DeployCmd) ExternalRun(f *cmdutil.Factory, opts RunOptions) error {
+	ProjectConf = opts.ConfigPath
+	Sync = opts.Sync
+	Env = opts.Env
+	Auto = opts.Auto
+	SkipBuild = opts.SkipBuild
+	SkipFramework = opts.SkipFramework
+	WriteBucket = opts.WriteBucket
+	Workers = opts.Workers
+	NoRollback = opts.NoRollback
+	MultipartThreshold = opts.MultipartThreshold
+	Resume = opts.Resume
+	Canary = opts.Canary
+	KeepVersions = opts.KeepVersions
 	return cmd.Run(f)
 }
 
@@ -139,6 +179,12 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 	msgs = append(msgs, "Running deploy command")
 	ctx := context.Background()
 
+	lock, err := state.Acquire(ProjectConf, "deploy --local")
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
 	if Sync {
 		sync.ProjectConf = ProjectConf
 		syncCmd := sync.NewSyncCmd(f)
@@ -155,6 +201,14 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 		return err
 	}
 
+	if Canary != 0 {
+		if err := checkCanary(conf, Canary); err != nil {
+			return err
+		}
+	} else if conf.Workloads.Canary != nil {
+		return msg.ErrorCanaryInProgress
+	}
+
 	defer func() {
 		if err := cmd.WriteAzionJsonContent(conf, ProjectConf); err != nil {
 			logger.Debug("Error while writing azion.json file", zap.Error(err))
@@ -162,14 +216,26 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 	}()
 
 	var oldprefix, newprefix string
+	var journal *UploadJournal
 
-	if conf.Prefix == "" || conf.RotatePrefix == nil || *conf.RotatePrefix == true {
+	if Resume {
+		// the interrupted upload is continued under the same version, which the interrupted deploy already
+		// saved to azion.json, so the version live before it comes from the journal
+		journal, err = cmd.resumeUploadJournal(ProjectConf)
+		if err != nil {
+			return err
+		}
+		oldprefix, newprefix = journal.PreviousPrefix, journal.Prefix
+	} else if conf.Prefix == "" || conf.RotatePrefix == nil || *conf.RotatePrefix == true {
 		versionID := cmd.VersionID()
 		oldprefix, newprefix = conf.Prefix, versionID
 	} else {
 		oldprefix, newprefix = conf.Prefix, conf.Prefix
 	}
 
+	// variables handed to the hooks along with the ones describing the project
+	hookVars := []string{"AZION_PREVIOUS_PREFIX=" + oldprefix}
+
 	err = checkArgsJson(cmd, ProjectConf)
 	if err != nil {
 		return err
@@ -177,6 +243,11 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 
 	clients := NewClients(f)
 	interpreter := cmd.Interpreter()
+	interpreter.SkipRollback = NoRollback
+	interpreter.WriteAzionJsonContent = cmd.WriteAzionJsonContent
+
+	// the hooks are read again from every manifest the deploy builds
+	hooks := ProjectHooks(conf, BuiltManifest(interpreter))
 
 	if !SkipBuild && conf.NotFirstRun {
 		if !SkipFramework {
@@ -198,12 +269,18 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 			}
 		}
 
+		if err := cmd.RunHooks(HookPreBuild, hooks, conf, hookVars, &msgs); err != nil {
+			return err
+		}
 		buildCmd := cmd.BuildCmd(f)
 		err = buildCmd.ExternalRun(&contracts.BuildInfo{Preset: conf.Preset}, ProjectConf, &msgs, SkipFramework)
 		if err != nil {
 			logger.Debug("Error while running build command called by deploy command", zap.Error(err))
 			return err
 		}
+		if err := cmd.RunHooks(HookPostBuild, hooks, conf, hookVars, &msgs); err != nil {
+			return err
+		}
 	}
 
 	pathManifest, err := interpreter.ManifestPath()
@@ -211,9 +288,12 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 		return err
 	}
 
-	err = cmd.doApplication(clients.Application, context.Background(), conf, &msgs)
-	if err != nil {
-		return err
+	// the application of the current version is left as it is during a canary deployment
+	if Canary == 0 {
+		err = cmd.doApplication(clients.Application, context.Background(), conf, &msgs)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Time ReadManifest operation
@@ -223,6 +303,7 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 		return err
 	}
 	GlobalTimingSummary.ReadManifestTime = time.Since(readManifestStart)
+	hooks = ProjectHooks(conf, manifestStructure)
 
 	// Check if directory exists; if not, we skip creating bucket
 	if len(manifestStructure.Storage) == 0 {
@@ -242,12 +323,18 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 		if err != nil {
 			return err
 		}
+		if err := cmd.RunHooks(HookPreBuild, hooks, conf, hookVars, &msgs); err != nil {
+			return err
+		}
 		buildCmd := cmd.BuildCmd(f)
 		err = buildCmd.ExternalRun(&contracts.BuildInfo{}, ProjectConf, &msgs, SkipFramework)
 		if err != nil {
 			logger.Debug("Error while running build command called by deploy command", zap.Error(err))
 			return err
 		}
+		if err := cmd.RunHooks(HookPostBuild, hooks, conf, hookVars, &msgs); err != nil {
+			return err
+		}
 	}
 
 	// Time second ReadManifest operation
@@ -257,6 +344,7 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 		return err
 	}
 	GlobalTimingSummary.ReadManifestTime += time.Since(readManifestStart)
+	hooks = ProjectHooks(conf, manifestStructure)
 
 	// Check if directory exists; if not, we skip uploading static files
 	if _, err := os.Stat(PathStatic); os.IsNotExist(err) {
@@ -275,14 +363,46 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 		}
 		GlobalTimingSummary.CredentialsTime = time.Since(credentialsStart)
 
+		if err := cmd.RunHooks(HookPreUpload, hooks, conf, hookVars, &msgs); err != nil {
+			return err
+		}
+
 		uploadStart := time.Now()
+		if Resume {
+			if err := cmd.verifyUploadJournal(ctx, clients.Storage, conf, journal, &msgs); err != nil {
+				return err
+			}
+		} else {
+			journal = NewUploadJournal(conf.Bucket, conf.Prefix)
+			journal.PreviousPrefix = oldprefix
+		}
+		previous := cmd.readUploadIndex(ProjectConf)
+		uploaded := NewUploadIndex(conf.Bucket, conf.Prefix)
 		for _, storage := range manifestStructure.Storage {
-			err = cmd.uploadFilesWithCreds(f, conf, &msgs, storage.Dir, conf.Bucket, creds)
+			err = cmd.uploadFilesWithCreds(f, conf, &msgs, storage, conf.Bucket, creds, previous, uploaded, journal)
 			if err != nil {
 				return err
 			}
 		}
+		removeUploadJournal(ProjectConf)
+		if err := cmd.writeUploadIndex(uploaded, ProjectConf); err != nil {
+			logger.Debug("Error while writing the upload index", zap.Error(err))
+			return err
+		}
 		GlobalTimingSummary.UploadStaticFilesTime = time.Since(uploadStart)
+
+		if hooks != nil {
+			changed, err := writeChangedFiles(uploaded.Changed(previous))
+			if err != nil {
+				logger.Debug("Error while writing the list of changed files", zap.Error(err))
+				return err
+			}
+			defer os.Remove(changed)
+			hookVars = append(hookVars, "AZION_CHANGED_FILES_PATH="+changed)
+		}
+		if err := cmd.RunHooks(HookPostUpload, hooks, conf, hookVars, &msgs); err != nil {
+			return err
+		}
 	}
 
 	if len(conf.RulesEngine.Rules) == 0 && !conf.NotFirstRun {
@@ -296,19 +416,48 @@ func (cmd *DeployCmd) Run(f *cmdutil.Factory) error {
 
 	// Time CreateResources operation
 	manifestCreateStart := time.Now()
-	err = interpreter.CreateResources(conf, manifestStructure, FunctionIds, f, ProjectConf, &msgs)
+	if Canary != 0 {
+		err = cmd.deployCanary(ctx, clients, interpreter, conf, manifestStructure, oldprefix, &msgs)
+	} else {
+		err = interpreter.CreateResources(conf, manifestStructure, FunctionIds, f, ProjectConf, &msgs)
+	}
 	if err != nil {
 		return err
 	}
 	GlobalTimingSummary.ManifestCreateTime = time.Since(manifestCreateStart)
 
-	if len(manifestStructure.Workloads) == 0 || manifestStructure.Workloads[0].Name == "" {
+	if Canary == 0 && (len(manifestStructure.Workloads) == 0 || manifestStructure.Workloads[0].Name == "") {
 		err = cmd.doWorkload(clients.Workload, ctx, conf, &msgs)
 		if err != nil {
 			return err
 		}
 	}
 
+	// a deploy failing its health check is rolled back unless the health check of azion.json disables it
+	if err := cmd.healthCheck(ctx, conf, &msgs); err != nil {
+		if !rollbackUnhealthy(conf.HealthCheck) {
+			return err
+		}
+		return cmd.rollbackOnFailure(ctx, clients, conf, oldprefix, err, &msgs)
+	}
+
+	if err := cmd.RunHooks(HookPostDeploy, hooks, conf, hookVars, &msgs); err != nil {
+		if !hooks.RollbackOnFailure {
+			return err
+		}
+		return cmd.rollbackOnFailure(ctx, clients, conf, oldprefix, err, &msgs)
+	}
+
+	// the versions in use by a canary deployment are kept until it is promoted or aborted
+	if Canary == 0 {
+		if err := cmd.pruneVersions(ctx, clients.Storage, conf, manifestStructure, &msgs); err != nil {
+			logger.Debug("Error while removing old versions", zap.Error(err))
+			msgf := fmt.Sprintf(msg.DeployPruneFailed, err.Error())
+			logger.FInfoFlags(cmd.F.IOStreams.Out, msgf, f.Format, f.Out)
+			msgs = append(msgs, msgf)
+		}
+	}
+
 	// Calculate total deploy time
 	GlobalTimingSummary.TotalDeployTime = time.Since(totalStart)
 
@@ -0,0 +1,104 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	msg "github.com/aziontech/azion-cli/messages/deploy"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/ignore"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"go.uber.org/zap"
+)
+
+// walkStorage calls fn with every file of the storage entry to be uploaded, with its name relative to the
+// directory of the entry. Symlinks are skipped, as well as the files ignored by .azionignore or left out by
+// the include and exclude globs of the entry.
+func (cmd *DeployCmd) walkStorage(storage contracts.StorageManifest, fn func(pathDir, name string, info os.FileInfo) error) error {
+	wd, err := cmd.GetWorkDir()
+	if err != nil {
+		return err
+	}
+	matcher, err := ignore.New(wd, storage.Include, storage.Exclude)
+	if err != nil {
+		return fmt.Errorf(msg.ErrorReadIgnoreFile, ignore.FileName, err)
+	}
+
+	dir := storage.Dir
+	return cmd.FilepathWalk(dir, func(pathDir string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			logger.Debug("Skipping symlink file", zap.Any("File name", pathDir))
+			return nil
+		}
+
+		name := strings.TrimPrefix(pathDir, path.Clean(dir))
+		if matcher.Ignored(name, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		logger.Debug("Reading the following file", zap.Any("File name", pathDir))
+		return fn(pathDir, name, info)
+	})
+}
+
+// ListFiles prints the files a deploy would upload, from the storage entries of the manifest of the last
+// build. Without a manifest, the files of the default storage directory are listed.
+func (cmd *DeployCmd) ListFiles(f *cmdutil.Factory) error {
+	storages := []contracts.StorageManifest{{Dir: PathStatic}}
+	pathManifest, err := cmd.Interpreter().ManifestPath()
+	if err != nil {
+		return err
+	}
+	if data, err := cmd.FileReader(pathManifest); err == nil {
+		manifest := contracts.ManifestV4{}
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return err
+		}
+		if len(manifest.Storage) > 0 {
+			storages = manifest.Storage
+		}
+	} else {
+		logger.Debug("Manifest not found, listing the default storage directory", zap.Error(err))
+	}
+
+	var listed strings.Builder
+	total := 0
+	for _, storage := range storages {
+		if _, err := os.Stat(storage.Dir); os.IsNotExist(err) {
+			logger.Debug(msg.SkipUpload, zap.String("dir", storage.Dir))
+			continue
+		}
+		err := cmd.walkStorage(storage, func(pathDir, name string, info os.FileInfo) error {
+			listed.WriteString(fmt.Sprintf(msg.ListFilesEntry, path.Join(storage.Dir, name), info.Size()))
+			total++
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	listed.WriteString(fmt.Sprintf(msg.ListFilesTotal, total))
+
+	listOut := output.GeneralOutput{
+		Msg:   listed.String(),
+		Out:   f.IOStreams.Out,
+		Flags: f.Flags,
+	}
+	return output.Print(&listOut)
+}
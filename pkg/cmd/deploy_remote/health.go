@@ -0,0 +1,115 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	msg "github.com/aziontech/azion-cli/messages/deploy-remote"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultHealthStatus   = http.StatusOK
+	DefaultHealthRetries  = 30
+	DefaultHealthInterval = 10
+	DefaultHealthTimeout  = 10
+)
+
+// healthCheckOf fills the zero values of the health check of azion.json with the defaults
+func healthCheckOf(check *contracts.AzionJsonHealthCheck) contracts.AzionJsonHealthCheck {
+	filled := *check
+	if len(filled.Paths) == 0 {
+		filled.Paths = []string{"/"}
+	}
+	if filled.Status == 0 {
+		filled.Status = DefaultHealthStatus
+	}
+	if filled.Retries <= 0 {
+		filled.Retries = DefaultHealthRetries
+	}
+	if filled.Interval <= 0 {
+		filled.Interval = DefaultHealthInterval
+	}
+	if filled.Timeout <= 0 {
+		filled.Timeout = DefaultHealthTimeout
+	}
+	return filled
+}
+
+// rollbackUnhealthy tells whether a deploy failing the health check is rolled back
+func rollbackUnhealthy(check *contracts.AzionJsonHealthCheck) bool {
+	return check == nil || check.RollbackOnFailure == nil || *check.RollbackOnFailure
+}
+
+// probe requests a path of the workload once, returning why the response is not the expected one
+func probe(ctx context.Context, client *http.Client, url string, check contracts.AzionJsonHealthCheck) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(check.Timeout)*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != check.Status {
+		return fmt.Errorf(msg.ErrorHealthStatus, check.Status, resp.StatusCode)
+	}
+	if check.Body == "" {
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(string(body), check.Body) {
+		return fmt.Errorf(msg.ErrorHealthBody, check.Body)
+	}
+	return nil
+}
+
+// healthCheck polls the paths of the health check of azion.json against the workload until each one answers
+// as expected. The deploy still propagating, a path is retried at every interval until the retries run out.
+func (cmd *DeployCmd) healthCheck(ctx context.Context, conf *contracts.AzionApplicationOptions, msgs *[]string) error {
+	if conf.HealthCheck == nil || conf.Workloads.Url == "" {
+		return nil
+	}
+	check := healthCheckOf(conf.HealthCheck)
+	client := &http.Client{}
+	baseURL := strings.TrimSuffix(conf.Workloads.Url, "/")
+
+	for _, path := range check.Paths {
+		url := baseURL + "/" + strings.TrimPrefix(path, "/")
+		msgf := fmt.Sprintf(msg.HealthChecking, url)
+		logger.FInfoFlags(cmd.Io.Out, msgf, cmd.F.Format, cmd.F.Out)
+		*msgs = append(*msgs, msgf)
+
+		var err error
+		for attempt := 1; attempt <= check.Retries; attempt++ {
+			if err = probe(ctx, client, url, check); err == nil {
+				break
+			}
+			logger.Debug("Health check attempt failed", zap.String("url", url), zap.Int("attempt", attempt), zap.Error(err))
+			if attempt < check.Retries {
+				cmd.Sleep(time.Duration(check.Interval) * time.Second)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf(msg.ErrorHealthCheck, url, check.Retries, err)
+		}
+	}
+
+	logger.FInfoFlags(cmd.Io.Out, msg.HealthPassed, cmd.F.Format, cmd.F.Out)
+	*msgs = append(*msgs, msg.HealthPassed)
+	return nil
+}
@@ -0,0 +1,95 @@
+package deploy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheck(t *testing.T) {
+	// the home answers after two failed attempts, as during the propagation of a deploy
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			attempts++
+			if attempts <= 2 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write([]byte("<h1>version 2</h1>"))
+		case "/api/health":
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		check  *contracts.AzionJsonHealthCheck
+		sleeps int
+		err    string
+	}{
+		{name: "without health check", sleeps: 0},
+		{
+			name:   "path answers after retries",
+			check:  &contracts.AzionJsonHealthCheck{Body: "version 2", Retries: 5},
+			sleeps: 2,
+		},
+		{
+			name:  "path without leading slash",
+			check: &contracts.AzionJsonHealthCheck{Paths: []string{"api/health"}, Body: `"ok"`},
+		},
+		{
+			name:   "unexpected status",
+			check:  &contracts.AzionJsonHealthCheck{Paths: []string{"/missing"}, Retries: 3},
+			sleeps: 2,
+			err:    "/missing failed after 3 attempts: expected the status 200, found 404",
+		},
+		{
+			name:   "missing body",
+			check:  &contracts.AzionJsonHealthCheck{Paths: []string{"/api/health"}, Body: "degraded", Retries: 2},
+			sleeps: 1,
+			err:    "expected the response to contain 'degraded'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, _, _ := testutils.NewFactory(nil)
+			sleeps := 0
+			cmd := &DeployCmd{Io: f.IOStreams, F: f, Sleep: func(d time.Duration) {
+				require.Equal(t, DefaultHealthInterval*time.Second, d)
+				sleeps++
+			}}
+
+			conf := &contracts.AzionApplicationOptions{HealthCheck: tt.check}
+			conf.Workloads.Url = server.URL + "/"
+			attempts = 0
+
+			err := cmd.healthCheck(context.Background(), conf, &[]string{})
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.sleeps, sleeps)
+		})
+	}
+}
+
+func TestRollbackUnhealthy(t *testing.T) {
+	enabled, disabled := true, false
+	require.True(t, rollbackUnhealthy(nil))
+	require.True(t, rollbackUnhealthy(&contracts.AzionJsonHealthCheck{}))
+	require.True(t, rollbackUnhealthy(&contracts.AzionJsonHealthCheck{RollbackOnFailure: &enabled}))
+	require.False(t, rollbackUnhealthy(&contracts.AzionJsonHealthCheck{RollbackOnFailure: &disabled}))
+}
@@ -0,0 +1,129 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	msg "github.com/aziontech/azion-cli/messages/deploy-remote"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	manifestInt "github.com/aziontech/azion-cli/pkg/manifest"
+	"go.uber.org/zap"
+)
+
+const (
+	HookPreBuild   = "pre-build"
+	HookPostBuild  = "post-build"
+	HookPreUpload  = "pre-upload"
+	HookPostUpload = "post-upload"
+	HookPostDeploy = "post-deploy"
+)
+
+// ProjectHooks returns the hooks of the project. The ones declared in azion.config reach the CLI through the
+// manifest generated from it, and replace the ones of azion.json.
+func ProjectHooks(conf *contracts.AzionApplicationOptions, manifest *contracts.ManifestV4) *contracts.AzionJsonHooks {
+	if manifest != nil && manifest.Hooks != nil {
+		return manifest.Hooks
+	}
+	return conf.Hooks
+}
+
+// BuiltManifest reads the manifest generated by the last build, if any, so that the hooks of azion.config are
+// known before the project is built again
+func BuiltManifest(interpreter *manifestInt.ManifestInterpreter) *contracts.ManifestV4 {
+	pathManifest, err := interpreter.ManifestPath()
+	if err != nil {
+		return nil
+	}
+	data, err := interpreter.FileReader(pathManifest)
+	if err != nil {
+		return nil
+	}
+	manifest := &contracts.ManifestV4{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		logger.Debug("Error while reading the hooks of the manifest", zap.Error(err))
+		return nil
+	}
+	return manifest
+}
+
+// hookCommands returns the commands declared for a stage of the deploy
+func hookCommands(hooks *contracts.AzionJsonHooks, stage string) []string {
+	if hooks == nil {
+		return nil
+	}
+	switch stage {
+	case HookPreBuild:
+		return hooks.PreBuild
+	case HookPostBuild:
+		return hooks.PostBuild
+	case HookPreUpload:
+		return hooks.PreUpload
+	case HookPostUpload:
+		return hooks.PostUpload
+	case HookPostDeploy:
+		return hooks.PostDeploy
+	}
+	return nil
+}
+
+// hookEnv returns the environment variables describing the deploy to the commands of a stage. Values
+// only known later in the deploy, such as the workload URL on the first deploy, are left empty.
+func hookEnv(stage string, conf *contracts.AzionApplicationOptions, vars []string) []string {
+	env := []string{
+		"AZION_HOOK=" + stage,
+		"AZION_APPLICATION_ID=" + strconv.FormatInt(conf.Application.ID, 10),
+		"AZION_WORKLOAD_ID=" + strconv.FormatInt(conf.Workloads.Id, 10),
+		"AZION_WORKLOAD_URL=" + conf.Workloads.Url,
+		"AZION_BUCKET=" + conf.Bucket,
+		"AZION_PREFIX=" + conf.Prefix,
+	}
+	if Canary != 0 {
+		env = append(env, "AZION_CANARY="+strconv.FormatInt(Canary, 10))
+	}
+	return append(env, vars...)
+}
+
+// RunHooks runs, in order, the commands of a stage of the deploy. The first failing command stops the stage.
+func (cmd *DeployCmd) RunHooks(stage string, hooks *contracts.AzionJsonHooks, conf *contracts.AzionApplicationOptions, vars []string, msgs *[]string) error {
+	commands := hookCommands(hooks, stage)
+	if len(commands) == 0 {
+		return nil
+	}
+
+	env := hookEnv(stage, conf, vars)
+	for _, command := range commands {
+		msgf := fmt.Sprintf(msg.HookRunning, stage, command)
+		logger.FInfoFlags(cmd.Io.Out, msgf, cmd.F.Format, cmd.F.Out)
+		*msgs = append(*msgs, msgf)
+
+		if err := cmd.CommandRunnerStream(cmd.Io.Out, env, command); err != nil {
+			logger.Debug("Error while running a deploy hook", zap.String("stage", stage), zap.String("command", command), zap.Error(err))
+			return fmt.Errorf(msg.ErrorHook, stage, command, err)
+		}
+	}
+	return nil
+}
+
+// writeChangedFiles writes the names of the files uploaded by the deploy, one per line, to a temporary file
+// handed to the hooks through AZION_CHANGED_FILES_PATH. The caller removes the file.
+func writeChangedFiles(files []string) (string, error) {
+	file, err := os.CreateTemp("", "azion-changed-files-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	content := strings.Join(files, "\n")
+	if len(files) > 0 {
+		content += "\n"
+	}
+	if _, err := file.WriteString(content); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
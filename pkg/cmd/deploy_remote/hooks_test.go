@@ -0,0 +1,121 @@
+package deploy
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	manifestInt "github.com/aziontech/azion-cli/pkg/manifest"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunHooks(t *testing.T) {
+	conf := func() *contracts.AzionApplicationOptions {
+		conf := &contracts.AzionApplicationOptions{
+			Bucket: "bucket",
+			Prefix: "20240202",
+			Hooks: &contracts.AzionJsonHooks{
+				PreBuild:   []string{"npm run lint", "npm test"},
+				PostDeploy: []string{"./smoke.sh"},
+			},
+		}
+		conf.Application.ID = 10
+		conf.Workloads.Id = 20
+		conf.Workloads.Url = "https://example.map.azionedge.net"
+		return conf
+	}
+
+	newCmd := func(run func(out io.Writer, envVars []string, comm string) error) *DeployCmd {
+		f, _, _ := testutils.NewFactory(nil)
+		return &DeployCmd{Io: f.IOStreams, F: f, CommandRunnerStream: run}
+	}
+
+	t.Run("commands of the stage run in order with the deploy variables", func(t *testing.T) {
+		commands := []string{}
+		var env []string
+		cmd := newCmd(func(out io.Writer, envVars []string, comm string) error {
+			commands = append(commands, comm)
+			env = envVars
+			return nil
+		})
+
+		msgs := []string{}
+		err := cmd.RunHooks(HookPreBuild, conf().Hooks, conf(), []string{"AZION_PREVIOUS_PREFIX=20240101"}, &msgs)
+		require.NoError(t, err)
+		require.Equal(t, []string{"npm run lint", "npm test"}, commands)
+		require.Equal(t, []string{
+			"AZION_HOOK=pre-build",
+			"AZION_APPLICATION_ID=10",
+			"AZION_WORKLOAD_ID=20",
+			"AZION_WORKLOAD_URL=https://example.map.azionedge.net",
+			"AZION_BUCKET=bucket",
+			"AZION_PREFIX=20240202",
+			"AZION_PREVIOUS_PREFIX=20240101",
+		}, env)
+		require.Len(t, msgs, 2)
+	})
+
+	t.Run("failing command stops the stage", func(t *testing.T) {
+		commands := []string{}
+		cmd := newCmd(func(out io.Writer, envVars []string, comm string) error {
+			commands = append(commands, comm)
+			return errors.New("exit status 1")
+		})
+
+		err := cmd.RunHooks(HookPreBuild, conf().Hooks, conf(), nil, &[]string{})
+		require.ErrorContains(t, err, "The pre-build hook 'npm run lint' failed: exit status 1")
+		require.Equal(t, []string{"npm run lint"}, commands)
+	})
+
+	t.Run("stages without commands are skipped", func(t *testing.T) {
+		cmd := newCmd(func(out io.Writer, envVars []string, comm string) error {
+			t.Fatalf("unexpected command %s", comm)
+			return nil
+		})
+
+		require.NoError(t, cmd.RunHooks(HookPostUpload, conf().Hooks, conf(), nil, &[]string{}))
+		require.NoError(t, cmd.RunHooks(HookPostDeploy, nil, conf(), nil, &[]string{}))
+	})
+}
+
+func TestProjectHooks(t *testing.T) {
+	conf := &contracts.AzionApplicationOptions{Hooks: &contracts.AzionJsonHooks{PreBuild: []string{"npm test"}}}
+
+	t.Run("hooks of azion.json are used when the manifest declares none", func(t *testing.T) {
+		require.Equal(t, conf.Hooks, ProjectHooks(conf, nil))
+		require.Equal(t, conf.Hooks, ProjectHooks(conf, &contracts.ManifestV4{}))
+	})
+
+	t.Run("hooks of azion.config replace the ones of azion.json", func(t *testing.T) {
+		interpreter := manifestInt.NewManifestInterpreter()
+		interpreter.GetWorkDir = func() (string, error) { return "/project", nil }
+		interpreter.FileReader = func(path string) ([]byte, error) {
+			require.Equal(t, "/project/.edge/manifest.json", path)
+			return []byte(`{"hooks": {"post-deploy": ["./smoke.sh"], "rollback-on-failure": true}}`), nil
+		}
+
+		require.Equal(t, &contracts.AzionJsonHooks{PostDeploy: []string{"./smoke.sh"}, RollbackOnFailure: true}, ProjectHooks(conf, BuiltManifest(interpreter)))
+	})
+
+	t.Run("projects not built yet have no manifest", func(t *testing.T) {
+		interpreter := manifestInt.NewManifestInterpreter()
+		interpreter.FileReader = func(path string) ([]byte, error) {
+			return nil, os.ErrNotExist
+		}
+
+		require.Nil(t, BuiltManifest(interpreter))
+	})
+}
+
+func TestWriteChangedFiles(t *testing.T) {
+	name, err := writeChangedFiles([]string{"/index.html", "/app.js"})
+	require.NoError(t, err)
+	defer os.Remove(name)
+
+	data, err := os.ReadFile(name)
+	require.NoError(t, err)
+	require.Equal(t, "/index.html\n/app.js\n", string(data))
+}
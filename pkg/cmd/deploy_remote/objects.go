@@ -0,0 +1,170 @@
+package deploy
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	msg "github.com/aziontech/azion-cli/messages/deploy"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+)
+
+const (
+	EncodingBrotli = "br"
+	EncodingGzip   = "gzip"
+
+	// minCompressSize is the size below which compressing a file is not worth a variant
+	minCompressSize = 1024
+)
+
+// compressionExtensions is the extension added to the name of the variants of each encoding
+var compressionExtensions = map[string]string{
+	EncodingBrotli: ".br",
+	EncodingGzip:   ".gz",
+}
+
+// validateStorageSettings checks the compression and object settings of a storage entry of the manifest
+func validateStorageSettings(storage contracts.StorageManifest) error {
+	for _, encoding := range storage.Compression {
+		if _, ok := compressionExtensions[encoding]; !ok {
+			return fmt.Errorf(msg.ErrorCompressionEncoding, encoding, storage.Dir)
+		}
+	}
+	for _, object := range storage.Objects {
+		if _, err := path.Match(strings.TrimPrefix(object.Pattern, "/"), ""); err != nil {
+			return fmt.Errorf(msg.ErrorObjectPattern, object.Pattern, err)
+		}
+	}
+	return nil
+}
+
+// fileObjects returns the objects stored for a file: the file itself with the settings of the patterns it
+// matches and, for text assets, a variant compressed with each encoding of the storage entry
+func fileObjects(storage contracts.StorageManifest, name, localPath, mimeType string, size int64) []contracts.FileOps {
+	settings := objectSettings(storage.Objects, name)
+	file := contracts.FileOps{
+		Path:            name,
+		MimeType:        mimeType,
+		LocalPath:       localPath,
+		ContentEncoding: settings.ContentEncoding,
+		CacheControl:    settings.CacheControl,
+		Metadata:        settings.Metadata,
+	}
+	objects := []contracts.FileOps{file}
+
+	// files already encoded are not compressed again
+	if settings.ContentEncoding != "" || size < minCompressSize || !compressible(mimeType) {
+		return objects
+	}
+	for _, encoding := range storage.Compression {
+		variant := file
+		variant.Path = name + compressionExtensions[encoding]
+		variant.Compression = encoding
+		variant.ContentEncoding = encoding
+		objects = append(objects, variant)
+	}
+	return objects
+}
+
+// objectSettings merges the settings of the patterns matching the file, later patterns taking precedence
+func objectSettings(objects []contracts.StorageObjectSettings, name string) contracts.StorageObjectSettings {
+	settings := contracts.StorageObjectSettings{}
+	for _, object := range objects {
+		if !matchPattern(object.Pattern, name) {
+			continue
+		}
+		if object.CacheControl != "" {
+			settings.CacheControl = object.CacheControl
+		}
+		if object.ContentEncoding != "" {
+			settings.ContentEncoding = object.ContentEncoding
+		}
+		for key, value := range object.Metadata {
+			if settings.Metadata == nil {
+				settings.Metadata = make(map[string]string)
+			}
+			settings.Metadata[key] = value
+		}
+	}
+	return settings
+}
+
+// matchPattern matches the path of a file, relative to the storage directory, against a glob pattern.
+// Patterns without a slash match the name of the file in any directory.
+func matchPattern(pattern, name string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+	name = strings.TrimPrefix(name, "/")
+	if !strings.Contains(pattern, "/") {
+		name = path.Base(name)
+	}
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
+
+// compressible reports whether the mime type is of a text asset
+func compressible(mimeType string) bool {
+	if strings.HasPrefix(mimeType, "text/") || strings.HasSuffix(mimeType, "+json") || strings.HasSuffix(mimeType, "+xml") {
+		return true
+	}
+	switch mimeType {
+	case "application/javascript", "application/x-javascript", "application/json", "application/xml",
+		"application/wasm", "application/x-font-ttf", "font/ttf", "font/otf", "image/x-icon", "image/vnd.microsoft.icon":
+		return true
+	}
+	return false
+}
+
+// objectHash identifies the content stored in an object, so that objects are uploaded again when the
+// settings they are stored with change
+func objectHash(hash string, object contracts.FileOps) string {
+	if object.Compression == "" && object.ContentEncoding == "" && object.CacheControl == "" && len(object.Metadata) == 0 {
+		return hash
+	}
+	settings, _ := json.Marshal(struct {
+		Hash            string            `json:"hash"`
+		Compression     string            `json:"compression"`
+		ContentEncoding string            `json:"content_encoding"`
+		CacheControl    string            `json:"cache_control"`
+		Metadata        map[string]string `json:"metadata"`
+	}{hash, object.Compression, object.ContentEncoding, object.CacheControl, object.Metadata})
+	return fmt.Sprintf("%x", sha256.Sum256(settings))
+}
+
+// compressFile writes the content of file, encoded with encoding, to a temporary file rewound to be uploaded.
+// The caller removes the temporary file.
+func compressFile(file io.Reader, encoding string) (*os.File, error) {
+	compressed, err := os.CreateTemp("", "azion-*"+compressionExtensions[encoding])
+	if err != nil {
+		return nil, err
+	}
+
+	var writer io.WriteCloser
+	switch encoding {
+	case EncodingBrotli:
+		writer = brotli.NewWriterLevel(compressed, brotli.BestCompression)
+	case EncodingGzip:
+		writer, err = gzip.NewWriterLevel(compressed, gzip.BestCompression)
+	default:
+		err = fmt.Errorf(msg.ErrorUnknownEncoding, encoding)
+	}
+	if err == nil {
+		if _, err = io.Copy(writer, file); err == nil {
+			err = writer.Close()
+		}
+	}
+	if err == nil {
+		_, err = compressed.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		compressed.Close()
+		os.Remove(compressed.Name())
+		return nil, err
+	}
+	return compressed, nil
+}
@@ -0,0 +1,89 @@
+package deploy
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileObjects(t *testing.T) {
+	storage := contracts.StorageManifest{
+		Dir:         ".edge/storage",
+		Compression: []string{EncodingBrotli, EncodingGzip},
+		Objects: []contracts.StorageObjectSettings{
+			{Pattern: "*", CacheControl: "max-age=60"},
+			{Pattern: "assets/*.js", CacheControl: "max-age=31536000, immutable", Metadata: map[string]string{"team": "web"}},
+			{Pattern: "*.wasm.gz", ContentEncoding: "gzip"},
+		},
+	}
+
+	t.Run("text assets get compressed variants", func(t *testing.T) {
+		objects := fileObjects(storage, "/assets/app.js", "/tmp/app.js", "application/javascript", 4096)
+		require.Len(t, objects, 3)
+		require.Equal(t, "/assets/app.js", objects[0].Path)
+		require.Empty(t, objects[0].ContentEncoding)
+		require.Equal(t, "max-age=31536000, immutable", objects[0].CacheControl)
+		require.Equal(t, map[string]string{"team": "web"}, objects[0].Metadata)
+
+		require.Equal(t, "/assets/app.js.br", objects[1].Path)
+		require.Equal(t, EncodingBrotli, objects[1].Compression)
+		require.Equal(t, EncodingBrotli, objects[1].ContentEncoding)
+		require.Equal(t, "application/javascript", objects[1].MimeType)
+		require.Equal(t, "/assets/app.js.gz", objects[2].Path)
+		require.Equal(t, EncodingGzip, objects[2].ContentEncoding)
+	})
+
+	t.Run("binary, small and encoded files are not compressed", func(t *testing.T) {
+		require.Len(t, fileObjects(storage, "/image.png", "/tmp/image.png", "image/png", 4096), 1)
+		require.Len(t, fileObjects(storage, "/index.html", "/tmp/index.html", "text/html", 100), 1)
+
+		objects := fileObjects(storage, "/app.wasm.gz", "/tmp/app.wasm.gz", "application/gzip", 4096)
+		require.Len(t, objects, 1)
+		require.Equal(t, "gzip", objects[0].ContentEncoding)
+		require.Equal(t, "max-age=60", objects[0].CacheControl)
+	})
+
+	t.Run("settings change the hash of the object", func(t *testing.T) {
+		plain := contracts.FileOps{Path: "/index.html"}
+		require.Equal(t, "aaa", objectHash("aaa", plain))
+
+		cached := contracts.FileOps{Path: "/index.html", CacheControl: "max-age=60"}
+		require.NotEqual(t, "aaa", objectHash("aaa", cached))
+		cached.CacheControl = "max-age=120"
+		require.NotEqual(t, objectHash("aaa", contracts.FileOps{CacheControl: "max-age=60"}), objectHash("aaa", cached))
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		require.Error(t, validateStorageSettings(contracts.StorageManifest{Compression: []string{"zstd"}}))
+		require.Error(t, validateStorageSettings(contracts.StorageManifest{Objects: []contracts.StorageObjectSettings{{Pattern: "[a-"}}}))
+		require.NoError(t, validateStorageSettings(storage))
+	})
+}
+
+func TestCompressFile(t *testing.T) {
+	content := strings.Repeat("body { color: red; }\n", 100)
+	readers := map[string]func(io.Reader) (io.Reader, error){
+		EncodingBrotli: func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil },
+		EncodingGzip:   func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+	}
+	for encoding, newReader := range readers {
+		t.Run(encoding, func(t *testing.T) {
+			compressed, err := compressFile(strings.NewReader(content), encoding)
+			require.NoError(t, err)
+			defer os.Remove(compressed.Name())
+			defer compressed.Close()
+
+			reader, err := newReader(compressed)
+			require.NoError(t, err)
+			decompressed, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			require.Equal(t, content, string(decompressed))
+		})
+	}
+}
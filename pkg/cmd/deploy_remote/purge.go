@@ -2,7 +2,6 @@ package deploy
 
 import (
 	"context"
-	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -145,14 +144,20 @@ func ReadFilesEdgeStorage() ([]Data, error) {
 			return err
 		}
 		if !info.IsDir() {
-			content, err := os.ReadFile(path)
+			file, err := os.Open(path)
+			if err != nil {
+				logger.Debug("Error read file", zap.Error(err))
+				return err
+			}
+			defer file.Close()
+			hash, err := hashFile(file)
 			if err != nil {
 				logger.Debug("Error read file", zap.Error(err))
 				return err
 			}
 			dt := Data{
 				Name: path,
-				Hash: fmt.Sprintf("%x", sha256.Sum256(content)),
+				Hash: hash,
 			}
 			data = append(data, dt)
 		}
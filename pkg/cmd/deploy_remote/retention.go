@@ -0,0 +1,76 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	msg "github.com/aziontech/azion-cli/messages/deploy-remote"
+	apiStorage "github.com/aziontech/azion-cli/pkg/api/storage"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/schedule"
+	"go.uber.org/zap"
+)
+
+// keepVersions returns how many versions are kept in the bucket: the value of --keep-versions or, without it,
+// the largest keep_versions of the storage of the manifest. Zero keeps every version.
+func keepVersions(manifest *contracts.ManifestV4) int {
+	if KeepVersions > 0 {
+		return KeepVersions
+	}
+	keep := 0
+	for _, storage := range manifest.Storage {
+		keep = max(keep, storage.KeepVersions)
+	}
+	return keep
+}
+
+// expiredVersions returns the versions older than the newest keep ones. The current version is never
+// expired, even when it is older after a rollback.
+func expiredVersions(versions []string, current string, keep int) []string {
+	expired := []string{}
+	if keep <= 0 || len(versions) <= keep {
+		return expired
+	}
+	for _, version := range versions[keep:] {
+		if version != current {
+			expired = append(expired, version)
+		}
+	}
+	return expired
+}
+
+// pruneVersions removes the objects of the versions past the retention from the bucket. Each version is
+// scheduled before being deleted, so the ones interrupted or failed are deleted in the next runs of the CLI.
+func (cmd *DeployCmd) pruneVersions(ctx context.Context, client *apiStorage.Client, conf *contracts.AzionApplicationOptions, manifest *contracts.ManifestV4, msgs *[]string) error {
+	keep := keepVersions(manifest)
+	if keep == 0 || conf.Bucket == "" {
+		return nil
+	}
+
+	versions, err := ListVersions(ctx, client, conf.Bucket)
+	if err != nil {
+		return err
+	}
+	prefixes := make([]string, len(versions))
+	for i, version := range versions {
+		prefixes[i] = version.Prefix
+	}
+	expired := expiredVersions(prefixes, conf.Prefix, keep)
+	if len(expired) == 0 {
+		return nil
+	}
+
+	for _, version := range expired {
+		logger.Debug("Scheduling the removal of an old version", zap.String("bucket", conf.Bucket), zap.String("prefix", version))
+		if err := schedule.NewSchedule(nil, cmd.F, schedule.VersionName(conf.Bucket, version), schedule.DELETE_VERSION); err != nil {
+			return err
+		}
+	}
+	schedule.ExecSchedulesOfKind(cmd.F, schedule.DELETE_VERSION)
+
+	msgf := fmt.Sprintf(msg.DeployVersionsRemoved, len(expired), keep)
+	logger.FInfoFlags(cmd.Io.Out, msgf, cmd.F.Format, cmd.F.Out)
+	*msgs = append(*msgs, msgf)
+	return nil
+}
@@ -0,0 +1,37 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpiredVersions(t *testing.T) {
+	versions := []string{"20240105000000", "20240104000000", "20240103000000", "20240102000000", "20240101000000"}
+
+	t.Run("versions past the newest ones kept are expired", func(t *testing.T) {
+		require.Equal(t, []string{"20240102000000", "20240101000000"}, expiredVersions(versions, "20240105000000", 3))
+	})
+
+	t.Run("the current version is kept after a rollback", func(t *testing.T) {
+		require.Equal(t, []string{"20240103000000", "20240101000000"}, expiredVersions(versions, "20240102000000", 2))
+	})
+
+	t.Run("nothing is expired within the retention or without it", func(t *testing.T) {
+		require.Empty(t, expiredVersions(versions, "20240105000000", 5))
+		require.Empty(t, expiredVersions(versions, "20240105000000", 0))
+	})
+}
+
+func TestKeepVersions(t *testing.T) {
+	manifest := &contracts.ManifestV4{Storage: []contracts.StorageManifest{{KeepVersions: 3}, {KeepVersions: 5}, {}}}
+
+	KeepVersions = 0
+	require.Equal(t, 5, keepVersions(manifest))
+	require.Equal(t, 0, keepVersions(&contracts.ManifestV4{}))
+
+	KeepVersions = 2
+	defer func() { KeepVersions = 0 }()
+	require.Equal(t, 2, keepVersions(manifest))
+}
@@ -0,0 +1,121 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	msg "github.com/aziontech/azion-cli/messages/deploy-remote"
+	apiConnector "github.com/aziontech/azion-cli/pkg/api/connector"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/utils"
+	edgesdk "github.com/aziontech/azionapi-v4-go-sdk-dev/azion-api"
+	"go.uber.org/zap"
+)
+
+// rollbackDeploy reverts a deploy that failed its health check or post-deploy hooks. A canary deployment is removed, while
+// otherwise the storage connectors of the project are pointed back to the static files of the previous
+// version, as done by 'azion rollback'.
+func (cmd *DeployCmd) rollbackDeploy(ctx context.Context, clients *Clients, conf *contracts.AzionApplicationOptions, previous string, msgs *[]string) error {
+	if Canary != 0 {
+		logger.FInfoFlags(cmd.Io.Out, msg.DeployRollingBackCanary, cmd.F.Format, cmd.F.Out)
+		*msgs = append(*msgs, msg.DeployRollingBackCanary)
+		return cmd.removeCanary(ctx, clients, conf, false, msgs)
+	}
+
+	if previous == "" || previous == conf.Prefix {
+		return msg.ErrorNoPreviousVersion
+	}
+
+	msgf := fmt.Sprintf(msg.DeployRollingBack, previous)
+	logger.FInfoFlags(cmd.Io.Out, msgf, cmd.F.Format, cmd.F.Out)
+	*msgs = append(*msgs, msgf)
+
+	connectors, err := StorageConnectors(ctx, clients.Connector.Get, conf)
+	if err != nil {
+		return err
+	}
+	for _, id := range connectors {
+		if err := SetConnectorPrefix(ctx, clients.Connector, id, conf.Bucket, previous); err != nil {
+			return err
+		}
+	}
+	conf.Prefix = previous
+
+	if len(conf.Workloads.Domains) > 0 {
+		if err := cmd.PurgeWildcard(conf.Workloads.Domains, "/*"); err != nil {
+			msgf := fmt.Sprintf(msg.DeployRollbackPurgeFailed, err.Error())
+			logger.FInfoFlags(cmd.Io.Out, msgf, cmd.F.Format, cmd.F.Out)
+			*msgs = append(*msgs, msgf)
+		}
+	}
+	return nil
+}
+
+// rollbackOnFailure rolls back a deploy that failed after its resources were updated, and returns the failure
+func (cmd *DeployCmd) rollbackOnFailure(ctx context.Context, clients *Clients, conf *contracts.AzionApplicationOptions, previous string, failure error, msgs *[]string) error {
+	if err := cmd.rollbackDeploy(ctx, clients, conf, previous, msgs); err != nil {
+		logger.Debug("Error while rolling back the deploy", zap.Error(err))
+		return fmt.Errorf(msg.ErrorRollbackDeploy, failure, err.Error())
+	}
+	return failure
+}
+
+// StorageConnectors returns the IDs of the connectors in azion.json that serve static files from the bucket
+// of the project. Connectors no longer found are skipped.
+func StorageConnectors(ctx context.Context, get func(ctx context.Context, id int64) (edgesdk.Connector, error), conf *contracts.AzionApplicationOptions) ([]int64, error) {
+	ids := []int64{}
+	for _, connector := range conf.Connectors {
+		// http connectors are the ones with addresses
+		if len(connector.Address) > 0 {
+			continue
+		}
+		resp, err := get(ctx, connector.Id)
+		if errors.Is(err, utils.ErrorNotFound404) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if resp.ConnectorBase == nil {
+			continue
+		}
+
+		data, err := json.Marshal(resp.ConnectorBase)
+		if err != nil {
+			return nil, err
+		}
+		var storage struct {
+			Attributes struct {
+				Bucket string `json:"bucket"`
+			} `json:"attributes"`
+		}
+		if err := json.Unmarshal(data, &storage); err != nil {
+			return nil, err
+		}
+		if storage.Attributes.Bucket == conf.Bucket {
+			ids = append(ids, connector.Id)
+		}
+	}
+	return ids, nil
+}
+
+// SetConnectorPrefix points a storage connector to the static files of a version of the bucket
+func SetConnectorPrefix(ctx context.Context, client *apiConnector.Client, id int64, bucket, prefix string) error {
+	request := apiConnector.UpdateRequest{}
+
+	attributes := edgesdk.ConnectorStorageAttributesRequest{}
+	attributes.SetBucket(bucket)
+	attributes.SetPrefix(prefix)
+
+	storageRequest := edgesdk.PatchedConnectorRequest{}
+	storageConnectorRequest := edgesdk.PatchedConnectorRequestBase{}
+	storageConnectorRequest.SetAttributes(attributes)
+	storageRequest.PatchedConnectorRequestBase = &storageConnectorRequest
+	request.PatchedConnectorRequest = storageRequest
+
+	_, err := client.Update(ctx, &request, id)
+	return err
+}
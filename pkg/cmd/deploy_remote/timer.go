@@ -135,7 +135,8 @@ func (ts *TimingSummary) PrintSummary() {
 	logger.Debug("============================================")
 }
 
-// HandleManifestTimingCallback handles timing callbacks from the manifest package
+// HandleManifestTimingCallback handles timing callbacks from the manifest package.
+// Durations are accumulated, since application scoped steps run once per application.
 func HandleManifestTimingCallback(name string, duration time.Duration) {
 	if GlobalTimingSummary == nil {
 		return
@@ -145,25 +146,25 @@ func HandleManifestTimingCallback(name string, duration time.Duration) {
 
 	switch name {
 	case "ManifestFunctions":
-		GlobalTimingSummary.ManifestFunctionsTime = duration
+		GlobalTimingSummary.ManifestFunctionsTime += duration
 	case "ManifestFunctionInstances":
-		GlobalTimingSummary.ManifestFunctionInstancesTime = duration
+		GlobalTimingSummary.ManifestFunctionInstancesTime += duration
 	case "ManifestEdgeApplication":
-		GlobalTimingSummary.ManifestEdgeApplicationTime = duration
+		GlobalTimingSummary.ManifestEdgeApplicationTime += duration
 	case "ManifestCacheSettings":
-		GlobalTimingSummary.ManifestCacheSettingsTime = duration
+		GlobalTimingSummary.ManifestCacheSettingsTime += duration
 	case "ManifestConnectors":
-		GlobalTimingSummary.ManifestConnectorsTime = duration
+		GlobalTimingSummary.ManifestConnectorsTime += duration
 	case "ManifestRulesEngine":
-		GlobalTimingSummary.ManifestRulesEngineTime = duration
+		GlobalTimingSummary.ManifestRulesEngineTime += duration
 	case "ManifestWorkloads":
-		GlobalTimingSummary.ManifestWorkloadsTime = duration
+		GlobalTimingSummary.ManifestWorkloadsTime += duration
 	case "ManifestWorkloadDeployments":
-		GlobalTimingSummary.ManifestWorkloadDeploymentsTime = duration
+		GlobalTimingSummary.ManifestWorkloadDeploymentsTime += duration
 	case "ManifestFirewalls":
-		GlobalTimingSummary.ManifestFirewallsTime = duration
+		GlobalTimingSummary.ManifestFirewallsTime += duration
 	case "ManifestPurge":
-		GlobalTimingSummary.ManifestPurgeTime = duration
+		GlobalTimingSummary.ManifestPurgeTime += duration
 	default:
 		// Unknown timing name, ignore
 	}
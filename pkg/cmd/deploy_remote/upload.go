@@ -2,10 +2,11 @@ package deploy
 
 import (
 	"errors"
+	"fmt"
 	"os"
-	"path"
-	"strings"
+	"sync/atomic"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	msg "github.com/aziontech/azion-cli/messages/deploy"
 	"github.com/aziontech/azion-cli/pkg/api/s3"
 	"github.com/aziontech/azion-cli/pkg/cmdutil"
@@ -28,13 +29,40 @@ func ReadSettings(path string) (token.Settings, error) {
 }
 
 func (cmd *DeployCmd) uploadFiles(
-	f *cmdutil.Factory, conf *contracts.AzionApplicationOptions, msgs *[]string, dir string, bucket string, settings token.Settings) error {
-	logger.Debug("Path to be uploaded: " + dir)
-
+	f *cmdutil.Factory, conf *contracts.AzionApplicationOptions, msgs *[]string, storage contracts.StorageManifest, bucket string,
+	settings token.Settings, previous, uploaded *UploadIndex, journal *UploadJournal) error {
 	cfg, err := s3.New(settings.S3AccessKey, settings.S3SecretKey)
 	if err != nil {
 		return errors.New(msg.ErrorUnableSDKConfig + err.Error())
 	}
+	return cmd.upload(f, conf, msgs, storage, bucket, cfg, previous, uploaded, journal)
+}
+
+// uploadFilesWithCreds uploads files using S3Credentials instead of Settings
+func (cmd *DeployCmd) uploadFilesWithCreds(
+	f *cmdutil.Factory, conf *contracts.AzionApplicationOptions, msgs *[]string, storage contracts.StorageManifest, bucket string,
+	creds token.S3Credentials, previous, uploaded *UploadIndex, journal *UploadJournal) error {
+	cfg, err := s3.New(creds.S3AccessKey, creds.S3SecretKey)
+	if err != nil {
+		return errors.New(msg.ErrorUnableSDKConfig + err.Error())
+	}
+	return cmd.upload(f, conf, msgs, storage, bucket, cfg, previous, uploaded, journal)
+}
+
+// upload sends the files of dir to the bucket under the prefix of the project. Files found in the upload index
+// of the previous deploy are not transferred again: they are skipped when the object is already in place, or
+// copied server-side from the object holding the same content. Uploaded files are recorded in uploaded.
+// The progress is kept in the journal, and files it records as stored by an interrupted deploy are skipped.
+// Objects are stored with the settings of the storage entry, along with the compressed variants of text assets.
+func (cmd *DeployCmd) upload(
+	f *cmdutil.Factory, conf *contracts.AzionApplicationOptions, msgs *[]string, storage contracts.StorageManifest, bucket string,
+	cfg aws.Config, previous, uploaded *UploadIndex, journal *UploadJournal) error {
+	dir := storage.Dir
+	logger.Debug("Path to be uploaded: " + dir)
+
+	if err := validateStorageSettings(storage); err != nil {
+		return err
+	}
 
 	logger.FInfoFlags(cmd.F.IOStreams.Out, msg.UploadStart, f.Format, f.Out)
 	*msgs = append(*msgs, msg.UploadStart)
@@ -46,36 +74,52 @@ func (cmd *DeployCmd) uploadFiles(
 
 	// Collect all files in a single walk to avoid double traversal
 	var fileOps []contracts.FileOps
-	if err := cmd.FilepathWalk(dir, func(pathDir string, info os.FileInfo, err error) error {
+	hashes := make(map[string]string)
+	copied, unchanged, resumed := 0, 0, 0
+	if err := cmd.walkStorage(storage, func(pathDir, fileString string, info os.FileInfo) error {
+		fileContent, err := cmd.Open(pathDir)
 		if err != nil {
+			logger.Debug("Error while trying to read file <"+pathDir+"> about to be uploaded", zap.Error(err))
 			return err
 		}
 
-		if info.Mode()&os.ModeSymlink != 0 {
-			logger.Debug("Skipping symlink file", zap.Any("File name", pathDir))
-			return nil
+		hash, err := hashFile(fileContent)
+		// the file is opened again by the worker that uploads it
+		fileContent.Close()
+		if err != nil {
+			logger.Debug("Error while hashing file <"+pathDir+">", zap.Error(err))
+			return err
 		}
-		logger.Debug("Reading the following file", zap.Any("File name", pathDir))
-		if !info.IsDir() {
-			fileContent, err := cmd.Open(pathDir)
-			if err != nil {
-				logger.Debug("Error while trying to read file <"+pathDir+"> about to be uploaded", zap.Error(err))
-				return err
+
+		mimeType, err := mimemagic.MatchFilePath(pathDir, -1)
+		if err != nil {
+			logger.Debug("Error while matching file path", zap.Error(err))
+			return err
+		}
+
+		for _, object := range fileObjects(storage, fileString, pathDir, mimeType.MediaType(), info.Size()) {
+			objectHash := objectHash(hash, object)
+			hashes[object.Path] = objectHash
+
+			if journal.Uploaded(object.Path, objectHash) {
+				logger.Debug("Skipping file uploaded before the interruption", zap.String("File name", object.Path))
+				resumed++
+				continue
 			}
+			journal.Add(object.Path, objectHash)
 
-			fileString := strings.TrimPrefix(pathDir, path.Clean(dir))
-			mimeType, err := mimemagic.MatchFilePath(pathDir, -1)
-			if err != nil {
-				logger.Debug("Error while matching file path", zap.Error(err))
-				return err
+			source, isUnchanged := previous.Source(bucket, conf.Prefix, object.Path, objectHash)
+			if isUnchanged {
+				logger.Debug("Skipping unchanged file", zap.String("File name", object.Path))
+				unchanged++
+				continue
 			}
-			fileOptions := contracts.FileOps{
-				Path:        fileString,
-				MimeType:    mimeType.MediaType(),
-				FileContent: fileContent,
+			object.CopySource = source
+			if source != "" {
+				copied++
 			}
 
-			fileOps = append(fileOps, fileOptions)
+			fileOps = append(fileOps, object)
 		}
 		return nil
 	}); err != nil {
@@ -83,134 +127,58 @@ func (cmd *DeployCmd) uploadFiles(
 		return err
 	}
 
-	totalFiles := len(fileOps)
-	if totalFiles == 0 {
-		logger.FInfoFlags(cmd.F.IOStreams.Out, msg.UploadSuccessful, f.Format, f.Out)
-		*msgs = append(*msgs, msg.UploadSuccessful)
-		return nil
+	if resumed > 0 {
+		msgf := fmt.Sprintf(msg.UploadResumed, resumed)
+		logger.FInfoFlags(cmd.F.IOStreams.Out, msgf, f.Format, f.Out)
+		*msgs = append(*msgs, msgf)
 	}
 
-	// Create channels and workers after we know the file count
-	jobsChan := make(chan contracts.FileOps, totalFiles)
-	results := make(chan error, noOfWorkers)
-
-	// Create worker goroutines
-	for i := 1; i <= noOfWorkers; i++ {
-		go Worker(jobsChan, results, &currentFile, cfg, bucket, conf.Prefix)
-	}
-
-	bar := progressbar.NewOptions(
-		totalFiles,
-		progressbar.OptionSetDescription("Uploading files"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWriter(cmd.F.IOStreams.Out),
-		progressbar.OptionClearOnFinish(),
-	)
-
-	if f.Silent {
-		bar = nil
+	totalFiles := len(fileOps)
+	if totalFiles > 0 {
+		if err := cmd.writeUploadJournal(journal, ProjectConf); err != nil {
+			logger.Debug("Error while writing the upload journal", zap.Error(err))
+			return err
+		}
+		if err := cmd.runUploadWorkers(f, fileOps, cfg, bucket, conf.Prefix, noOfWorkers, &currentFile, journal); err != nil {
+			return err
+		}
 	}
 
-	// Queue all files for upload
-	for _, fileOp := range fileOps {
-		jobsChan <- fileOp
+	for name, hash := range hashes {
+		uploaded.Add(name, hash)
 	}
-	close(jobsChan)
-
-	// Check for errors from workers
-	for a := 1; a <= totalFiles; a++ {
-		result := <-results
-		if result != nil {
-			return result
-		}
 
-		if bar != nil {
-			err := bar.Set(int(currentFile))
-			if err != nil {
-				return err
-			}
-		}
+	if copied > 0 || unchanged > 0 {
+		msgf := fmt.Sprintf(msg.UploadIncremental, totalFiles-copied, copied, unchanged)
+		logger.FInfoFlags(cmd.F.IOStreams.Out, msgf, f.Format, f.Out)
+		*msgs = append(*msgs, msgf)
 	}
-
-	// All jobs are processed, no more values will be sent on results:
-	close(results)
 	logger.FInfoFlags(cmd.F.IOStreams.Out, msg.UploadSuccessful, f.Format, f.Out)
 	*msgs = append(*msgs, msg.UploadSuccessful)
 
 	return nil
 }
 
-// uploadFilesWithCreds uploads files using S3Credentials instead of Settings
-func (cmd *DeployCmd) uploadFilesWithCreds(
-	f *cmdutil.Factory, conf *contracts.AzionApplicationOptions, msgs *[]string, dir string, bucket string, creds token.S3Credentials) error {
-	logger.Debug("Path to be uploaded: " + dir)
-
-	cfg, err := s3.New(creds.S3AccessKey, creds.S3SecretKey)
-	if err != nil {
-		return errors.New(msg.ErrorUnableSDKConfig + err.Error())
-	}
-
-	logger.FInfoFlags(cmd.F.IOStreams.Out, msg.UploadStart, f.Format, f.Out)
-	*msgs = append(*msgs, msg.UploadStart)
-
-	noOfWorkers := workers.CalculateOptimal(Workers)
-	logger.Debug("Using workers for upload", zap.Int("worker_count", noOfWorkers))
-
-	var currentFile int64
-
-	// Collect all files in a single walk to avoid double traversal
-	var fileOps []contracts.FileOps
-	if err := cmd.FilepathWalk(dir, func(pathDir string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if info.Mode()&os.ModeSymlink != 0 {
-			logger.Debug("Skipping symlink file", zap.Any("File name", pathDir))
-			return nil
-		}
-		logger.Debug("Reading the following file", zap.Any("File name", pathDir))
-		if !info.IsDir() {
-			fileContent, err := cmd.Open(pathDir)
-			if err != nil {
-				logger.Debug("Error while trying to read file <"+pathDir+"> about to be uploaded", zap.Error(err))
-				return err
-			}
-
-			fileString := strings.TrimPrefix(pathDir, path.Clean(dir))
-			mimeType, err := mimemagic.MatchFilePath(pathDir, -1)
-			if err != nil {
-				logger.Debug("Error while matching file path", zap.Error(err))
-				return err
-			}
-			fileOptions := contracts.FileOps{
-				Path:        fileString,
-				MimeType:    mimeType.MediaType(),
-				FileContent: fileContent,
-			}
-
-			fileOps = append(fileOps, fileOptions)
-		}
-		return nil
-	}); err != nil {
-		logger.Debug("Error while reading files to be uploaded", zap.Error(err))
-		return err
-	}
-
+// runUploadWorkers transfers the files through a pool of workers, showing the progress. The journal is written
+// from time to time and when an upload fails, so that the deploy can be resumed.
+func (cmd *DeployCmd) runUploadWorkers(
+	f *cmdutil.Factory, fileOps []contracts.FileOps, cfg aws.Config, bucket, prefix string, noOfWorkers int, currentFile *int64,
+	journal *UploadJournal) error {
 	totalFiles := len(fileOps)
-	if totalFiles == 0 {
-		logger.FInfoFlags(cmd.F.IOStreams.Out, msg.UploadSuccessful, f.Format, f.Out)
-		*msgs = append(*msgs, msg.UploadSuccessful)
-		return nil
-	}
 
 	// Create channels and workers after we know the file count
 	jobsChan := make(chan contracts.FileOps, totalFiles)
 	results := make(chan error, noOfWorkers)
 
+	multipart := s3.MultipartOptions{
+		Threshold: MultipartThreshold * 1024 * 1024,
+		PartSize:  s3.DefaultPartSize,
+		StateDir:  s3.MultipartStateDir,
+	}
+
 	// Create worker goroutines
 	for i := 1; i <= noOfWorkers; i++ {
-		go Worker(jobsChan, results, &currentFile, cfg, bucket, conf.Prefix)
+		go Worker(jobsChan, results, currentFile, cfg, bucket, prefix, multipart, journal)
 	}
 
 	bar := progressbar.NewOptions(
@@ -235,11 +203,19 @@ func (cmd *DeployCmd) uploadFilesWithCreds(
 	for a := 1; a <= totalFiles; a++ {
 		result := <-results
 		if result != nil {
+			if err := cmd.writeUploadJournal(journal, ProjectConf); err != nil {
+				logger.Debug("Error while writing the upload journal", zap.Error(err))
+			}
 			return result
 		}
+		if journal.shouldFlush() {
+			if err := cmd.writeUploadJournal(journal, ProjectConf); err != nil {
+				logger.Debug("Error while writing the upload journal", zap.Error(err))
+			}
+		}
 
 		if bar != nil {
-			err := bar.Set(int(currentFile))
+			err := bar.Set(int(atomic.LoadInt64(currentFile)))
 			if err != nil {
 				return err
 			}
@@ -248,13 +224,10 @@ func (cmd *DeployCmd) uploadFilesWithCreds(
 
 	// All jobs are processed, no more values will be sent on results:
 	close(results)
-	logger.FInfoFlags(cmd.F.IOStreams.Out, msg.UploadSuccessful, f.Format, f.Out)
-	*msgs = append(*msgs, msg.UploadSuccessful)
-
 	return nil
 }
 
 // UploadFiles is the package-level function that calls the method
 func UploadFiles(f *cmdutil.Factory, conf *contracts.AzionApplicationOptions, msgs *[]string, dir string, bucket string, settings token.Settings, cmd *DeployCmd) error {
-	return cmd.uploadFiles(f, conf, msgs, dir, bucket, settings)
+	return cmd.uploadFiles(f, conf, msgs, contracts.StorageManifest{Dir: dir}, bucket, settings, nil, nil, nil)
 }
@@ -0,0 +1,118 @@
+package deploy
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// UploadIndexFile keeps, next to azion.json, the hash of every object uploaded by the last deploy
+const UploadIndexFile = "uploads.json"
+
+// UploadIndex records the objects a deploy uploaded to the bucket, so that the next deploy only
+// transfers the files whose content changed
+type UploadIndex struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix"`
+	Files  []Data `json:"files"`
+
+	byName map[string]string
+	byHash map[string]string
+}
+
+func NewUploadIndex(bucket, prefix string) *UploadIndex {
+	return &UploadIndex{Bucket: bucket, Prefix: prefix, Files: []Data{}}
+}
+
+// Add records an object of the bucket along with the hash of its content
+func (index *UploadIndex) Add(name, hash string) {
+	if index == nil {
+		return
+	}
+	if index.byName == nil {
+		index.byName = make(map[string]string)
+		index.byHash = make(map[string]string)
+	}
+	if _, ok := index.byName[name]; !ok {
+		index.Files = append(index.Files, Data{Name: name, Hash: hash})
+	}
+	index.byName[name] = hash
+	if _, ok := index.byHash[hash]; !ok {
+		index.byHash[hash] = name
+	}
+}
+
+// Source looks for the content of a file among the objects of the last deploy. It returns unchanged when the
+// object at the same key already holds it, or the key of an object with the same content to be copied.
+// Objects of another bucket are never used.
+func (index *UploadIndex) Source(bucket, prefix, name, hash string) (key string, unchanged bool) {
+	if index == nil || index.Bucket != bucket || index.byHash == nil {
+		return "", false
+	}
+	if index.Prefix == prefix && index.byName[name] == hash {
+		return "", true
+	}
+	if source, ok := index.byHash[hash]; ok {
+		return path.Join(index.Prefix, source), false
+	}
+	return "", false
+}
+
+// Changed returns the names of the files whose content is not found under the same name in the previous index
+func (index *UploadIndex) Changed(previous *UploadIndex) []string {
+	changed := []string{}
+	for _, file := range index.Files {
+		if previous == nil || previous.byName[file.Name] != file.Hash {
+			changed = append(changed, file.Name)
+		}
+	}
+	return changed
+}
+
+// readUploadIndex reads the index written by the last deploy. Without it every file is uploaded.
+func (cmd *DeployCmd) readUploadIndex(confPath string) *UploadIndex {
+	data, err := cmd.FileReader(path.Join(confPath, UploadIndexFile))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Debug("Error while reading the upload index", zap.Error(err))
+		}
+		return nil
+	}
+
+	stored := UploadIndex{}
+	if err := cmd.Unmarshal(data, &stored); err != nil {
+		logger.Debug("Error while decoding the upload index, uploading every file", zap.Error(err))
+		return nil
+	}
+	index := NewUploadIndex(stored.Bucket, stored.Prefix)
+	for _, file := range stored.Files {
+		index.Add(file.Name, file.Hash)
+	}
+	return index
+}
+
+func (cmd *DeployCmd) writeUploadIndex(index *UploadIndex, confPath string) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return cmd.WriteFile(path.Join(confPath, UploadIndexFile), data, 0644)
+}
+
+// hashFile returns the SHA-256 of the content of a file, the same hash kept in files.json, and rewinds it
+func hashFile(file io.ReadSeeker) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
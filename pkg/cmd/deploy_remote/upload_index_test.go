@@ -0,0 +1,98 @@
+package deploy
+
+import (
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadIndex(t *testing.T) {
+	previous := NewUploadIndex("bucket", "20240101")
+	previous.Add("/index.html", "aaa")
+	previous.Add("/app.js", "bbb")
+
+	tests := []struct {
+		name      string
+		bucket    string
+		prefix    string
+		file      string
+		hash      string
+		source    string
+		unchanged bool
+	}{
+		{name: "same prefix and content", bucket: "bucket", prefix: "20240101", file: "/index.html", hash: "aaa", unchanged: true},
+		{name: "content of the previous prefix is copied", bucket: "bucket", prefix: "20240202", file: "/index.html", hash: "aaa", source: "20240101/index.html"},
+		{name: "renamed file is copied", bucket: "bucket", prefix: "20240101", file: "/main.js", hash: "bbb", source: "20240101/app.js"},
+		{name: "changed content is uploaded", bucket: "bucket", prefix: "20240101", file: "/index.html", hash: "ccc"},
+		{name: "another bucket is not used", bucket: "other", prefix: "20240101", file: "/index.html", hash: "aaa"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, unchanged := previous.Source(tt.bucket, tt.prefix, tt.file, tt.hash)
+			require.Equal(t, tt.source, source)
+			require.Equal(t, tt.unchanged, unchanged)
+		})
+	}
+
+	t.Run("changed files", func(t *testing.T) {
+		uploaded := NewUploadIndex("bucket", "20240202")
+		uploaded.Add("/index.html", "aaa")
+		uploaded.Add("/app.js", "ccc")
+		uploaded.Add("/main.css", "ddd")
+		require.Equal(t, []string{"/app.js", "/main.css"}, uploaded.Changed(previous))
+		require.Equal(t, []string{"/index.html", "/app.js", "/main.css"}, uploaded.Changed(nil))
+	})
+
+	t.Run("without index every file is uploaded", func(t *testing.T) {
+		var index *UploadIndex
+		source, unchanged := index.Source("bucket", "20240101", "/index.html", "aaa")
+		require.Empty(t, source)
+		require.False(t, unchanged)
+	})
+
+	t.Run("index is kept next to azion.json", func(t *testing.T) {
+		files := map[string][]byte{}
+		cmd := &DeployCmd{
+			Unmarshal: json.Unmarshal,
+			FileReader: func(path string) ([]byte, error) {
+				if data, ok := files[path]; ok {
+					return data, nil
+				}
+				return nil, os.ErrNotExist
+			},
+			WriteFile: func(filename string, data []byte, perm fs.FileMode) error {
+				files[filename] = data
+				return nil
+			},
+		}
+		require.Nil(t, cmd.readUploadIndex("azion"))
+
+		require.NoError(t, cmd.writeUploadIndex(previous, "azion"))
+		index := cmd.readUploadIndex("azion")
+		require.Equal(t, previous.Files, index.Files)
+		_, unchanged := index.Source("bucket", "20240101", "/app.js", "bbb")
+		require.True(t, unchanged)
+	})
+
+	t.Run("hash matches files.json", func(t *testing.T) {
+		file, err := os.CreateTemp(t.TempDir(), "asset")
+		require.NoError(t, err)
+		_, err = file.WriteString("content")
+		require.NoError(t, err)
+		_, err = file.Seek(0, 0)
+		require.NoError(t, err)
+
+		hash, err := hashFile(file)
+		require.NoError(t, err)
+		require.Equal(t, "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", hash)
+
+		// the file is rewound to be uploaded
+		content, err := io.ReadAll(file)
+		require.NoError(t, err)
+		require.Equal(t, "content", string(content))
+	})
+}
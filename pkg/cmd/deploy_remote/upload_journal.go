@@ -0,0 +1,241 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	msg "github.com/aziontech/azion-cli/messages/deploy"
+	apiStorage "github.com/aziontech/azion-cli/pkg/api/storage"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// UploadJournalFile keeps, next to azion.json, the progress of an upload until it finishes, so that an
+// interrupted deploy can be resumed with --resume
+const UploadJournalFile = "upload-journal.json"
+
+// journalFlushEvery is the number of uploaded files after which the journal is written again
+const journalFlushEvery = 25
+
+// UploadJournal records the files a deploy is uploading to a prefix and the ones already stored in the bucket
+type UploadJournal struct {
+	Bucket string
+	Prefix string
+	// PreviousPrefix is the version live before the deploy, which azion.json no longer holds once the
+	// interrupted deploy saved the new prefix
+	PreviousPrefix string
+
+	mu        sync.Mutex
+	hashes    map[string]string
+	completed map[string]bool
+	pending   int
+}
+
+// journalFile is the content of UploadJournalFile
+type journalFile struct {
+	Bucket         string   `json:"bucket"`
+	Prefix         string   `json:"prefix"`
+	PreviousPrefix string   `json:"previous_prefix,omitempty"`
+	Files          []Data   `json:"files"`
+	Completed      []string `json:"completed"`
+}
+
+func NewUploadJournal(bucket, prefix string) *UploadJournal {
+	return &UploadJournal{
+		Bucket:    bucket,
+		Prefix:    prefix,
+		hashes:    make(map[string]string),
+		completed: make(map[string]bool),
+	}
+}
+
+// Add records a file to be uploaded. A file whose content changed since it was uploaded is uploaded again.
+func (journal *UploadJournal) Add(name, hash string) {
+	if journal == nil {
+		return
+	}
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	if previous, ok := journal.hashes[name]; ok && previous == hash {
+		return
+	}
+	journal.hashes[name] = hash
+	delete(journal.completed, name)
+}
+
+// Uploaded reports whether the file, with this same content, is already stored in the bucket
+func (journal *UploadJournal) Uploaded(name, hash string) bool {
+	if journal == nil {
+		return false
+	}
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	return journal.completed[name] && journal.hashes[name] == hash
+}
+
+// Complete records a file as stored in the bucket
+func (journal *UploadJournal) Complete(name string) {
+	if journal == nil {
+		return
+	}
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	journal.completed[name] = true
+	journal.pending++
+}
+
+// Verify keeps as completed only the files found among the keys of the bucket
+func (journal *UploadJournal) Verify(keys map[string]bool) {
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	for name := range journal.completed {
+		if !keys[journal.key(name)] {
+			logger.Debug("Object of the interrupted upload not found in the bucket", zap.String("File name", name))
+			delete(journal.completed, name)
+		}
+	}
+}
+
+// key is the key of the object of a file, as listed by the Storage API
+func (journal *UploadJournal) key(name string) string {
+	return strings.TrimPrefix(path.Join(journal.Prefix, name), "/")
+}
+
+// shouldFlush reports whether enough files were uploaded since the journal was last written
+func (journal *UploadJournal) shouldFlush() bool {
+	if journal == nil {
+		return false
+	}
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	return journal.pending >= journalFlushEvery
+}
+
+// snapshot returns the content to be written to the journal file
+func (journal *UploadJournal) snapshot() journalFile {
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	journal.pending = 0
+
+	stored := journalFile{
+		Bucket:         journal.Bucket,
+		Prefix:         journal.Prefix,
+		PreviousPrefix: journal.PreviousPrefix,
+		Files:          []Data{},
+		Completed:      []string{},
+	}
+	for name, hash := range journal.hashes {
+		stored.Files = append(stored.Files, Data{Name: name, Hash: hash})
+	}
+	for name := range journal.completed {
+		stored.Completed = append(stored.Completed, name)
+	}
+	sort.Slice(stored.Files, func(i, j int) bool { return stored.Files[i].Name < stored.Files[j].Name })
+	sort.Strings(stored.Completed)
+	return stored
+}
+
+// readUploadJournal reads the journal of an interrupted upload. It returns nil when there is none.
+func (cmd *DeployCmd) readUploadJournal(confPath string) *UploadJournal {
+	data, err := cmd.FileReader(path.Join(confPath, UploadJournalFile))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Debug("Error while reading the upload journal", zap.Error(err))
+		}
+		return nil
+	}
+
+	stored := journalFile{}
+	if err := cmd.Unmarshal(data, &stored); err != nil {
+		logger.Debug("Error while decoding the upload journal", zap.Error(err))
+		return nil
+	}
+	journal := NewUploadJournal(stored.Bucket, stored.Prefix)
+	journal.PreviousPrefix = stored.PreviousPrefix
+	for _, file := range stored.Files {
+		journal.Add(file.Name, file.Hash)
+	}
+	for _, name := range stored.Completed {
+		journal.completed[name] = true
+	}
+	return journal
+}
+
+func (cmd *DeployCmd) writeUploadJournal(journal *UploadJournal, confPath string) error {
+	if journal == nil {
+		return nil
+	}
+	data, err := json.MarshalIndent(journal.snapshot(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return cmd.WriteFile(path.Join(confPath, UploadJournalFile), data, 0644)
+}
+
+// resumeUploadJournal reads the journal of the upload resumed with --resume
+func (cmd *DeployCmd) resumeUploadJournal(confPath string) (*UploadJournal, error) {
+	journal := cmd.readUploadJournal(confPath)
+	if journal == nil || journal.Prefix == "" {
+		return nil, msg.ErrorNoUploadToResume
+	}
+	return journal, nil
+}
+
+// verifyUploadJournal confirms with the Storage API the files the journal records as uploaded, since the last
+// of them may not have been written to the journal before the interruption
+func (cmd *DeployCmd) verifyUploadJournal(
+	ctx context.Context, client *apiStorage.Client, conf *contracts.AzionApplicationOptions, journal *UploadJournal, msgs *[]string) error {
+	if journal.Bucket != conf.Bucket || journal.Prefix != conf.Prefix {
+		logger.Debug("The journal refers to another upload", zap.String("bucket", journal.Bucket), zap.String("prefix", journal.Prefix))
+		return msg.ErrorNoUploadToResume
+	}
+
+	msgf := fmt.Sprintf(msg.UploadResuming, journal.Prefix)
+	logger.FInfoFlags(cmd.F.IOStreams.Out, msgf, cmd.F.Format, cmd.F.Out)
+	*msgs = append(*msgs, msgf)
+
+	keys, err := storedObjects(ctx, client, journal.Bucket, journal.Prefix)
+	if err != nil {
+		return fmt.Errorf(msg.ErrorListUploaded, err)
+	}
+	journal.Verify(keys)
+	return nil
+}
+
+// removeUploadJournal discards the journal once every file was uploaded
+func removeUploadJournal(confPath string) {
+	if err := os.Remove(path.Join(confPath, UploadJournalFile)); err != nil && !os.IsNotExist(err) {
+		logger.Debug("Error while removing the upload journal", zap.Error(err))
+	}
+}
+
+// storedObjects lists the keys of the objects of the bucket under the prefix
+func storedObjects(ctx context.Context, client *apiStorage.Client, bucket, prefix string) (map[string]bool, error) {
+	keys := make(map[string]bool)
+	keyPrefix := strings.TrimPrefix(prefix+"/", "/")
+	var continuationToken string
+	for {
+		objects, err := client.ListObject(ctx, bucket, &contracts.ListOptions{ContinuationToken: continuationToken})
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range objects.Results {
+			if strings.HasPrefix(object.GetKey(), keyPrefix) {
+				keys[object.GetKey()] = true
+			}
+		}
+		logger.Debug("continuing to next page", zap.Any("continuation-token", objects.GetContinuationToken()))
+		if contToken, ok := objects.GetContinuationTokenOk(); contToken == nil || !ok {
+			break
+		}
+		continuationToken = objects.GetContinuationToken()
+	}
+	return keys, nil
+}
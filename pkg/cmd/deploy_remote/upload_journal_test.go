@@ -0,0 +1,73 @@
+package deploy
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"testing"
+
+	msg "github.com/aziontech/azion-cli/messages/deploy"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadJournal(t *testing.T) {
+	t.Run("completed files are skipped while their content is the same", func(t *testing.T) {
+		journal := NewUploadJournal("bucket", "20240101")
+		journal.Add("/index.html", "aaa")
+		journal.Add("/app.js", "bbb")
+		require.False(t, journal.Uploaded("/index.html", "aaa"))
+
+		journal.Complete("/index.html")
+		require.True(t, journal.Uploaded("/index.html", "aaa"))
+		require.False(t, journal.Uploaded("/index.html", "ccc"))
+		require.False(t, journal.Uploaded("/app.js", "bbb"))
+
+		journal.Add("/index.html", "ccc")
+		require.False(t, journal.Uploaded("/index.html", "ccc"))
+	})
+
+	t.Run("files missing from the bucket are uploaded again", func(t *testing.T) {
+		journal := NewUploadJournal("bucket", "20240101")
+		journal.Add("/index.html", "aaa")
+		journal.Add("/app.js", "bbb")
+		journal.Complete("/index.html")
+		journal.Complete("/app.js")
+
+		journal.Verify(map[string]bool{"20240101/index.html": true})
+		require.True(t, journal.Uploaded("/index.html", "aaa"))
+		require.False(t, journal.Uploaded("/app.js", "bbb"))
+	})
+
+	t.Run("journal is kept next to azion.json", func(t *testing.T) {
+		files := map[string][]byte{}
+		cmd := &DeployCmd{
+			Unmarshal: json.Unmarshal,
+			FileReader: func(path string) ([]byte, error) {
+				if data, ok := files[path]; ok {
+					return data, nil
+				}
+				return nil, os.ErrNotExist
+			},
+			WriteFile: func(filename string, data []byte, perm fs.FileMode) error {
+				files[filename] = data
+				return nil
+			},
+		}
+		_, err := cmd.resumeUploadJournal("azion")
+		require.ErrorIs(t, err, msg.ErrorNoUploadToResume)
+
+		journal := NewUploadJournal("bucket", "20240101")
+		journal.PreviousPrefix = "20231231"
+		journal.Add("/index.html", "aaa")
+		journal.Add("/app.js", "bbb")
+		journal.Complete("/app.js")
+		require.NoError(t, cmd.writeUploadJournal(journal, "azion"))
+
+		resumed, err := cmd.resumeUploadJournal("azion")
+		require.NoError(t, err)
+		require.Equal(t, "20240101", resumed.Prefix)
+		require.Equal(t, "20231231", resumed.PreviousPrefix)
+		require.True(t, resumed.Uploaded("/app.js", "bbb"))
+		require.False(t, resumed.Uploaded("/index.html", "aaa"))
+	})
+}
@@ -0,0 +1,74 @@
+package deploy
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	apiStorage "github.com/aziontech/azion-cli/pkg/api/storage"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+)
+
+// VersionLayout is the layout of the timestamps naming the prefixes that deploys upload static files to
+const VersionLayout = "20060102150405"
+
+// Version is a deploy of the project kept in its bucket, under the timestamp prefix of its static files
+type Version struct {
+	Prefix string
+	Files  int
+	Size   int64
+}
+
+// ListVersions returns the versions deployed to bucket, newest first. Objects outside of a timestamp prefix
+// are not part of any version.
+func ListVersions(ctx context.Context, client *apiStorage.Client, bucket string) ([]Version, error) {
+	found := map[string]*Version{}
+	options := &contracts.ListOptions{}
+	for {
+		resp, err := client.ListObject(ctx, bucket, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range resp.Results {
+			prefix, _, ok := strings.Cut(object.GetKey(), "/")
+			if !ok {
+				continue
+			}
+			if _, err := time.Parse(VersionLayout, prefix); err != nil {
+				continue
+			}
+			version, ok := found[prefix]
+			if !ok {
+				version = &Version{Prefix: prefix}
+				found[prefix] = version
+			}
+			version.Files++
+			version.Size += int64(object.GetSize())
+		}
+		if resp.GetContinuationToken() == "" {
+			break
+		}
+		options.ContinuationToken = resp.GetContinuationToken()
+	}
+
+	versions := make([]Version, 0, len(found))
+	for _, version := range found {
+		versions = append(versions, *version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Prefix > versions[j].Prefix
+	})
+	return versions, nil
+}
+
+// PreviousVersion returns the prefix of the version deployed right before current, or an empty string when
+// there is none
+func PreviousVersion(versions []Version, current string) string {
+	for i, version := range versions {
+		if version.Prefix == current && i+1 < len(versions) {
+			return versions[i+1].Prefix
+		}
+	}
+	return ""
+}
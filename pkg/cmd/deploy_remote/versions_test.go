@@ -0,0 +1,16 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviousVersion(t *testing.T) {
+	versions := []Version{{Prefix: "20240103000000"}, {Prefix: "20240102000000"}, {Prefix: "20240101000000"}}
+
+	require.Equal(t, "20240102000000", PreviousVersion(versions, "20240103000000"))
+	require.Equal(t, "20240101000000", PreviousVersion(versions, "20240102000000"))
+	require.Equal(t, "", PreviousVersion(versions, "20240101000000"))
+	require.Equal(t, "", PreviousVersion(versions, "20231231000000"))
+}
@@ -0,0 +1,41 @@
+package deploy
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aziontech/azion-cli/pkg/api/s3"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadJob(t *testing.T) {
+	multipart := s3.MultipartOptions{Threshold: s3.DefaultMultipartThreshold}
+
+	t.Run("file is opened when transferred", func(t *testing.T) {
+		empty := path.Join(t.TempDir(), "empty.txt")
+		require.NoError(t, os.WriteFile(empty, nil, 0644))
+
+		job := contracts.FileOps{Path: "/empty.txt", LocalPath: empty}
+		require.NoError(t, uploadJob(&job, aws.Config{}, "bucket", "prefix", multipart))
+		require.NotNil(t, job.FileContent)
+
+		// the worker closes the file once the job is done
+		_, err := job.FileContent.Stat()
+		require.ErrorIs(t, err, os.ErrClosed)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		job := contracts.FileOps{Path: "/missing.txt", LocalPath: path.Join(t.TempDir(), "missing.txt")}
+		require.ErrorIs(t, uploadJob(&job, aws.Config{}, "bucket", "prefix", multipart), os.ErrNotExist)
+	})
+}
+
+func TestMultipartOptions(t *testing.T) {
+	multipart := s3.MultipartOptions{Threshold: 100}
+	require.False(t, multipart.Enabled(99))
+	require.True(t, multipart.Enabled(100))
+	require.False(t, s3.MultipartOptions{}.Enabled(1<<40))
+}
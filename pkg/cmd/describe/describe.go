@@ -14,6 +14,7 @@ import (
 	firewallrules "github.com/aziontech/azion-cli/pkg/cmd/describe/firewall_rules"
 	function "github.com/aziontech/azion-cli/pkg/cmd/describe/function"
 	functioninstance "github.com/aziontech/azion-cli/pkg/cmd/describe/function_instance"
+	kv "github.com/aziontech/azion-cli/pkg/cmd/describe/kv"
 	networklist "github.com/aziontech/azion-cli/pkg/cmd/describe/network_list"
 	origin "github.com/aziontech/azion-cli/pkg/cmd/describe/origin"
 	"github.com/aziontech/azion-cli/pkg/cmd/describe/personal_token"
@@ -59,7 +60,7 @@ func NewCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.AddCommand(edgeConnector.NewCmd(f))
 	cmd.AddCommand(functioninstance.NewCmd(f))
 	cmd.AddCommand(networklist.NewCmd(f))
-	// cmd.AddCommand(kv.NewCmd(f))
+	cmd.AddCommand(kv.NewCmd(f))
 	cmd.AddCommand(firewall.NewCmd(f))
 	cmd.AddCommand(firewallinstance.NewCmd(f))
 	cmd.AddCommand(firewallrules.NewCmd(f))
@@ -3,6 +3,10 @@ package storage
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -55,17 +59,39 @@ func (f *Fields) RunE(cmd *cobra.Command, args []string) error {
 
 	client := api.NewClient(f.Factory.HttpClient, f.Factory.Config.GetString("storage_url"), f.Factory.Config.GetString("token"))
 	ctx := context.Background()
-	bFile, err := client.GetObject(ctx, f.BucketName, f.ObjectKey)
+	object, err := client.DownloadObject(ctx, f.BucketName, f.ObjectKey, 0, time.Time{})
 	if err != nil {
 		return fmt.Errorf(msg.ERROR_DESCRIBE_OBJECT, err)
 	}
+	defer object.Close()
 
-	describeOut := output.RawOutput{
-		Bytes: bFile,
-		Out:   f.Factory.IOStreams.Out,
-		Flags: f.Factory.Flags,
+	// objects may be larger than the memory available, so they are copied as they are read
+	if f.Factory.Out == "" {
+		if _, err := io.Copy(f.Factory.IOStreams.Out, object); err != nil {
+			return fmt.Errorf(msg.ERROR_DESCRIBE_OBJECT, err)
+		}
+		return nil
+	}
+	if err := writeObject(object, f.Factory.Out); err != nil {
+		return fmt.Errorf(msg.ERROR_DESCRIBE_OBJECT, err)
+	}
+	logger.FInfo(f.Factory.IOStreams.Out, fmt.Sprintf(output.WRITE_SUCCESS, f.Factory.Out))
+	return nil
+}
+
+func writeObject(object io.Reader, outPath string) error {
+	if err := os.MkdirAll(filepath.Dir(outPath), os.ModePerm); err != nil {
+		return err
+	}
+	file, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, object); err != nil {
+		file.Close()
+		return err
 	}
-	return output.Print(&describeOut)
+	return file.Close()
 }
 
 func (f *Fields) AddFlags(flags *pflag.FlagSet) {
@@ -1,11 +1,15 @@
 package storage
 
 import (
+	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/aziontech/azion-cli/pkg/httpmock"
 	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
 	"github.com/aziontech/azion-cli/pkg/testutils"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
@@ -19,8 +23,16 @@ func TestDescribeObject(t *testing.T) {
 		request   httpmock.Matcher
 		response  httpmock.Responder
 		args      []string
+		expectOut string
 		expectErr bool
 	}{
+		{
+			name:      "streams the object",
+			request:   httpmock.REST("GET", "workspace/storage/buckets/mybucket/objects/test.json"),
+			response:  httpmock.StringResponse(`{"name": "test"}`),
+			args:      []string{"--bucket-name", "mybucket", "--object-key", "test.json"},
+			expectOut: `{"name": "test"}`,
+		},
 		{
 			name:      "object not found",
 			request:   httpmock.REST("GET", "storage/buckets/unknown/objects/unknown-object"),
@@ -49,7 +61,7 @@ func TestDescribeObject(t *testing.T) {
 			mock := &httpmock.Registry{}
 			mock.Register(tt.request, tt.response)
 
-			factory, _, _ := testutils.NewFactory(mock)
+			factory, stdout, _ := testutils.NewFactory(mock)
 			cmd := NewObject(factory)
 			cmd.SetArgs(tt.args)
 
@@ -59,7 +71,29 @@ func TestDescribeObject(t *testing.T) {
 				require.Error(t, err)
 			} else {
 				require.NoError(t, err)
+				require.Equal(t, tt.expectOut, stdout.String())
 			}
 		})
 	}
 }
+
+func TestDescribeObjectOut(t *testing.T) {
+	logger.New(zapcore.DebugLevel)
+
+	mock := &httpmock.Registry{}
+	mock.Register(
+		httpmock.REST("GET", "workspace/storage/buckets/mybucket/objects/test.json"),
+		httpmock.StringResponse(`{"name": "test"}`),
+	)
+
+	factory, stdout, _ := testutils.NewFactory(mock)
+	factory.Out = filepath.Join(t.TempDir(), "objects", "test.json")
+	cmd := NewObject(factory)
+	cmd.SetArgs([]string{"--bucket-name", "mybucket", "--object-key", "test.json"})
+
+	require.NoError(t, cmd.Execute())
+	content, err := os.ReadFile(factory.Out)
+	require.NoError(t, err)
+	require.Equal(t, `{"name": "test"}`, string(content))
+	require.Equal(t, fmt.Sprintf(output.WRITE_SUCCESS, factory.Out), stdout.String())
+}
@@ -0,0 +1,88 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/kv/delete"
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/iostreams"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type DeleteCmd struct {
+	Io         *iostreams.IOStreams
+	AskInput   func(string) (string, error)
+	DeleteItem func(ctx context.Context, namespace, key string) error
+}
+
+func NewDeleteCmd(f *cmdutil.Factory) *DeleteCmd {
+	return &DeleteCmd{
+		Io:       f.IOStreams,
+		AskInput: utils.AskInput,
+		DeleteItem: func(ctx context.Context, namespace, key string) error {
+			client := api.NewClient(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
+			return client.DeleteItem(ctx, namespace, key)
+		},
+	}
+}
+
+func NewCobraCmd(delete *DeleteCmd, f *cmdutil.Factory) *cobra.Command {
+	var namespace, key string
+
+	cobraCmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+		$ azion kv delete --namespace "my-namespace" --key "greeting"
+		`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !cmd.Flags().Changed("namespace") {
+				answer, err := delete.AskInput(msg.AskInputNamespace)
+				if err != nil {
+					logger.Debug("Error while parsing answer", zap.Error(err))
+					return utils.ErrorParseResponse
+				}
+				namespace = answer
+			}
+			if !cmd.Flags().Changed("key") {
+				answer, err := delete.AskInput(msg.AskInputKey)
+				if err != nil {
+					logger.Debug("Error while parsing answer", zap.Error(err))
+					return utils.ErrorParseResponse
+				}
+				key = answer
+			}
+
+			if err := delete.DeleteItem(context.Background(), namespace, key); err != nil {
+				return fmt.Errorf(msg.ErrorDeleteItem, key, err)
+			}
+
+			deleteOut := output.GeneralOutput{
+				Msg:   fmt.Sprintf(msg.OutputSuccess, key, namespace),
+				Out:   f.IOStreams.Out,
+				Flags: f.Flags,
+			}
+			return output.Print(&deleteOut)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&namespace, "namespace", "", msg.FlagNamespace)
+	cobraCmd.Flags().StringVar(&key, "key", "", msg.FlagKey)
+	cobraCmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cobraCmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewDeleteCmd(f), f)
+}
@@ -0,0 +1,87 @@
+package delete
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	msg "github.com/aziontech/azion-cli/messages/kv/delete"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		inputs         []string
+		inputErr       error
+		deleteErr      error
+		expectedOutput string
+		expectedErr    string
+	}{
+		{
+			name:           "delete key",
+			args:           []string{"--namespace", "ns", "--key", "greeting"},
+			expectedOutput: fmt.Sprintf(msg.OutputSuccess, "greeting", "ns"),
+		},
+		{
+			name:           "ask for the namespace and the key",
+			inputs:         []string{"ns", "greeting"},
+			expectedOutput: fmt.Sprintf(msg.OutputSuccess, "greeting", "ns"),
+		},
+		{
+			name:        "error in input",
+			args:        []string{"--namespace", "ns"},
+			inputErr:    errors.New("interrupted"),
+			expectedErr: utils.ErrorParseResponse.Error(),
+		},
+		{
+			name:        "key not found",
+			args:        []string{"--namespace", "ns", "--key", "greeting"},
+			deleteErr:   utils.ErrorNotFound404,
+			expectedErr: fmt.Sprintf("Failed to delete the key 'greeting': %s", utils.ErrorNotFound404.Error()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, stdout, _ := testutils.NewFactory(nil)
+
+			inputs := tt.inputs
+			deleteCmd := NewDeleteCmd(f)
+			deleteCmd.AskInput = func(string) (string, error) {
+				if tt.inputErr != nil {
+					return "", tt.inputErr
+				}
+				answer := inputs[0]
+				inputs = inputs[1:]
+				return answer, nil
+			}
+			deleteCmd.DeleteItem = func(ctx context.Context, namespace, key string) error {
+				require.Equal(t, "ns", namespace)
+				require.Equal(t, "greeting", key)
+				return tt.deleteErr
+			}
+
+			cmd := NewCobraCmd(deleteCmd, f)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedOutput, stdout.String())
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package get
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/kv/get"
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/iostreams"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type GetCmd struct {
+	Io       *iostreams.IOStreams
+	AskInput func(string) (string, error)
+	GetItem  func(ctx context.Context, namespace, key string) (*api.Item, error)
+}
+
+func NewGetCmd(f *cmdutil.Factory) *GetCmd {
+	return &GetCmd{
+		Io:       f.IOStreams,
+		AskInput: utils.AskInput,
+		GetItem: func(ctx context.Context, namespace, key string) (*api.Item, error) {
+			client := api.NewClient(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
+			return client.GetItem(ctx, namespace, key)
+		},
+	}
+}
+
+func NewCobraCmd(get *GetCmd, f *cmdutil.Factory) *cobra.Command {
+	var namespace, key string
+	var details bool
+
+	cobraCmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+		$ azion kv get --namespace "my-namespace" --key "greeting"
+		$ azion kv get --namespace "my-namespace" --key "greeting" --details
+		$ azion kv get --namespace "my-namespace" --key "greeting" --format json
+		`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !cmd.Flags().Changed("namespace") {
+				answer, err := get.AskInput(msg.AskInputNamespace)
+				if err != nil {
+					logger.Debug("Error while parsing answer", zap.Error(err))
+					return utils.ErrorParseResponse
+				}
+				namespace = answer
+			}
+			if !cmd.Flags().Changed("key") {
+				answer, err := get.AskInput(msg.AskInputKey)
+				if err != nil {
+					logger.Debug("Error while parsing answer", zap.Error(err))
+					return utils.ErrorParseResponse
+				}
+				key = answer
+			}
+
+			item, err := get.GetItem(context.Background(), namespace, key)
+			if err != nil {
+				return fmt.Errorf(msg.ErrorGetItem, key, err)
+			}
+
+			// the value alone is printed by default, so that it can be piped to other commands
+			if !details && len(f.Flags.Format) == 0 && len(f.Flags.Out) == 0 {
+				rawOut := output.RawOutput{
+					Bytes: []byte(item.Value),
+					Out:   f.IOStreams.Out,
+					Flags: f.Flags,
+				}
+				return output.Print(&rawOut)
+			}
+
+			describeOut := output.DescribeOutput{
+				GeneralOutput: output.GeneralOutput{
+					Out:   f.IOStreams.Out,
+					Flags: f.Flags,
+				},
+				Fields: map[string]string{
+					"Key":        "Key",
+					"Value":      "Value",
+					"Expiration": "Expiration",
+					"Metadata":   "Metadata",
+				},
+				Values: item,
+			}
+			return output.Print(&describeOut)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&namespace, "namespace", "", msg.FlagNamespace)
+	cobraCmd.Flags().StringVar(&key, "key", "", msg.FlagKey)
+	cobraCmd.Flags().BoolVar(&details, "details", false, msg.FlagDetails)
+	cobraCmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cobraCmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewGetCmd(f), f)
+}
@@ -0,0 +1,62 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+func TestGet(t *testing.T) {
+	item := &api.Item{Key: "greeting", Value: "hello", Expiration: 1735689600, Metadata: map[string]interface{}{"lang": "en"}}
+
+	t.Run("prints the value", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		getCmd := NewGetCmd(f)
+		getCmd.GetItem = func(ctx context.Context, namespace, key string) (*api.Item, error) {
+			require.Equal(t, "ns", namespace)
+			require.Equal(t, "greeting", key)
+			return item, nil
+		}
+
+		cmd := NewCobraCmd(getCmd, f)
+		cmd.SetArgs([]string{"--namespace", "ns", "--key", "greeting"})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, "hello", stdout.String())
+	})
+
+	t.Run("details", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		getCmd := NewGetCmd(f)
+		getCmd.GetItem = func(ctx context.Context, namespace, key string) (*api.Item, error) {
+			return item, nil
+		}
+
+		cmd := NewCobraCmd(getCmd, f)
+		cmd.SetArgs([]string{"--namespace", "ns", "--key", "greeting", "--details"})
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, stdout.String(), "1735689600")
+		require.Contains(t, stdout.String(), "lang")
+	})
+
+	t.Run("key not found", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(nil)
+		getCmd := NewGetCmd(f)
+		getCmd.GetItem = func(ctx context.Context, namespace, key string) (*api.Item, error) {
+			return nil, errors.New("Not Found")
+		}
+
+		cmd := NewCobraCmd(getCmd, f)
+		cmd.SetArgs([]string{"--namespace", "ns", "--key", "missing"})
+		require.ErrorContains(t, cmd.Execute(), "Failed to get the key 'missing': Not Found")
+	})
+}
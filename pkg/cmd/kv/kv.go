@@ -0,0 +1,44 @@
+package kv
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/kv"
+	"github.com/aziontech/azion-cli/pkg/cmd/kv/delete"
+	"github.com/aziontech/azion-cli/pkg/cmd/kv/get"
+	"github.com/aziontech/azion-cli/pkg/cmd/kv/kvexport"
+	"github.com/aziontech/azion-cli/pkg/cmd/kv/kvimport"
+	"github.com/aziontech/azion-cli/pkg/cmd/kv/list"
+	"github.com/aziontech/azion-cli/pkg/cmd/kv/put"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   msg.Usage,
+		Short: msg.ShortDescription,
+		Long:  msg.LongDescription,
+		Example: heredoc.Doc(`
+		$ azion kv --help
+		$ azion kv put --namespace "my-namespace" --key "greeting" --value "hello"
+		$ azion kv get --namespace "my-namespace" --key "greeting"
+		$ azion kv list --namespace "my-namespace" --prefix "user:"
+		$ azion kv delete --namespace "my-namespace" --key "greeting"
+		$ azion kv import --namespace "my-namespace" --file data.ndjson
+		$ azion kv export --namespace "my-namespace" --file data.csv
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(get.NewCmd(f))
+	cmd.AddCommand(put.NewCmd(f))
+	cmd.AddCommand(list.NewCmd(f))
+	cmd.AddCommand(delete.NewCmd(f))
+	cmd.AddCommand(kvimport.NewCmd(f))
+	cmd.AddCommand(kvexport.NewCmd(f))
+	cmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cmd
+}
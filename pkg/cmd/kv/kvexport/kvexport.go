@@ -0,0 +1,203 @@
+package kvexport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/kv/kvexport"
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/iostreams"
+	"github.com/aziontech/azion-cli/pkg/kv/records"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/workers"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type Options struct {
+	Namespace  string
+	File       string
+	FileFormat string
+	Prefix     string
+	Workers    int
+}
+
+type ExportCmd struct {
+	Io        *iostreams.IOStreams
+	AskInput  func(string) (string, error)
+	Create    func(name string) (io.WriteCloser, error)
+	ListItems func(ctx context.Context, namespace string, opts api.ListItemsOptions) (*api.ItemPage, error)
+	GetItem   func(ctx context.Context, namespace, key string) (*api.Item, error)
+}
+
+func NewExportCmd(f *cmdutil.Factory) *ExportCmd {
+	client := func() *api.Client {
+		return api.NewClient(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
+	}
+	return &ExportCmd{
+		Io:       f.IOStreams,
+		AskInput: utils.AskInput,
+		Create: func(name string) (io.WriteCloser, error) {
+			return os.Create(name)
+		},
+		ListItems: func(ctx context.Context, namespace string, opts api.ListItemsOptions) (*api.ItemPage, error) {
+			return client().ListItems(ctx, namespace, opts)
+		},
+		GetItem: func(ctx context.Context, namespace, key string) (*api.Item, error) {
+			return client().GetItem(ctx, namespace, key)
+		},
+	}
+}
+
+func NewCobraCmd(export *ExportCmd, f *cmdutil.Factory) *cobra.Command {
+	opts := &Options{}
+
+	cobraCmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+		$ azion kv export --namespace "my-namespace" --file data.ndjson
+		$ azion kv export --namespace "my-namespace" --file redirects.csv --prefix "redirect:"
+		$ azion kv export --namespace "my-namespace" > data.ndjson
+		`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !cmd.Flags().Changed("namespace") {
+				answer, err := export.AskInput(msg.AskInputNamespace)
+				if err != nil {
+					logger.Debug("Error while parsing answer", zap.Error(err))
+					return utils.ErrorParseResponse
+				}
+				opts.Namespace = answer
+			}
+			return export.Run(f, opts)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&opts.Namespace, "namespace", "", msg.FlagNamespace)
+	cobraCmd.Flags().StringVar(&opts.File, "file", "", msg.FlagFile)
+	cobraCmd.Flags().StringVar(&opts.FileFormat, "file-format", "", msg.FlagFileFormat)
+	cobraCmd.Flags().StringVar(&opts.Prefix, "prefix", "", msg.FlagPrefix)
+	cobraCmd.Flags().IntVar(&opts.Workers, "workers", 0, msg.FlagWorkers)
+	cobraCmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cobraCmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewExportCmd(f), f)
+}
+
+// Run writes the keys of the namespace page by page. The values of a page are read concurrently and written
+// in the order of the page; keys that cannot be read are reported and make the command fail at the end.
+func (cmd *ExportCmd) Run(f *cmdutil.Factory, opts *Options) error {
+	format := records.NDJSON
+	out := f.IOStreams.Out
+	if opts.File != "" {
+		var err error
+		format, err = records.FormatOf(opts.File, opts.FileFormat)
+		if err != nil {
+			return err
+		}
+		file, err := cmd.Create(opts.File)
+		if err != nil {
+			return fmt.Errorf(msg.ErrorCreateFile, opts.File, err)
+		}
+		defer file.Close()
+		out = file
+	} else if opts.FileFormat != "" {
+		var err error
+		format, err = records.FormatOf("", opts.FileFormat)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	writer := records.NewWriter(out, format)
+	noOfWorkers := workers.CalculateOptimal(opts.Workers)
+	exported, failed := 0, 0
+
+	listOpts := api.ListItemsOptions{Prefix: opts.Prefix}
+	for {
+		page, err := cmd.ListItems(ctx, opts.Namespace, listOpts)
+		if err != nil {
+			return fmt.Errorf(msg.ErrorListItems, opts.Namespace, err)
+		}
+
+		items, errs := cmd.readItems(ctx, opts.Namespace, page.Items, noOfWorkers)
+		for i, item := range items {
+			if errs[i] != nil {
+				failed++
+				// the standard output may hold the records, so failures are reported apart
+				logger.FInfo(f.IOStreams.Err, fmt.Sprintf(msg.RecordFailed, page.Items[i].Key, errs[i].Error()))
+				continue
+			}
+			if err := writer.Write(item); err != nil {
+				return fmt.Errorf(msg.ErrorWriteFile, err)
+			}
+			exported++
+		}
+
+		if page.Cursor == "" {
+			break
+		}
+		listOpts.Cursor = page.Cursor
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf(msg.ErrorWriteFile, err)
+	}
+
+	if opts.File != "" {
+		exportOut := output.GeneralOutput{
+			Msg:   fmt.Sprintf(msg.OutputSuccess, exported, opts.Namespace, opts.File),
+			Out:   f.IOStreams.Out,
+			Flags: f.Flags,
+		}
+		if err := output.Print(&exportOut); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf(msg.ErrorExport, failed)
+	}
+	return nil
+}
+
+// readItems reads the values of the keys of a page, at most noOfWorkers at a time, keeping their order
+func (cmd *ExportCmd) readItems(ctx context.Context, namespace string, keys []api.Item, noOfWorkers int) ([]api.Item, []error) {
+	items := make([]api.Item, len(keys))
+	errs := make([]error, len(keys))
+
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, noOfWorkers)
+	for i, key := range keys {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(i int, key string) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			item, err := cmd.GetItem(ctx, namespace, key)
+			if err != nil {
+				logger.Debug("Error while exporting a key", zap.String("key", key), zap.Error(err))
+				errs[i] = err
+				return
+			}
+			items[i] = *item
+		}(i, key.Key)
+	}
+	wg.Wait()
+	return items, errs
+}
@@ -0,0 +1,96 @@
+package kvexport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+type buffer struct {
+	bytes.Buffer
+}
+
+func (b *buffer) Close() error { return nil }
+
+func TestExport(t *testing.T) {
+	pages := map[string]*api.ItemPage{
+		"":      {Items: []api.Item{{Key: "a"}, {Key: "b"}}, Cursor: "next"},
+		"next":  {Items: []api.Item{{Key: "c"}}},
+		"other": {},
+	}
+	values := map[string]*api.Item{
+		"a": {Key: "a", Value: "1"},
+		"b": {Key: "b", Value: "2", Metadata: map[string]interface{}{"owner": "web"}},
+		"c": {Key: "c", Value: "3", Expiration: 1735689600},
+	}
+
+	newCmd := func(t *testing.T) *ExportCmd {
+		f, _, _ := testutils.NewFactory(nil)
+		exportCmd := NewExportCmd(f)
+		exportCmd.ListItems = func(ctx context.Context, namespace string, opts api.ListItemsOptions) (*api.ItemPage, error) {
+			require.Equal(t, "flags", namespace)
+			require.Equal(t, "flag:", opts.Prefix)
+			return pages[opts.Cursor], nil
+		}
+		exportCmd.GetItem = func(ctx context.Context, namespace, key string) (*api.Item, error) {
+			return values[key], nil
+		}
+		return exportCmd
+	}
+
+	t.Run("every page to the standard output", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		cmd := NewCobraCmd(newCmd(t), f)
+		cmd.SetArgs([]string{"--namespace", "flags", "--prefix", "flag:"})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, `{"key":"a","value":"1"}
+{"key":"b","value":"2","metadata":{"owner":"web"}}
+{"key":"c","value":"3","expiration":1735689600}
+`, stdout.String())
+	})
+
+	t.Run("csv file", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		exportCmd := newCmd(t)
+		file := &buffer{}
+		exportCmd.Create = func(name string) (io.WriteCloser, error) {
+			require.Equal(t, "flags.csv", name)
+			return file, nil
+		}
+
+		cmd := NewCobraCmd(exportCmd, f)
+		cmd.SetArgs([]string{"--namespace", "flags", "--prefix", "flag:", "--file", "flags.csv"})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, "key,value,expiration,metadata\na,1,,\nb,2,,\"{\"\"owner\"\":\"\"web\"\"}\"\nc,3,1735689600,\n", file.String())
+		require.Contains(t, stdout.String(), "Exported 3 keys of the namespace 'flags' to flags.csv")
+	})
+
+	t.Run("keys that cannot be read are reported", func(t *testing.T) {
+		f, stdout, stderr := testutils.NewFactory(nil)
+		exportCmd := newCmd(t)
+		exportCmd.GetItem = func(ctx context.Context, namespace, key string) (*api.Item, error) {
+			if key == "b" {
+				return nil, errors.New("Not Found")
+			}
+			return values[key], nil
+		}
+
+		cmd := NewCobraCmd(exportCmd, f)
+		cmd.SetArgs([]string{"--namespace", "flags", "--prefix", "flag:"})
+		require.ErrorContains(t, cmd.Execute(), "Failed to export 1 keys")
+		require.NotContains(t, stdout.String(), `"key":"b"`)
+		require.Contains(t, stderr.String(), "Key 'b': Not Found")
+	})
+}
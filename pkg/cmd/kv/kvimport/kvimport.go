@@ -0,0 +1,269 @@
+package kvimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/kv/kvimport"
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/iostreams"
+	"github.com/aziontech/azion-cli/pkg/kv/records"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/workers"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type Options struct {
+	Namespace  string
+	File       string
+	FileFormat string
+	Workers    int
+	DryRun     bool
+	Diff       bool
+}
+
+type ImportCmd struct {
+	Io       *iostreams.IOStreams
+	AskInput func(string) (string, error)
+	Open     func(name string) (io.ReadCloser, error)
+	GetItem  func(ctx context.Context, namespace, key string) (*api.Item, error)
+	PutItem  func(ctx context.Context, namespace, key string, req api.PutItemRequest) error
+}
+
+type status int
+
+const (
+	statusWritten status = iota
+	statusNew
+	statusChanged
+	statusUnchanged
+	statusFailed
+)
+
+type result struct {
+	record records.Record
+	status status
+	err    error
+}
+
+func NewImportCmd(f *cmdutil.Factory) *ImportCmd {
+	client := func() *api.Client {
+		return api.NewClient(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
+	}
+	return &ImportCmd{
+		Io:       f.IOStreams,
+		AskInput: utils.AskInput,
+		Open: func(name string) (io.ReadCloser, error) {
+			return os.Open(name)
+		},
+		GetItem: func(ctx context.Context, namespace, key string) (*api.Item, error) {
+			return client().GetItem(ctx, namespace, key)
+		},
+		PutItem: func(ctx context.Context, namespace, key string, req api.PutItemRequest) error {
+			return client().PutItem(ctx, namespace, key, req)
+		},
+	}
+}
+
+func NewCobraCmd(importCmd *ImportCmd, f *cmdutil.Factory) *cobra.Command {
+	opts := &Options{}
+
+	cobraCmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+		$ azion kv import --namespace "my-namespace" --file data.ndjson
+		$ azion kv import --namespace "my-namespace" --file redirects.csv --workers 20
+		$ azion kv import --namespace "my-namespace" --file flags.json --diff --dry-run
+		`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !cmd.Flags().Changed("namespace") {
+				answer, err := importCmd.AskInput(msg.AskInputNamespace)
+				if err != nil {
+					logger.Debug("Error while parsing answer", zap.Error(err))
+					return utils.ErrorParseResponse
+				}
+				opts.Namespace = answer
+			}
+			if !cmd.Flags().Changed("file") {
+				answer, err := importCmd.AskInput(msg.AskInputFile)
+				if err != nil {
+					logger.Debug("Error while parsing answer", zap.Error(err))
+					return utils.ErrorParseResponse
+				}
+				opts.File = answer
+			}
+			return importCmd.Run(f, opts)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&opts.Namespace, "namespace", "", msg.FlagNamespace)
+	cobraCmd.Flags().StringVar(&opts.File, "file", "", msg.FlagFile)
+	cobraCmd.Flags().StringVar(&opts.FileFormat, "file-format", "", msg.FlagFileFormat)
+	cobraCmd.Flags().IntVar(&opts.Workers, "workers", 0, msg.FlagWorkers)
+	cobraCmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, msg.FlagDryRun)
+	cobraCmd.Flags().BoolVar(&opts.Diff, "diff", false, msg.FlagDiff)
+	cobraCmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cobraCmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewImportCmd(f), f)
+}
+
+// Run streams the records of the file to a pool of workers writing them to the namespace. Failed records are
+// reported at the end, sorted by line, and make the command fail once the others are imported.
+func (cmd *ImportCmd) Run(f *cmdutil.Factory, opts *Options) error {
+	format, err := records.FormatOf(opts.File, opts.FileFormat)
+	if err != nil {
+		return err
+	}
+	file, err := cmd.Open(opts.File)
+	if err != nil {
+		return fmt.Errorf(msg.ErrorOpenFile, opts.File, err)
+	}
+	defer file.Close()
+
+	ctx := context.Background()
+	pending := make(chan records.Record)
+	results := make(chan result)
+
+	var readErr error
+	go func() {
+		defer close(pending)
+		readErr = records.Read(file, format, func(record records.Record) {
+			pending <- record
+		})
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers.CalculateOptimal(opts.Workers); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for record := range pending {
+				results <- cmd.importRecord(ctx, opts, record)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	counts := map[status]int{}
+	planned := []result{}
+	failed := []result{}
+	for res := range results {
+		counts[res.status]++
+		switch {
+		case res.status == statusFailed:
+			failed = append(failed, res)
+		case opts.DryRun && res.status != statusUnchanged:
+			planned = append(planned, res)
+		}
+	}
+	if readErr != nil {
+		return readErr
+	}
+
+	byLine := func(results []result) {
+		sort.Slice(results, func(i, j int) bool { return results[i].record.Line < results[j].record.Line })
+	}
+	byLine(planned)
+	for _, res := range planned {
+		kind := msg.PlannedWrite
+		switch res.status {
+		case statusNew:
+			kind = msg.PlannedNew
+		case statusChanged:
+			kind = msg.PlannedChanged
+		}
+		logger.FInfoFlags(f.IOStreams.Out, fmt.Sprintf(msg.RecordPlanned, kind, res.record.Item.Key), f.Format, f.Out)
+	}
+	byLine(failed)
+	for _, res := range failed {
+		logger.FInfoFlags(f.IOStreams.Out, fmt.Sprintf(msg.RecordFailed, res.record.Line, res.record.Item.Key, res.err.Error()), f.Format, f.Out)
+	}
+
+	imported := counts[statusWritten] + counts[statusNew] + counts[statusChanged]
+	summary := msg.OutputSuccess
+	if opts.DryRun {
+		summary = msg.OutputDryRun
+	}
+	importOut := output.GeneralOutput{
+		Msg:   fmt.Sprintf(summary, imported, opts.Namespace, counts[statusUnchanged], counts[statusFailed]),
+		Out:   f.IOStreams.Out,
+		Flags: f.Flags,
+	}
+	if err := output.Print(&importOut); err != nil {
+		return err
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf(msg.ErrorImport, len(failed))
+	}
+	return nil
+}
+
+func (cmd *ImportCmd) importRecord(ctx context.Context, opts *Options, record records.Record) result {
+	if record.Err != nil {
+		return result{record: record, status: statusFailed, err: record.Err}
+	}
+
+	status := statusWritten
+	if opts.Diff {
+		current, err := cmd.GetItem(ctx, opts.Namespace, record.Item.Key)
+		switch {
+		case errors.Is(err, utils.ErrorNotFound404):
+			status = statusNew
+		case err != nil:
+			return result{record: record, status: statusFailed, err: err}
+		case equal(current, &record.Item):
+			return result{record: record, status: statusUnchanged}
+		default:
+			status = statusChanged
+		}
+	}
+	if opts.DryRun {
+		return result{record: record, status: status}
+	}
+
+	request := api.PutItemRequest{
+		Value:      record.Item.Value,
+		Expiration: record.Item.Expiration,
+		Metadata:   record.Item.Metadata,
+	}
+	if err := cmd.PutItem(ctx, opts.Namespace, record.Item.Key, request); err != nil {
+		logger.Debug("Error while importing a record", zap.String("key", record.Item.Key), zap.Error(err))
+		return result{record: record, status: statusFailed, err: err}
+	}
+	return result{record: record, status: status}
+}
+
+// equal tells whether a key of the namespace already holds a record. Metadata are compared as decoded
+// from JSON, so that the order of their fields does not matter.
+func equal(current, record *api.Item) bool {
+	if current.Value != record.Value || current.Expiration != record.Expiration {
+		return false
+	}
+	if len(current.Metadata) == 0 && len(record.Metadata) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(current.Metadata, record.Metadata)
+}
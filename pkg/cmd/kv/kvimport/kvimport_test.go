@@ -0,0 +1,123 @@
+package kvimport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+const data = `{"key": "flag:dark", "value": "on"}
+{"key": "flag:beta", "value": "off", "metadata": {"owner": "web"}}
+{"key": "flag:new", "value": "on"}
+{"value": "no key"}
+`
+
+func TestImport(t *testing.T) {
+	current := map[string]*api.Item{
+		"flag:dark": {Key: "flag:dark", Value: "on"},
+		"flag:beta": {Key: "flag:beta", Value: "on", Metadata: map[string]interface{}{"owner": "web"}},
+	}
+
+	newCmd := func(t *testing.T) (*ImportCmd, map[string]api.PutItemRequest) {
+		f, _, _ := testutils.NewFactory(nil)
+		var mu sync.Mutex
+		written := map[string]api.PutItemRequest{}
+		importCmd := NewImportCmd(f)
+		importCmd.Open = func(name string) (io.ReadCloser, error) {
+			require.Equal(t, "flags.ndjson", name)
+			return io.NopCloser(strings.NewReader(data)), nil
+		}
+		importCmd.GetItem = func(ctx context.Context, namespace, key string) (*api.Item, error) {
+			if item, ok := current[key]; ok {
+				return item, nil
+			}
+			return nil, utils.ErrorNotFound404
+		}
+		importCmd.PutItem = func(ctx context.Context, namespace, key string, req api.PutItemRequest) error {
+			require.Equal(t, "flags", namespace)
+			mu.Lock()
+			defer mu.Unlock()
+			written[key] = req
+			return nil
+		}
+		return importCmd, written
+	}
+
+	t.Run("writes every record and reports the malformed ones", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		importCmd, written := newCmd(t)
+
+		cmd := NewCobraCmd(importCmd, f)
+		cmd.SetArgs([]string{"--namespace", "flags", "--file", "flags.ndjson", "--workers", "2"})
+		err := cmd.Execute()
+		require.ErrorContains(t, err, "Failed to import 1 of the records")
+		require.Len(t, written, 3)
+		require.Equal(t, api.PutItemRequest{Value: "off", Metadata: map[string]interface{}{"owner": "web"}}, written["flag:beta"])
+		require.Contains(t, stdout.String(), "Line 4, key '': the record has no key")
+		require.Contains(t, stdout.String(), "Imported 3 keys to the namespace 'flags', 0 unchanged and 1 failed")
+	})
+
+	t.Run("diff writes only the changes", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		importCmd, written := newCmd(t)
+
+		cmd := NewCobraCmd(importCmd, f)
+		cmd.SetArgs([]string{"--namespace", "flags", "--file", "flags.ndjson", "--diff"})
+		require.Error(t, cmd.Execute())
+		require.Len(t, written, 2)
+		require.Contains(t, written, "flag:beta")
+		require.Contains(t, written, "flag:new")
+		require.Contains(t, stdout.String(), "Imported 2 keys to the namespace 'flags', 1 unchanged and 1 failed")
+	})
+
+	t.Run("dry run lists the changes without writing them", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		importCmd, written := newCmd(t)
+
+		cmd := NewCobraCmd(importCmd, f)
+		cmd.SetArgs([]string{"--namespace", "flags", "--file", "flags.ndjson", "--diff", "--dry-run"})
+		require.Error(t, cmd.Execute())
+		require.Empty(t, written)
+		require.Contains(t, stdout.String(), "changed 'flag:beta'\nnew 'flag:new'\n")
+		require.Contains(t, stdout.String(), "2 keys would be imported to the namespace 'flags', 1 unchanged and 1 failed")
+	})
+
+	t.Run("failed writes are reported", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		importCmd, _ := newCmd(t)
+		importCmd.PutItem = func(ctx context.Context, namespace, key string, req api.PutItemRequest) error {
+			if key == "flag:new" {
+				return errors.New("Too Many Requests")
+			}
+			return nil
+		}
+
+		cmd := NewCobraCmd(importCmd, f)
+		cmd.SetArgs([]string{"--namespace", "flags", "--file", "flags.ndjson"})
+		require.ErrorContains(t, cmd.Execute(), "Failed to import 2 of the records")
+		require.Contains(t, stdout.String(), "Line 3, key 'flag:new': Too Many Requests")
+	})
+}
+
+func TestEqual(t *testing.T) {
+	item := &api.Item{Key: "a", Value: "1", Metadata: map[string]interface{}{"owner": "web"}}
+	require.True(t, equal(item, &api.Item{Key: "a", Value: "1", Metadata: map[string]interface{}{"owner": "web"}}))
+	require.True(t, equal(&api.Item{Key: "a", Metadata: map[string]interface{}{}}, &api.Item{Key: "a"}))
+	require.False(t, equal(item, &api.Item{Key: "a", Value: "2", Metadata: item.Metadata}))
+	require.False(t, equal(item, &api.Item{Key: "a", Value: "1"}))
+	require.False(t, equal(item, &api.Item{Key: "a", Value: "1", Metadata: item.Metadata, Expiration: 1735689600}))
+}
@@ -0,0 +1,137 @@
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/kv/list"
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/iostreams"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type ListCmd struct {
+	Io        *iostreams.IOStreams
+	AskInput  func(string) (string, error)
+	ListItems func(ctx context.Context, namespace string, opts api.ListItemsOptions) (*api.ItemPage, error)
+}
+
+func NewListCmd(f *cmdutil.Factory) *ListCmd {
+	return &ListCmd{
+		Io:       f.IOStreams,
+		AskInput: utils.AskInput,
+		ListItems: func(ctx context.Context, namespace string, opts api.ListItemsOptions) (*api.ItemPage, error) {
+			client := api.NewClient(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
+			return client.ListItems(ctx, namespace, opts)
+		},
+	}
+}
+
+func NewCobraCmd(list *ListCmd, f *cmdutil.Factory) *cobra.Command {
+	var namespace string
+	var details bool
+	opts := api.ListItemsOptions{}
+
+	cobraCmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+		$ azion kv list --namespace "my-namespace"
+		$ azion kv list --namespace "my-namespace" --prefix "user:" --limit 50
+		$ azion kv list --namespace "my-namespace" --cursor "eyJrZXkiOiJ1c2VyOjUwIn0"
+		$ azion kv list --namespace "my-namespace" --details
+		`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !cmd.Flags().Changed("namespace") {
+				answer, err := list.AskInput(msg.AskInputNamespace)
+				if err != nil {
+					logger.Debug("Error while parsing answer", zap.Error(err))
+					return utils.ErrorParseResponse
+				}
+				namespace = answer
+			}
+			if opts.Limit < 0 {
+				return msg.ErrorLimit
+			}
+			return list.Run(f, namespace, opts, details)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&namespace, "namespace", "", msg.FlagNamespace)
+	cobraCmd.Flags().StringVar(&opts.Prefix, "prefix", "", msg.FlagPrefix)
+	cobraCmd.Flags().Int64Var(&opts.Limit, "limit", 0, msg.FlagLimit)
+	cobraCmd.Flags().StringVar(&opts.Cursor, "cursor", "", msg.FlagCursor)
+	cobraCmd.Flags().BoolVar(&details, "details", false, msg.FlagDetails)
+	cobraCmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cobraCmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewListCmd(f), f)
+}
+
+// Run prints a page of the keys of the namespace, followed by the cursor of the next one
+func (cmd *ListCmd) Run(f *cmdutil.Factory, namespace string, opts api.ListItemsOptions, details bool) error {
+	page, err := cmd.ListItems(context.Background(), namespace, opts)
+	if err != nil {
+		return fmt.Errorf(msg.ErrorListItems, namespace, err)
+	}
+
+	listOut := output.ListOutput{}
+	listOut.Columns = []string{"KEY"}
+	listOut.Out = f.IOStreams.Out
+	listOut.Flags = f.Flags
+	// the cursor is part of the structured output, so scripts can read the next page
+	listOut.Cursor = page.Cursor
+
+	if details {
+		listOut.Columns = []string{"KEY", "EXPIRATION", "METADATA"}
+	}
+
+	for _, item := range page.Items {
+		if !details {
+			listOut.Lines = append(listOut.Lines, []string{item.Key})
+			continue
+		}
+		listOut.Lines = append(listOut.Lines, []string{item.Key, expiration(item.Expiration), metadata(item.Metadata)})
+	}
+
+	if err := output.Print(&listOut); err != nil {
+		return err
+	}
+
+	if page.Cursor != "" && len(f.Flags.Format) == 0 && len(f.Flags.Out) == 0 {
+		logger.FInfo(f.IOStreams.Out, fmt.Sprintf(msg.NextPage, page.Cursor))
+	}
+	return nil
+}
+
+func expiration(unix int64) string {
+	if unix == 0 {
+		return "-"
+	}
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
+
+func metadata(values map[string]interface{}) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "-"
+	}
+	return string(data)
+}
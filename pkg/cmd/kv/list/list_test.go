@@ -0,0 +1,80 @@
+package list
+
+import (
+	"context"
+	"testing"
+
+	msg "github.com/aziontech/azion-cli/messages/kv/list"
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+func TestList(t *testing.T) {
+	t.Run("page with the cursor of the next one", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		listCmd := NewListCmd(f)
+		listCmd.ListItems = func(ctx context.Context, namespace string, opts api.ListItemsOptions) (*api.ItemPage, error) {
+			require.Equal(t, "ns", namespace)
+			require.Equal(t, api.ListItemsOptions{Prefix: "user:", Limit: 2, Cursor: "abc"}, opts)
+			return &api.ItemPage{
+				Items: []api.Item{
+					{Key: "user:1", Expiration: 1735689600, Metadata: map[string]interface{}{"role": "admin"}},
+					{Key: "user:2"},
+				},
+				Cursor: "def",
+			}, nil
+		}
+
+		cmd := NewCobraCmd(listCmd, f)
+		cmd.SetArgs([]string{"--namespace", "ns", "--prefix", "user:", "--limit", "2", "--cursor", "abc", "--details"})
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, stdout.String(), "user:1")
+		require.Contains(t, stdout.String(), "2025-01-01T00:00:00Z")
+		require.Contains(t, stdout.String(), `{"role":"admin"}`)
+		require.Contains(t, stdout.String(), "user:2")
+		require.Contains(t, stdout.String(), "--cursor def")
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		listCmd := NewListCmd(f)
+		listCmd.ListItems = func(ctx context.Context, namespace string, opts api.ListItemsOptions) (*api.ItemPage, error) {
+			return &api.ItemPage{Items: []api.Item{{Key: "greeting"}}}, nil
+		}
+
+		cmd := NewCobraCmd(listCmd, f)
+		cmd.SetArgs([]string{"--namespace", "ns"})
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, stdout.String(), "greeting")
+		require.NotContains(t, stdout.String(), "--cursor")
+	})
+
+	t.Run("structured output with the cursor of the next one", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		f.Format = "json"
+		listCmd := NewListCmd(f)
+		listCmd.ListItems = func(ctx context.Context, namespace string, opts api.ListItemsOptions) (*api.ItemPage, error) {
+			return &api.ItemPage{Items: []api.Item{{Key: "user:1"}}, Cursor: "def"}, nil
+		}
+
+		cmd := NewCobraCmd(listCmd, f)
+		cmd.SetArgs([]string{"--namespace", "ns"})
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, stdout.String(), `"cursor": "def"`)
+		require.NotContains(t, stdout.String(), "--cursor")
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(nil)
+		cmd := NewCobraCmd(NewListCmd(f), f)
+		cmd.SetArgs([]string{"--namespace", "ns", "--limit", "-1"})
+		require.ErrorIs(t, cmd.Execute(), msg.ErrorLimit)
+	})
+}
@@ -0,0 +1,156 @@
+package put
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/kv/put"
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/iostreams"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type Fields struct {
+	Namespace  string
+	Key        string
+	Value      string
+	File       string
+	TTL        int64
+	Expiration int64
+	Metadata   string
+}
+
+type PutCmd struct {
+	Io       *iostreams.IOStreams
+	AskInput func(string) (string, error)
+	ReadFile func(name string) ([]byte, error)
+	PutItem  func(ctx context.Context, namespace, key string, req api.PutItemRequest) error
+}
+
+func NewPutCmd(f *cmdutil.Factory) *PutCmd {
+	return &PutCmd{
+		Io:       f.IOStreams,
+		AskInput: utils.AskInput,
+		ReadFile: os.ReadFile,
+		PutItem: func(ctx context.Context, namespace, key string, req api.PutItemRequest) error {
+			client := api.NewClient(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
+			return client.PutItem(ctx, namespace, key, req)
+		},
+	}
+}
+
+func NewCobraCmd(put *PutCmd, f *cmdutil.Factory) *cobra.Command {
+	fields := &Fields{}
+
+	cobraCmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+		$ azion kv put --namespace "my-namespace" --key "greeting" --value "hello"
+		$ azion kv put --namespace "my-namespace" --key "config" --file ./config.json
+		$ azion kv put --namespace "my-namespace" --key "session" --value "abc" --ttl 3600
+		$ azion kv put --namespace "my-namespace" --key "user:1" --value "ana" --metadata '{"role": "admin"}'
+		`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !cmd.Flags().Changed("namespace") {
+				answer, err := put.AskInput(msg.AskInputNamespace)
+				if err != nil {
+					logger.Debug("Error while parsing answer", zap.Error(err))
+					return utils.ErrorParseResponse
+				}
+				fields.Namespace = answer
+			}
+			if !cmd.Flags().Changed("key") {
+				answer, err := put.AskInput(msg.AskInputKey)
+				if err != nil {
+					logger.Debug("Error while parsing answer", zap.Error(err))
+					return utils.ErrorParseResponse
+				}
+				fields.Key = answer
+			}
+			if cmd.Flags().Changed("value") && cmd.Flags().Changed("file") {
+				return msg.ErrorValueAndFile
+			}
+			if !cmd.Flags().Changed("value") && !cmd.Flags().Changed("file") {
+				answer, err := put.AskInput(msg.AskInputValue)
+				if err != nil {
+					logger.Debug("Error while parsing answer", zap.Error(err))
+					return utils.ErrorParseResponse
+				}
+				fields.Value = answer
+			}
+
+			request, err := put.request(fields)
+			if err != nil {
+				return err
+			}
+
+			if err := put.PutItem(context.Background(), fields.Namespace, fields.Key, request); err != nil {
+				return fmt.Errorf(msg.ErrorPutItem, fields.Key, err)
+			}
+
+			putOut := output.GeneralOutput{
+				Msg:   fmt.Sprintf(msg.OutputSuccess, fields.Key, fields.Namespace),
+				Out:   f.IOStreams.Out,
+				Flags: f.Flags,
+			}
+			return output.Print(&putOut)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&fields.Namespace, "namespace", "", msg.FlagNamespace)
+	cobraCmd.Flags().StringVar(&fields.Key, "key", "", msg.FlagKey)
+	cobraCmd.Flags().StringVar(&fields.Value, "value", "", msg.FlagValue)
+	cobraCmd.Flags().StringVar(&fields.File, "file", "", msg.FlagFile)
+	cobraCmd.Flags().Int64Var(&fields.TTL, "ttl", 0, msg.FlagTTL)
+	cobraCmd.Flags().Int64Var(&fields.Expiration, "expiration", 0, msg.FlagExpiration)
+	cobraCmd.Flags().StringVar(&fields.Metadata, "metadata", "", msg.FlagMetadata)
+	cobraCmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cobraCmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewPutCmd(f), f)
+}
+
+// request validates the fields of the command and turns them into the request of the API
+func (put *PutCmd) request(fields *Fields) (api.PutItemRequest, error) {
+	request := api.PutItemRequest{Value: fields.Value}
+
+	if fields.File != "" {
+		data, err := put.ReadFile(fields.File)
+		if err != nil {
+			return request, fmt.Errorf(msg.ErrorReadFile, fields.File, err)
+		}
+		request.Value = string(data)
+	}
+
+	if fields.TTL != 0 && fields.Expiration != 0 {
+		return request, msg.ErrorTTLAndExpiration
+	}
+	if fields.TTL < 0 || fields.Expiration < 0 {
+		return request, msg.ErrorExpiration
+	}
+	request.ExpirationTTL = fields.TTL
+	request.Expiration = fields.Expiration
+
+	if fields.Metadata != "" {
+		if err := json.Unmarshal([]byte(fields.Metadata), &request.Metadata); err != nil || request.Metadata == nil {
+			logger.Debug("Error while parsing the metadata", zap.Error(err))
+			return request, msg.ErrorMetadata
+		}
+	}
+	return request, nil
+}
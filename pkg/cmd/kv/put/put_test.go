@@ -0,0 +1,113 @@
+package put
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	msg "github.com/aziontech/azion-cli/messages/kv/put"
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+func TestPut(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		files   map[string]string
+		request api.PutItemRequest
+		err     error
+	}{
+		{
+			name:    "value with ttl",
+			args:    []string{"--namespace", "ns", "--key", "session", "--value", "abc", "--ttl", "3600"},
+			request: api.PutItemRequest{Value: "abc", ExpirationTTL: 3600},
+		},
+		{
+			name:    "value from file with expiration",
+			args:    []string{"--namespace", "ns", "--key", "config", "--file", "config.json", "--expiration", "1735689600"},
+			files:   map[string]string{"config.json": `{"theme": "dark"}`},
+			request: api.PutItemRequest{Value: `{"theme": "dark"}`, Expiration: 1735689600},
+		},
+		{
+			name:    "metadata",
+			args:    []string{"--namespace", "ns", "--key", "user:1", "--value", "ana", "--metadata", `{"role": "admin"}`},
+			request: api.PutItemRequest{Value: "ana", Metadata: map[string]interface{}{"role": "admin"}},
+		},
+		{
+			name: "value and file",
+			args: []string{"--namespace", "ns", "--key", "k", "--value", "v", "--file", "config.json"},
+			err:  msg.ErrorValueAndFile,
+		},
+		{
+			name: "ttl and expiration",
+			args: []string{"--namespace", "ns", "--key", "k", "--value", "v", "--ttl", "60", "--expiration", "1735689600"},
+			err:  msg.ErrorTTLAndExpiration,
+		},
+		{
+			name: "negative ttl",
+			args: []string{"--namespace", "ns", "--key", "k", "--value", "v", "--ttl", "-1"},
+			err:  msg.ErrorExpiration,
+		},
+		{
+			name: "metadata not an object",
+			args: []string{"--namespace", "ns", "--key", "k", "--value", "v", "--metadata", `["admin"]`},
+			err:  msg.ErrorMetadata,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, stdout, _ := testutils.NewFactory(nil)
+			putCmd := NewPutCmd(f)
+			putCmd.ReadFile = func(name string) ([]byte, error) {
+				if content, ok := tt.files[name]; ok {
+					return []byte(content), nil
+				}
+				return nil, errors.New("file not found")
+			}
+			var sent *api.PutItemRequest
+			putCmd.PutItem = func(ctx context.Context, namespace, key string, req api.PutItemRequest) error {
+				require.Equal(t, "ns", namespace)
+				sent = &req
+				return nil
+			}
+
+			cmd := NewCobraCmd(putCmd, f)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				require.Nil(t, sent)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.request, *sent)
+			require.Contains(t, stdout.String(), "written to the namespace 'ns'")
+		})
+	}
+
+	t.Run("asks for the value", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(nil)
+		putCmd := NewPutCmd(f)
+		putCmd.AskInput = func(prompt string) (string, error) {
+			require.Equal(t, msg.AskInputValue, prompt)
+			return "asked", nil
+		}
+		putCmd.PutItem = func(ctx context.Context, namespace, key string, req api.PutItemRequest) error {
+			require.Equal(t, "asked", req.Value)
+			return nil
+		}
+
+		cmd := NewCobraCmd(putCmd, f)
+		cmd.SetArgs([]string{"--namespace", "ns", "--key", "k"})
+		require.NoError(t, cmd.Execute())
+	})
+}
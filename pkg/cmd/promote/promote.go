@@ -0,0 +1,37 @@
+package promote
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/promote"
+	deploy "github.com/aziontech/azion-cli/pkg/cmd/deploy_remote"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+var projectPath string
+
+func NewCobraCmd(deployCmd *deploy.DeployCmd, f *cmdutil.Factory) *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:           msg.USAGE,
+		Short:         msg.SHORTDESCRIPTION,
+		Long:          msg.LONGDESCRIPTION,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+		$ azion promote
+		$ azion promote --config-dir azion-staging
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deployCmd.Promote(f, projectPath)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&projectPath, "config-dir", "azion", msg.CONFFLAG)
+	cobraCmd.Flags().BoolP("help", "h", false, msg.FLAGHELP)
+
+	return cobraCmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(deploy.NewDeployCmd(f), f)
+}
@@ -2,17 +2,20 @@ package rollback
 
 import (
 	"context"
+	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/MakeNowJust/heredoc"
 	msg "github.com/aziontech/azion-cli/messages/rollback"
 	apiConnector "github.com/aziontech/azion-cli/pkg/api/connector"
+	apipurge "github.com/aziontech/azion-cli/pkg/api/realtime_purge"
 	api "github.com/aziontech/azion-cli/pkg/api/storage"
+	deploy "github.com/aziontech/azion-cli/pkg/cmd/deploy_remote"
 	"github.com/aziontech/azion-cli/pkg/cmdutil"
 	"github.com/aziontech/azion-cli/pkg/contracts"
 	"github.com/aziontech/azion-cli/pkg/logger"
 	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/state"
 	"github.com/aziontech/azion-cli/utils"
 	sdk "github.com/aziontech/azionapi-v4-go-sdk-dev/azion-api"
 	"github.com/spf13/cobra"
@@ -22,19 +25,38 @@ import (
 var (
 	connectorID int64
 	projectPath string
+	to          string
 )
 
 type RollbackCmd struct {
 	AskInput              func(string) (string, error)
 	GetAzionJsonContent   func(pathConf string) (*contracts.AzionApplicationOptions, error)
 	WriteAzionJsonContent func(conf *contracts.AzionApplicationOptions, confPath string) error
+	ListVersions          func(ctx context.Context, bucket string) ([]deploy.Version, error)
+	GetConnector          func(ctx context.Context, id int64) (sdk.Connector, error)
+	UpdatePrefix          func(ctx context.Context, id int64, bucket, prefix string) error
+	PurgeDomain           func(ctx context.Context, domain string) error
 }
 
 func NewDeleteCmd(f *cmdutil.Factory) *RollbackCmd {
+	store := state.New(f)
+	storageClient := api.NewClient(f.HttpClient, f.Config.GetString("storage_url"), f.Config.GetString("token"))
+	connectorClient := apiConnector.NewClient(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
+	purgeClient := apipurge.NewClient(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
 	return &RollbackCmd{
-		GetAzionJsonContent:   utils.GetAzionJsonContent,
-		WriteAzionJsonContent: utils.WriteAzionJsonContent,
+		GetAzionJsonContent:   store.Read,
+		WriteAzionJsonContent: store.Write,
 		AskInput:              utils.AskInput,
+		ListVersions: func(ctx context.Context, bucket string) ([]deploy.Version, error) {
+			return deploy.ListVersions(ctx, storageClient, bucket)
+		},
+		GetConnector: connectorClient.Get,
+		UpdatePrefix: func(ctx context.Context, id int64, bucket, prefix string) error {
+			return deploy.SetConnectorPrefix(ctx, connectorClient, id, bucket, prefix)
+		},
+		PurgeDomain: func(ctx context.Context, domain string) error {
+			return purgeClient.PurgeCache(ctx, []string{domain + "/*"}, "wildcard", "edge_cache")
+		},
 	}
 }
 
@@ -46,11 +68,31 @@ func NewCobraCmd(rollback *RollbackCmd, f *cmdutil.Factory) *cobra.Command {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		Example: heredoc.Doc(`
-		$ azion rollback --connector-id aaaa-bbbb-cccc-dddd
+		$ azion rollback
+		$ azion rollback --to 20240105143000
+		$ azion rollback --connector-id 1234
 		`),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			conf, err := rollback.GetAzionJsonContent(projectPath)
+			if err != nil {
+				logger.Debug("Error while reading azion.json file", zap.Error(err))
+				return msg.ERRORAZION
+			}
+
+			if conf.Bucket == "" || conf.Prefix == "" {
+				return msg.ERRORNEEDSDEPLOY
+			}
 
+			connectors := []int64{connectorID}
 			if !cmd.Flags().Changed("connector-id") {
+				connectors, err = deploy.StorageConnectors(ctx, rollback.GetConnector, conf)
+				if err != nil {
+					return msg.ERRORROLLBACK
+				}
+			}
+			if len(connectors) == 0 {
 				answer, err := rollback.AskInput(msg.ASKCONNECTOR)
 				if err != nil {
 					return err
@@ -62,47 +104,28 @@ func NewCobraCmd(rollback *RollbackCmd, f *cmdutil.Factory) *cobra.Command {
 					return msg.ERRORCONVERTCONNECTORID
 				}
 
-				connectorID = num
+				connectors = []int64{num}
 			}
 
-			conf, err := rollback.GetAzionJsonContent(projectPath)
-			if err != nil {
-				logger.Debug("Error while reading azion.json file", zap.Error(err))
-				return msg.ERRORAZION
-			}
-
-			if conf.Bucket == "" || conf.Prefix == "" {
-				return msg.ERRORNEEDSDEPLOY
-			}
-
-			timestamp, err := checkForNewTimestamp(f, conf.Prefix, conf.Bucket)
+			versions, err := rollback.ListVersions(ctx, conf.Bucket)
 			if err != nil {
+				logger.Debug("Error while listing the versions of the bucket", zap.Error(err))
 				return msg.ERRORROLLBACK
 			}
 
-			if timestamp == "" {
-				logger.Debug("No previous timestamp found for rollback")
-				return msg.ERRORNOPREVIOUS
+			timestamp, err := targetVersion(versions, conf.Prefix, conf.Bucket, to)
+			if err != nil {
+				return err
 			}
 
 			logger.Debug("Rolling back to previous timestamp", zap.String("from", conf.Prefix), zap.String("to", timestamp))
 
-			clientConnector := apiConnector.NewClient(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
-			request := apiConnector.UpdateRequest{}
-
-			attributes := sdk.ConnectorStorageAttributesRequest{}
-			attributes.SetBucket(conf.Bucket)
-			attributes.SetPrefix(timestamp)
-
-			storageRequest := sdk.PatchedConnectorRequest{}
-			storageConnectorRequest := sdk.PatchedConnectorRequestBase{}
-			storageConnectorRequest.SetAttributes(attributes)
-			storageRequest.PatchedConnectorRequestBase = &storageConnectorRequest
-			request.PatchedConnectorRequest = storageRequest
-
-			_, err = clientConnector.Update(context.Background(), &request, connectorID)
-			if err != nil {
-				return msg.ERRORROLLBACK
+			for _, id := range connectors {
+				if err := rollback.UpdatePrefix(ctx, id, conf.Bucket, timestamp); err != nil {
+					logger.Debug("Error while updating the prefix of the connector", zap.Int64("connector", id), zap.Error(err))
+					return msg.ERRORROLLBACK
+				}
+				logger.FInfoFlags(f.IOStreams.Out, fmt.Sprintf(msg.ROLLEDBACK, id, timestamp), f.Format, f.Out)
 			}
 
 			conf.Prefix = timestamp
@@ -111,6 +134,16 @@ func NewCobraCmd(rollback *RollbackCmd, f *cmdutil.Factory) *cobra.Command {
 				return msg.ERRORROLLBACK
 			}
 
+			// the rollback is done at this point, so the cache left to expire is reported instead of failing
+			for _, domain := range conf.Workloads.Domains {
+				if err := rollback.PurgeDomain(ctx, domain); err != nil {
+					logger.Debug("Error while purging the cache of the domain", zap.String("domain", domain), zap.Error(err))
+					logger.FInfoFlags(f.IOStreams.Out, fmt.Sprintf(msg.PURGEFAILED, domain, err.Error()), f.Format, f.Out)
+					continue
+				}
+				logger.FInfoFlags(f.IOStreams.Out, fmt.Sprintf(msg.PURGED, domain), f.Format, f.Out)
+			}
+
 			rollbackOut := output.GeneralOutput{
 				Msg:   msg.SUCCESS,
 				Out:   f.IOStreams.Out,
@@ -121,6 +154,7 @@ func NewCobraCmd(rollback *RollbackCmd, f *cmdutil.Factory) *cobra.Command {
 	}
 
 	cobraCmd.Flags().Int64Var(&connectorID, "connector-id", 0, msg.FLAGCONNECTORID)
+	cobraCmd.Flags().StringVar(&to, "to", "", msg.FLAGTO)
 	cobraCmd.Flags().StringVar(&projectPath, "config-dir", "azion", msg.CONFFLAG)
 	cobraCmd.Flags().BoolP("help", "h", false, msg.FLAGHELP)
 
@@ -131,43 +165,25 @@ func NewCmd(f *cmdutil.Factory) *cobra.Command {
 	return NewCobraCmd(NewDeleteCmd(f), f)
 }
 
-func checkForNewTimestamp(f *cmdutil.Factory, referenceTimestamp, bucketName string) (string, error) {
-	logger.Debug("Checking if there are previous static files for the following bucket", zap.Any("Bucket name", bucketName))
-	client := api.NewClient(f.HttpClient, f.Config.GetString("storage_url"), f.Config.GetString("token"))
-	c := context.Background()
-
-	var prevTimestamp string
-	var continuationToken string
-
-	for {
-		options := &contracts.ListOptions{
-			ContinuationToken: continuationToken,
-		}
-
-		resp, err := client.ListObject(c, bucketName, options)
-		if err != nil {
-			return "", err
-		}
-
-		for _, object := range resp.Results {
-			parts := strings.Split(object.Key, "/")
-			if len(parts) > 1 {
-				timestamp := parts[0]
-				logger.Debug("Found timestamp in bucket", zap.String("timestamp", timestamp), zap.String("key", object.Key))
-				if timestamp == referenceTimestamp {
-					logger.Debug("Found current timestamp, returning previous", zap.String("current", referenceTimestamp), zap.String("previous", prevTimestamp))
-					return prevTimestamp, nil
-				}
-				prevTimestamp = timestamp
-			}
+// targetVersion returns the version to roll back to: the one given with --to or, without it, the one
+// deployed right before the current version
+func targetVersion(versions []deploy.Version, current, bucket, to string) (string, error) {
+	if to == "" {
+		previous := deploy.PreviousVersion(versions, current)
+		if previous == "" {
+			logger.Debug("No previous timestamp found for rollback")
+			return "", msg.ERRORNOPREVIOUS
 		}
+		return previous, nil
+	}
 
-		logger.Debug("continuing to next page", zap.Any("continuation-token", resp.GetContinuationToken()))
-		if contToken, ok := resp.GetContinuationTokenOk(); contToken == nil || !ok {
-			break
+	if to == current {
+		return "", fmt.Errorf(msg.ERRORCURRENTVERSION, to)
+	}
+	for _, version := range versions {
+		if version.Prefix == to {
+			return to, nil
 		}
-		continuationToken = resp.GetContinuationToken()
 	}
-
-	return referenceTimestamp, nil
+	return "", fmt.Errorf(msg.ERRORVERSIONNOTFOUND, to, bucket)
 }
@@ -1,9 +1,12 @@
 package rollback
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	msg "github.com/aziontech/azion-cli/messages/rollback"
+	deploy "github.com/aziontech/azion-cli/pkg/cmd/deploy_remote"
 	"github.com/aziontech/azion-cli/pkg/contracts"
 	"github.com/aziontech/azion-cli/pkg/logger"
 	"github.com/aziontech/azion-cli/utils"
@@ -117,3 +120,74 @@ func TestRollbackWithAskInput(t *testing.T) {
 		})
 	}
 }
+
+func TestRollbackTo(t *testing.T) {
+	logger.New(zapcore.DebugLevel)
+
+	versions := []deploy.Version{
+		{Prefix: "20240103000000", Files: 3},
+		{Prefix: "20240102000000", Files: 3},
+		{Prefix: "20240101000000", Files: 2},
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		prefix string
+		err    string
+	}{
+		{name: "previous version by default", args: []string{"--connector-id", "42"}, prefix: "20240102000000"},
+		{name: "given version", args: []string{"--connector-id", "42", "--to", "20240101000000"}, prefix: "20240101000000"},
+		{name: "unknown version", args: []string{"--connector-id", "42", "--to", "20231231000000"}, err: fmt.Sprintf(msg.ERRORVERSIONNOTFOUND, "20231231000000", "bucket")},
+		{name: "current version", args: []string{"--connector-id", "42", "--to", "20240103000000"}, err: fmt.Sprintf(msg.ERRORCURRENTVERSION, "20240103000000")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &httpmock.Registry{}
+			f, _, _ := testutils.NewFactory(mock)
+
+			var written *contracts.AzionApplicationOptions
+			updated := map[int64]string{}
+			purged := []string{}
+
+			rollbackCmd := NewDeleteCmd(f)
+			rollbackCmd.GetAzionJsonContent = func(pathConf string) (*contracts.AzionApplicationOptions, error) {
+				return &contracts.AzionApplicationOptions{
+					Bucket:    "bucket",
+					Prefix:    "20240103000000",
+					Workloads: contracts.AzionJsonDataWorkload{Domains: []string{"example.azion.app"}},
+				}, nil
+			}
+			rollbackCmd.WriteAzionJsonContent = func(conf *contracts.AzionApplicationOptions, confPath string) error {
+				written = conf
+				return nil
+			}
+			rollbackCmd.ListVersions = func(ctx context.Context, bucket string) ([]deploy.Version, error) {
+				return versions, nil
+			}
+			rollbackCmd.UpdatePrefix = func(ctx context.Context, id int64, bucket, prefix string) error {
+				updated[id] = prefix
+				return nil
+			}
+			rollbackCmd.PurgeDomain = func(ctx context.Context, domain string) error {
+				purged = append(purged, domain)
+				return nil
+			}
+
+			to = ""
+			cobraCmd := NewCobraCmd(rollbackCmd, f)
+			cobraCmd.SetArgs(tt.args)
+			_, err := cobraCmd.ExecuteC()
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				require.Empty(t, updated)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, map[int64]string{42: tt.prefix}, updated)
+			require.Equal(t, tt.prefix, written.Prefix)
+			require.Equal(t, []string{"example.azion.app"}, purged)
+		})
+	}
+}
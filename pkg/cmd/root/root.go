@@ -8,6 +8,7 @@ import (
 
 	"github.com/MakeNowJust/heredoc"
 	msg "github.com/aziontech/azion-cli/messages/root"
+	"github.com/aziontech/azion-cli/pkg/cmd/abort"
 	buildCmd "github.com/aziontech/azion-cli/pkg/cmd/build"
 	"github.com/aziontech/azion-cli/pkg/cmd/clone"
 	"github.com/aziontech/azion-cli/pkg/cmd/completion"
@@ -15,17 +16,21 @@ import (
 	"github.com/aziontech/azion-cli/pkg/cmd/create"
 	"github.com/aziontech/azion-cli/pkg/cmd/delete"
 	"github.com/aziontech/azion-cli/pkg/cmd/describe"
+	"github.com/aziontech/azion-cli/pkg/cmd/kv"
 	"github.com/aziontech/azion-cli/pkg/cmd/list"
 	"github.com/aziontech/azion-cli/pkg/cmd/login"
 	"github.com/aziontech/azion-cli/pkg/cmd/logout"
 	logcmd "github.com/aziontech/azion-cli/pkg/cmd/logs"
 	"github.com/aziontech/azion-cli/pkg/cmd/profiles"
+	"github.com/aziontech/azion-cli/pkg/cmd/promote"
 	"github.com/aziontech/azion-cli/pkg/cmd/purge"
 	"github.com/aziontech/azion-cli/pkg/cmd/reset"
 	"github.com/aziontech/azion-cli/pkg/cmd/rollback"
+	"github.com/aziontech/azion-cli/pkg/cmd/storage"
 	"github.com/aziontech/azion-cli/pkg/cmd/sync"
 	"github.com/aziontech/azion-cli/pkg/cmd/unlink"
 	"github.com/aziontech/azion-cli/pkg/cmd/update"
+	"github.com/aziontech/azion-cli/pkg/cmd/versions"
 	"github.com/aziontech/azion-cli/pkg/cmd/warmup"
 	"github.com/aziontech/azion-cli/pkg/cmd/whoami"
 	"github.com/aziontech/azion-cli/pkg/metric"
@@ -179,6 +184,11 @@ func (fact *factoryRoot) setCmds(cobraCmd *cobra.Command) {
 	cobraCmd.AddCommand(reset.NewCmd(fact.factory))
 	cobraCmd.AddCommand(sync.NewCmd(fact.factory))
 	cobraCmd.AddCommand(rollback.NewCmd(fact.factory))
+	cobraCmd.AddCommand(versions.NewCmd(fact.factory))
+	cobraCmd.AddCommand(kv.NewCmd(fact.factory))
+	cobraCmd.AddCommand(storage.NewCmd(fact.factory))
+	cobraCmd.AddCommand(promote.NewCmd(fact.factory))
+	cobraCmd.AddCommand(abort.NewCmd(fact.factory))
 	cobraCmd.AddCommand(clone.NewCmd(fact.factory))
 	cobraCmd.AddCommand(warmup.NewCmd(fact.factory))
 	cobraCmd.AddCommand(profiles.NewCmd(fact.factory))
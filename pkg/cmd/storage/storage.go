@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/storage"
+	"github.com/aziontech/azion-cli/pkg/cmd/storage/storagedownload"
+	"github.com/aziontech/azion-cli/pkg/cmd/storage/storagesync"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     msg.USAGE,
+		Short:   msg.SHORT_DESCRIPTION_STORAGE,
+		Long:    msg.LONG_DESCRIPTION_STORAGE,
+		Example: heredoc.Doc(msg.EXAMPLE_STORAGE),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(storagesync.NewCmd(f))
+	cmd.AddCommand(storagedownload.NewCmd(f))
+	cmd.Flags().BoolP("help", "h", false, msg.FLAG_HELP)
+
+	return cmd
+}
@@ -0,0 +1,255 @@
+package storagedownload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/storage/storagedownload"
+	api "github.com/aziontech/azion-cli/pkg/api/storage"
+	"github.com/aziontech/azion-cli/pkg/cmd/storage/storagesync"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/iostreams"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/workers"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// partSuffix is added to the path of an object while it is downloaded, so an interrupted download is
+// resumed from the bytes already written instead of being taken for a complete file
+const partSuffix = ".azion-part"
+
+type Options struct {
+	Bucket  string
+	Prefix  string
+	Dest    string
+	Workers int
+}
+
+type DownloadCmd struct {
+	Io             *iostreams.IOStreams
+	AskInput       func(string) (string, error)
+	ListObjects    func(ctx context.Context, bucket, prefix string) (map[string]storagesync.Entry, error)
+	DownloadObject func(ctx context.Context, bucket, key string, offset int64, modTime time.Time) (*api.ObjectReader, error)
+}
+
+func NewDownloadCmd(f *cmdutil.Factory) *DownloadCmd {
+	client := func() *api.Client {
+		return api.NewClient(f.HttpClient, f.Config.GetString("storage_url"), f.Config.GetString("token"))
+	}
+	return &DownloadCmd{
+		Io:       f.IOStreams,
+		AskInput: utils.AskInput,
+		ListObjects: func(ctx context.Context, bucket, prefix string) (map[string]storagesync.Entry, error) {
+			return listObjects(ctx, client(), bucket, prefix)
+		},
+		DownloadObject: func(ctx context.Context, bucket, key string, offset int64, modTime time.Time) (*api.ObjectReader, error) {
+			return client().DownloadObject(ctx, bucket, key, offset, modTime)
+		},
+	}
+}
+
+func NewCobraCmd(download *DownloadCmd, f *cmdutil.Factory) *cobra.Command {
+	opts := &Options{}
+
+	cobraCmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+		$ azion storage download --bucket my-bucket --dest ./my-bucket
+		$ azion storage download --bucket logs --prefix 2024-05-17/ --dest ./out
+		$ azion storage download --bucket logs --prefix 2024-05 --dest ./out --workers 20
+		`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !cmd.Flags().Changed("bucket") {
+				answer, err := download.AskInput(msg.AskInputBucket)
+				if err != nil {
+					logger.Debug("Error while parsing answer", zap.Error(err))
+					return utils.ErrorParseResponse
+				}
+				opts.Bucket = answer
+			}
+			return download.Run(f, opts)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&opts.Bucket, "bucket", "", msg.FlagBucket)
+	cobraCmd.Flags().StringVar(&opts.Prefix, "prefix", "", msg.FlagPrefix)
+	cobraCmd.Flags().StringVar(&opts.Dest, "dest", ".", msg.FlagDest)
+	cobraCmd.Flags().IntVar(&opts.Workers, "workers", 0, msg.FlagWorkers)
+	cobraCmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cobraCmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewDownloadCmd(f), f)
+}
+
+// Run downloads the objects under the prefix to <dest>/<key>, skipping the ones already downloaded. Failures
+// are reported and make the command fail once every other object is downloaded.
+func (cmd *DownloadCmd) Run(f *cmdutil.Factory, opts *Options) error {
+	if info, err := os.Stat(opts.Dest); err == nil && !info.IsDir() {
+		return fmt.Errorf(msg.ErrorNotDirectory, opts.Dest)
+	}
+
+	ctx := context.Background()
+	objects, err := cmd.ListObjects(ctx, opts.Bucket, opts.Prefix)
+	if err != nil {
+		return fmt.Errorf(msg.ErrorListObjects, opts.Bucket, err)
+	}
+
+	// a file is already downloaded when it matches the object as a synced file would
+	local := map[string]storagesync.Entry{}
+	for key := range objects {
+		if info, err := os.Stat(filepath.Join(opts.Dest, filepath.FromSlash(key))); err == nil && !info.IsDir() {
+			local[key] = storagesync.Entry{Size: info.Size(), ModTime: info.ModTime()}
+		}
+	}
+	plan := storagesync.NewPlan(objects, local, false)
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+	limit := make(chan struct{}, workers.CalculateOptimal(opts.Workers))
+	for _, key := range plan.Transfer {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(key string) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			err := workers.RetryRateLimited(func() error {
+				return cmd.download(ctx, opts, key, objects[key])
+			})
+			if err == nil {
+				return
+			}
+			logger.Debug("Error while downloading an object", zap.String("key", key), zap.Error(err))
+			mu.Lock()
+			defer mu.Unlock()
+			failed++
+			logger.FInfoFlags(f.IOStreams.Out, fmt.Sprintf(msg.ObjectFailed, key, err.Error()), f.Format, f.Out)
+		}(key)
+	}
+	wg.Wait()
+
+	downloadOut := output.GeneralOutput{
+		Msg:   fmt.Sprintf(msg.OutputSuccess, len(plan.Transfer)-failed, opts.Dest, plan.Unchanged),
+		Out:   f.IOStreams.Out,
+		Flags: f.Flags,
+	}
+	if err := output.Print(&downloadOut); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf(msg.ErrorDownload, failed)
+	}
+	return nil
+}
+
+// download streams an object to its part file, starting after the bytes a previous run left there. The part
+// file is renamed to the path of the object once complete, with the time the object was stored as its
+// modification time.
+func (cmd *DownloadCmd) download(ctx context.Context, opts *Options, key string, object storagesync.Entry) error {
+	name := filepath.FromSlash(key)
+	// keys come from the bucket, so they must not lead out of the destination
+	if !filepath.IsLocal(name) {
+		return errors.New(msg.ErrorUnsafeKey)
+	}
+	localPath := filepath.Join(opts.Dest, name)
+	partPath := localPath + partSuffix
+	if err := os.MkdirAll(filepath.Dir(localPath), os.ModePerm); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(partPath, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	offset, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	// a part file as large as the object is left from another version of it
+	if offset >= object.Size {
+		offset = 0
+	}
+
+	reader, err := cmd.DownloadObject(ctx, opts.Bucket, key, offset, object.ModTime)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	defer reader.Close()
+
+	// the object is sent whole when it was replaced since the part file was written
+	if err := file.Truncate(reader.Offset); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Seek(reader.Offset, io.SeekStart); err != nil {
+		file.Close()
+		return err
+	}
+	written, err := io.Copy(file, reader)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if size := reader.Offset + written; size != object.Size {
+		os.Remove(partPath)
+		return fmt.Errorf(msg.ErrorSize, size, object.Size)
+	}
+	if err := os.Chtimes(partPath, object.ModTime, object.ModTime); err != nil {
+		return err
+	}
+	return os.Rename(partPath, localPath)
+}
+
+// listObjects lists the objects of the bucket whose key starts with the prefix, by their whole key
+func listObjects(ctx context.Context, client *api.Client, bucket, prefix string) (map[string]storagesync.Entry, error) {
+	objects := map[string]storagesync.Entry{}
+	options := &contracts.ListOptions{}
+	for {
+		resp, err := client.ListObject(ctx, bucket, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range resp.Results {
+			key := object.GetKey()
+			if !strings.HasPrefix(key, prefix) || strings.HasSuffix(key, "/") {
+				continue
+			}
+			objects[key] = storagesync.Entry{Size: int64(object.GetSize()), ModTime: object.GetLastModified()}
+		}
+		if resp.GetContinuationToken() == "" {
+			break
+		}
+		options.ContinuationToken = resp.GetContinuationToken()
+	}
+	return objects, nil
+}
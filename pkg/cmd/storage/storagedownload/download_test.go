@@ -0,0 +1,145 @@
+package storagedownload
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/aziontech/azion-cli/pkg/api/storage"
+	"github.com/aziontech/azion-cli/pkg/cmd/storage/storagesync"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+var stored = time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC)
+
+// bucket serves objects from their contents, recording the offset each download was requested from
+type bucket struct {
+	contents    map[string]string
+	ignoreRange bool
+
+	mu      sync.Mutex
+	offsets map[string]int64
+}
+
+func (b *bucket) listObjects(ctx context.Context, bucketName, prefix string) (map[string]storagesync.Entry, error) {
+	objects := map[string]storagesync.Entry{}
+	for key, content := range b.contents {
+		objects[key] = storagesync.Entry{Size: int64(len(content)), ModTime: stored}
+	}
+	return objects, nil
+}
+
+func (b *bucket) downloadObject(ctx context.Context, bucketName, key string, offset int64, modTime time.Time) (*api.ObjectReader, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.offsets == nil {
+		b.offsets = map[string]int64{}
+	}
+	b.offsets[key] = offset
+	if b.ignoreRange {
+		offset = 0
+	}
+	return &api.ObjectReader{ReadCloser: io.NopCloser(strings.NewReader(b.contents[key][offset:])), Offset: offset}, nil
+}
+
+func run(t *testing.T, b *bucket, opts *Options) (string, error) {
+	f, stdout, _ := testutils.NewFactory(nil)
+	cmd := &DownloadCmd{
+		Io:             f.IOStreams,
+		ListObjects:    b.listObjects,
+		DownloadObject: b.downloadObject,
+	}
+	err := cmd.Run(f, opts)
+	return stdout.String(), err
+}
+
+func requireFile(t *testing.T, path, content string) {
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, content, string(got))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.True(t, info.ModTime().Equal(stored))
+	require.NoFileExists(t, path+partSuffix)
+}
+
+func TestDownload(t *testing.T) {
+	t.Run("keeps the hierarchy of the keys", func(t *testing.T) {
+		dest := t.TempDir()
+		b := &bucket{contents: map[string]string{"logs/a.txt": "hello", "logs/2024/b.txt": "world!"}}
+
+		stdout, err := run(t, b, &Options{Bucket: "logs", Dest: dest})
+		require.NoError(t, err)
+		requireFile(t, filepath.Join(dest, "logs", "a.txt"), "hello")
+		requireFile(t, filepath.Join(dest, "logs", "2024", "b.txt"), "world!")
+		require.Equal(t, "Downloaded 2 objects to "+dest+" and skipped 0 already downloaded\n", stdout)
+
+		b.offsets = nil
+		stdout, err = run(t, b, &Options{Bucket: "logs", Dest: dest})
+		require.NoError(t, err)
+		require.Empty(t, b.offsets)
+		require.Equal(t, "Downloaded 0 objects to "+dest+" and skipped 2 already downloaded\n", stdout)
+	})
+
+	t.Run("resumes interrupted downloads", func(t *testing.T) {
+		dest := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dest, "a.txt"+partSuffix), []byte("he"), 0644))
+		b := &bucket{contents: map[string]string{"a.txt": "hello"}}
+
+		_, err := run(t, b, &Options{Bucket: "logs", Dest: dest})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), b.offsets["a.txt"])
+		requireFile(t, filepath.Join(dest, "a.txt"), "hello")
+	})
+
+	t.Run("starts over objects sent whole", func(t *testing.T) {
+		dest := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dest, "a.txt"+partSuffix), []byte("xx"), 0644))
+		b := &bucket{contents: map[string]string{"a.txt": "hello"}, ignoreRange: true}
+
+		_, err := run(t, b, &Options{Bucket: "logs", Dest: dest})
+		require.NoError(t, err)
+		requireFile(t, filepath.Join(dest, "a.txt"), "hello")
+	})
+
+	t.Run("downloads again files that differ", func(t *testing.T) {
+		dest := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dest, "a.txt"), []byte("hell"), 0644))
+		b := &bucket{contents: map[string]string{"a.txt": "hello"}}
+
+		_, err := run(t, b, &Options{Bucket: "logs", Dest: dest})
+		require.NoError(t, err)
+		requireFile(t, filepath.Join(dest, "a.txt"), "hello")
+	})
+
+	t.Run("rejects keys out of the destination", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "out")
+		b := &bucket{contents: map[string]string{"../evil.txt": "evil", "a.txt": "hello"}}
+
+		stdout, err := run(t, b, &Options{Bucket: "logs", Dest: dest})
+		require.EqualError(t, err, "Failed to download 1 objects. Run the command again to resume them")
+		require.Contains(t, stdout, "Failed to download '../evil.txt': "+"the key is not a relative path inside the destination directory\n")
+		require.NoFileExists(t, filepath.Join(dest, "..", "evil.txt"))
+		requireFile(t, filepath.Join(dest, "a.txt"), "hello")
+	})
+
+	t.Run("rejects a destination that is a file", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "out")
+		require.NoError(t, os.WriteFile(dest, []byte("x"), 0644))
+
+		_, err := run(t, &bucket{}, &Options{Bucket: "logs", Dest: dest})
+		require.EqualError(t, err, "'"+dest+"' is not a directory")
+	})
+}
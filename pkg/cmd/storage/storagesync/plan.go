@@ -0,0 +1,55 @@
+package storagesync
+
+import (
+	"sort"
+	"time"
+)
+
+// Entry is a file of the local directory or an object of the bucket, by its path relative to the synced root.
+// MD5 is the hex digest of its content, when known.
+type Entry struct {
+	Size    int64
+	ModTime time.Time
+	MD5     string
+}
+
+// Plan lists the paths to be transferred from the source and deleted from the destination
+type Plan struct {
+	Transfer  []string
+	Delete    []string
+	Unchanged int
+}
+
+// NewPlan compares the source with the destination. A file is transferred when the destination lacks it, when
+// their sizes differ or when their contents differ. Contents are compared by MD5 when both entries carry it,
+// otherwise the file is transferred when the source was modified after the destination. Objects only carry the
+// time they were stored, with a precision of seconds, so times are compared to the second.
+func NewPlan(source, destination map[string]Entry, withDelete bool) Plan {
+	plan := Plan{Transfer: []string{}, Delete: []string{}}
+	for name, src := range source {
+		dst, ok := destination[name]
+		if !ok || src.Size != dst.Size || changed(src, dst) {
+			plan.Transfer = append(plan.Transfer, name)
+			continue
+		}
+		plan.Unchanged++
+	}
+	if withDelete {
+		for name := range destination {
+			if _, ok := source[name]; !ok {
+				plan.Delete = append(plan.Delete, name)
+			}
+		}
+	}
+	sort.Strings(plan.Transfer)
+	sort.Strings(plan.Delete)
+	return plan
+}
+
+// changed reports whether the content of the source differs from the destination of the same size
+func changed(src, dst Entry) bool {
+	if src.MD5 != "" && dst.MD5 != "" {
+		return src.MD5 != dst.MD5
+	}
+	return src.ModTime.Truncate(time.Second).After(dst.ModTime.Truncate(time.Second))
+}
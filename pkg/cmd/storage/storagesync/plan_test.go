@@ -0,0 +1,76 @@
+package storagesync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPlan(t *testing.T) {
+	stored := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		source      map[string]Entry
+		destination map[string]Entry
+		withDelete  bool
+		want        Plan
+	}{
+		{
+			name:        "missing files are transferred",
+			source:      map[string]Entry{"index.html": {Size: 10, ModTime: stored}, "js/app.js": {Size: 20, ModTime: stored}},
+			destination: map[string]Entry{"index.html": {Size: 10, ModTime: stored}},
+			want:        Plan{Transfer: []string{"js/app.js"}, Delete: []string{}, Unchanged: 1},
+		},
+		{
+			name:        "files of another size are transferred",
+			source:      map[string]Entry{"index.html": {Size: 11, ModTime: stored}},
+			destination: map[string]Entry{"index.html": {Size: 10, ModTime: stored}},
+			want:        Plan{Transfer: []string{"index.html"}, Delete: []string{}},
+		},
+		{
+			name:        "files modified after the destination are transferred",
+			source:      map[string]Entry{"index.html": {Size: 10, ModTime: stored.Add(time.Minute)}},
+			destination: map[string]Entry{"index.html": {Size: 10, ModTime: stored}},
+			want:        Plan{Transfer: []string{"index.html"}, Delete: []string{}},
+		},
+		{
+			name:        "times are compared to the second",
+			source:      map[string]Entry{"index.html": {Size: 10, ModTime: stored.Add(300 * time.Millisecond)}},
+			destination: map[string]Entry{"index.html": {Size: 10, ModTime: stored}},
+			want:        Plan{Transfer: []string{}, Delete: []string{}, Unchanged: 1},
+		},
+		{
+			name:        "files of the same size are compared by content",
+			source:      map[string]Entry{"index.html": {Size: 10, ModTime: stored, MD5: "a"}, "app.js": {Size: 10, ModTime: stored.Add(time.Hour), MD5: "b"}},
+			destination: map[string]Entry{"index.html": {Size: 10, ModTime: stored, MD5: "c"}, "app.js": {Size: 10, ModTime: stored, MD5: "b"}},
+			want:        Plan{Transfer: []string{"index.html"}, Delete: []string{}, Unchanged: 1},
+		},
+		{
+			name:        "times are compared when a checksum is missing",
+			source:      map[string]Entry{"index.html": {Size: 10, ModTime: stored.Add(time.Minute), MD5: "a"}},
+			destination: map[string]Entry{"index.html": {Size: 10, ModTime: stored}},
+			want:        Plan{Transfer: []string{"index.html"}, Delete: []string{}},
+		},
+		{
+			name:        "extraneous files are kept without delete",
+			source:      map[string]Entry{},
+			destination: map[string]Entry{"old.html": {Size: 10, ModTime: stored}},
+			want:        Plan{Transfer: []string{}, Delete: []string{}},
+		},
+		{
+			name:        "extraneous files are deleted",
+			source:      map[string]Entry{"index.html": {Size: 10, ModTime: stored}},
+			destination: map[string]Entry{"old.html": {Size: 10, ModTime: stored}, "b/old.js": {Size: 1, ModTime: stored}},
+			withDelete:  true,
+			want:        Plan{Transfer: []string{"index.html"}, Delete: []string{"b/old.js", "old.html"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, NewPlan(tt.source, tt.destination, tt.withDelete))
+		})
+	}
+}
@@ -0,0 +1,507 @@
+package storagesync
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	msg "github.com/aziontech/azion-cli/messages/storage/storagesync"
+	"github.com/aziontech/azion-cli/pkg/api/s3"
+	api "github.com/aziontech/azion-cli/pkg/api/storage"
+	deploy "github.com/aziontech/azion-cli/pkg/cmd/deploy_remote"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/ignore"
+	"github.com/aziontech/azion-cli/pkg/iostreams"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/token"
+	"github.com/aziontech/azion-cli/pkg/workers"
+	"github.com/spf13/cobra"
+	"github.com/zRedShift/mimemagic"
+	"go.uber.org/zap"
+)
+
+type Options struct {
+	Delete  bool
+	DryRun  bool
+	Include []string
+	Exclude []string
+	Workers int
+}
+
+type SyncCmd struct {
+	Io           *iostreams.IOStreams
+	ListObjects  func(ctx context.Context, bucket, prefix string) (map[string]Entry, error)
+	Upload       func(ctx context.Context, bucket, key, localPath string) error
+	Download     func(ctx context.Context, bucket, key, localPath string, modTime time.Time) error
+	DeleteObject func(ctx context.Context, bucket, key string) error
+	ObjectETag   func(ctx context.Context, bucket, key string) (string, error)
+}
+
+// remote is a prefix of a bucket, given as <bucket>/<prefix>
+type remote struct {
+	Bucket string
+	Prefix string
+}
+
+// Key returns the key of the object at a path relative to the prefix
+func (r remote) Key(name string) string {
+	return strings.TrimPrefix(path.Join(r.Prefix, name), "/")
+}
+
+func (r remote) String() string {
+	return path.Join(r.Bucket, r.Prefix)
+}
+
+func NewSyncCmd(f *cmdutil.Factory) *SyncCmd {
+	client := func() *api.Client {
+		return api.NewClient(f.HttpClient, f.Config.GetString("storage_url"), f.Config.GetString("token"))
+	}
+	s3Config := bucketConfigs(f)
+	return &SyncCmd{
+		Io: f.IOStreams,
+		ListObjects: func(ctx context.Context, bucket, prefix string) (map[string]Entry, error) {
+			return listObjects(ctx, client(), bucket, prefix)
+		},
+		Upload: func(ctx context.Context, bucket, key, localPath string) error {
+			file, err := os.Open(localPath)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			mimeType, err := mimemagic.MatchFilePath(localPath, -1)
+			if err != nil {
+				return err
+			}
+			// unlike CreateObject, Upload leaves the headers shared by the client untouched, so files are
+			// uploaded concurrently with the same client
+			fileOps := &contracts.FileOps{Path: key, MimeType: mimeType.MediaType(), FileContent: file}
+			return client().Upload(ctx, fileOps, &contracts.AzionApplicationOptions{}, bucket)
+		},
+		Download: func(ctx context.Context, bucket, key, localPath string, modTime time.Time) error {
+			object, err := client().DownloadObject(ctx, bucket, key, 0, modTime)
+			if err != nil {
+				return err
+			}
+			defer object.Close()
+			return writeFile(localPath, object, modTime)
+		},
+		DeleteObject: func(ctx context.Context, bucket, key string) error {
+			return client().DeleteObject(ctx, bucket, key)
+		},
+		// listing a bucket gives no checksum of its objects, so their ETag is read through the S3 API
+		ObjectETag: func(ctx context.Context, bucket, key string) (string, error) {
+			cfg, err := s3Config(ctx, bucket)
+			if err != nil {
+				return "", err
+			}
+			return s3.ObjectETag(ctx, cfg, bucket, key)
+		},
+	}
+}
+
+// bucketConfigs returns the S3 configuration of a bucket, with the credentials of the bucket kept in the
+// credentials file of the active profile. As deploy does, they are created when the bucket has none yet.
+// Each bucket is configured once.
+func bucketConfigs(f *cmdutil.Factory) func(ctx context.Context, bucket string) (aws.Config, error) {
+	type bucketConfig struct {
+		cfg aws.Config
+		err error
+	}
+	var mu sync.Mutex
+	configs := map[string]bucketConfig{}
+
+	newConfig := func(ctx context.Context, bucket string) (aws.Config, error) {
+		profile := f.GetActiveProfile()
+		creds, exists, err := token.GetCredentialsForBucket(profile, bucket)
+		if err != nil {
+			return aws.Config{}, err
+		}
+		if !exists {
+			if creds, err = deploy.CreateBucketCredentials(ctx, bucket, f, profile); err != nil {
+				return aws.Config{}, err
+			}
+			if err := token.SaveCredentialsForBucket(profile, bucket, creds); err != nil {
+				return aws.Config{}, err
+			}
+		}
+		return s3.New(creds.S3AccessKey, creds.S3SecretKey)
+	}
+
+	return func(ctx context.Context, bucket string) (aws.Config, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		conf, ok := configs[bucket]
+		if !ok {
+			conf.cfg, conf.err = newConfig(ctx, bucket)
+			configs[bucket] = conf
+		}
+		return conf.cfg, conf.err
+	}
+}
+
+func NewCobraCmd(syncCmd *SyncCmd, f *cmdutil.Factory) *cobra.Command {
+	opts := &Options{}
+
+	cobraCmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.ExactArgs(2),
+		Example: heredoc.Doc(`
+		$ azion storage sync ./public my-bucket/assets
+		$ azion storage sync ./public my-bucket --delete --exclude "*.map"
+		$ azion storage sync my-bucket/backups ./backups --include "*.json" --dry-run
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return syncCmd.Run(f, args[0], args[1], opts)
+		},
+	}
+
+	cobraCmd.Flags().BoolVar(&opts.Delete, "delete", false, msg.FlagDelete)
+	cobraCmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, msg.FlagDryRun)
+	cobraCmd.Flags().StringArrayVar(&opts.Include, "include", []string{}, msg.FlagInclude)
+	cobraCmd.Flags().StringArrayVar(&opts.Exclude, "exclude", []string{}, msg.FlagExclude)
+	cobraCmd.Flags().IntVar(&opts.Workers, "workers", 0, msg.FlagWorkers)
+	cobraCmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cobraCmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewSyncCmd(f), f)
+}
+
+// Run uploads the source to the destination when the source is a local directory, and downloads it otherwise.
+// Files are transferred before the extraneous ones are deleted. Failures are reported and make the command fail
+// once every other file is synced.
+func (cmd *SyncCmd) Run(f *cmdutil.Factory, source, destination string, opts *Options) error {
+	upload := false
+	if info, err := os.Stat(source); err == nil {
+		if !info.IsDir() {
+			return fmt.Errorf(msg.ErrorNotDirectory, source)
+		}
+		upload = true
+	}
+
+	localDir, remoteArg := destination, source
+	if upload {
+		localDir, remoteArg = source, destination
+	}
+	bucket, err := parseRemote(remoteArg)
+	if err != nil {
+		return err
+	}
+	if !upload {
+		if info, err := os.Stat(localDir); err == nil && !info.IsDir() {
+			return fmt.Errorf(msg.ErrorNotDirectory, localDir)
+		}
+	}
+
+	matcher, err := ignore.New(localDir, opts.Include, opts.Exclude)
+	if err != nil {
+		return fmt.Errorf(msg.ErrorReadIgnoreFile, ignore.FileName, err)
+	}
+
+	ctx := context.Background()
+	local, err := listFiles(localDir, matcher)
+	if err != nil {
+		return fmt.Errorf(msg.ErrorListFiles, localDir, err)
+	}
+	objects, err := cmd.ListObjects(ctx, bucket.Bucket, bucket.Prefix)
+	if err != nil {
+		return fmt.Errorf(msg.ErrorListObjects, bucket.Bucket, err)
+	}
+	for name := range objects {
+		if matcher.Ignored(name, false) {
+			delete(objects, name)
+		}
+	}
+
+	noOfWorkers := workers.CalculateOptimal(opts.Workers)
+	if cmd.ObjectETag != nil {
+		cmd.addChecksums(ctx, bucket, localDir, local, objects, noOfWorkers)
+	}
+
+	var plan Plan
+	if upload {
+		plan = NewPlan(local, objects, opts.Delete)
+	} else {
+		plan = NewPlan(objects, local, opts.Delete)
+	}
+
+	if opts.DryRun {
+		planned := msg.PlannedDownload
+		if upload {
+			planned = msg.PlannedUpload
+		}
+		for _, name := range plan.Transfer {
+			logger.FInfoFlags(f.IOStreams.Out, fmt.Sprintf(planned, name), f.Format, f.Out)
+		}
+		for _, name := range plan.Delete {
+			logger.FInfoFlags(f.IOStreams.Out, fmt.Sprintf(msg.PlannedDelete, name), f.Format, f.Out)
+		}
+		dryRunOut := output.GeneralOutput{
+			Msg:   fmt.Sprintf(msg.OutputDryRun, len(plan.Transfer), len(plan.Delete), plan.Unchanged),
+			Out:   f.IOStreams.Out,
+			Flags: f.Flags,
+		}
+		return output.Print(&dryRunOut)
+	}
+
+	transfer := "download"
+	if upload {
+		transfer = "upload"
+	}
+	transferFailed := cmd.each(f, plan.Transfer, noOfWorkers, func(name string) error {
+		if upload {
+			return cmd.Upload(ctx, bucket.Bucket, bucket.Key(name), filepath.Join(localDir, filepath.FromSlash(name)))
+		}
+		// names come from the keys of the bucket, so they must not lead out of the local directory
+		localName := filepath.FromSlash(name)
+		if !filepath.IsLocal(localName) {
+			return errors.New(msg.ErrorUnsafeKey)
+		}
+		return cmd.Download(ctx, bucket.Bucket, bucket.Key(name), filepath.Join(localDir, localName), objects[name].ModTime)
+	}, transfer)
+	deleteFailed := cmd.each(f, plan.Delete, noOfWorkers, func(name string) error {
+		if upload {
+			return cmd.DeleteObject(ctx, bucket.Bucket, bucket.Key(name))
+		}
+		return os.Remove(filepath.Join(localDir, filepath.FromSlash(name)))
+	}, "delete")
+
+	summary := fmt.Sprintf(msg.OutputDownload, len(plan.Transfer)-transferFailed, localDir, len(plan.Delete)-deleteFailed, plan.Unchanged)
+	if upload {
+		summary = fmt.Sprintf(msg.OutputUpload, len(plan.Transfer)-transferFailed, bucket, len(plan.Delete)-deleteFailed, plan.Unchanged)
+	}
+	syncOut := output.GeneralOutput{
+		Msg:   summary,
+		Out:   f.IOStreams.Out,
+		Flags: f.Flags,
+	}
+	if err := output.Print(&syncOut); err != nil {
+		return err
+	}
+
+	if failed := transferFailed + deleteFailed; failed > 0 {
+		return fmt.Errorf(msg.ErrorSync, failed)
+	}
+	return nil
+}
+
+// each runs operation on every name, at most noOfWorkers at a time and retrying it while rate limited.
+// It reports the names that failed and returns how many did.
+func (cmd *SyncCmd) each(f *cmdutil.Factory, names []string, noOfWorkers int, operation func(name string) error, verb string) int {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+	limit := make(chan struct{}, noOfWorkers)
+	for _, name := range names {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(name string) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			err := workers.RetryRateLimited(func() error {
+				return operation(name)
+			})
+			if err == nil {
+				return
+			}
+			logger.Debug("Error while syncing a file", zap.String("name", name), zap.Error(err))
+			mu.Lock()
+			defer mu.Unlock()
+			failed++
+			logger.FInfoFlags(f.IOStreams.Out, fmt.Sprintf(msg.OperationFailed, verb, name, err.Error()), f.Format, f.Out)
+		}(name)
+	}
+	wg.Wait()
+	return failed
+}
+
+// addChecksums sets the MD5 of the files found with the same size in the local directory and in the bucket, so
+// that NewPlan compares their contents. Objects uploaded in parts have no MD5 as ETag, and files whose object
+// cannot be read are left to be compared by modification time.
+func (cmd *SyncCmd) addChecksums(ctx context.Context, bucket remote, localDir string, local, objects map[string]Entry, noOfWorkers int) {
+	names := []string{}
+	for name, object := range objects {
+		if file, ok := local[name]; ok && file.Size == object.Size {
+			names = append(names, name)
+		}
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	limit := make(chan struct{}, noOfWorkers)
+	for _, name := range names {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(name string) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			etag, err := cmd.ObjectETag(ctx, bucket.Bucket, bucket.Key(name))
+			if err != nil {
+				logger.Debug("Error while reading the ETag of an object", zap.String("name", name), zap.Error(err))
+				return
+			}
+			etag = strings.ToLower(etag)
+			if _, err := hex.DecodeString(etag); err != nil || len(etag) != 2*md5.Size {
+				return
+			}
+			sum, err := fileMD5(filepath.Join(localDir, filepath.FromSlash(name)))
+			if err != nil {
+				logger.Debug("Error while reading a local file", zap.String("name", name), zap.Error(err))
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			file, object := local[name], objects[name]
+			file.MD5, object.MD5 = sum, etag
+			local[name], objects[name] = file, object
+		}(name)
+	}
+	wg.Wait()
+}
+
+// fileMD5 returns the hex digest of the content of a file
+func fileMD5(localPath string) (string, error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := md5.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// parseRemote splits <bucket>/<prefix> into the bucket and the prefix, without leading or trailing slashes
+func parseRemote(arg string) (remote, error) {
+	bucket, prefix, _ := strings.Cut(strings.Trim(arg, "/"), "/")
+	if bucket == "" {
+		return remote{}, fmt.Errorf(msg.ErrorRemote, arg)
+	}
+	return remote{Bucket: bucket, Prefix: strings.Trim(prefix, "/")}, nil
+}
+
+// listFiles walks the local directory, which may not exist yet when downloading. Symlinks and the files
+// left out by the matcher are skipped.
+func listFiles(dir string, matcher *ignore.Matcher) (map[string]Entry, error) {
+	files := map[string]Entry{}
+	err := filepath.WalkDir(dir, func(pathDir string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if pathDir == dir && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			logger.Debug("Skipping symlink file", zap.String("path", pathDir))
+			return nil
+		}
+		name, err := filepath.Rel(dir, pathDir)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		if matcher.Ignored(name, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files[name] = Entry{Size: info.Size(), ModTime: info.ModTime()}
+		return nil
+	})
+	return files, err
+}
+
+// listObjects lists the objects of the bucket under the prefix, by their key relative to it
+func listObjects(ctx context.Context, client *api.Client, bucket, prefix string) (map[string]Entry, error) {
+	keyPrefix := ""
+	if prefix != "" {
+		keyPrefix = prefix + "/"
+	}
+	objects := map[string]Entry{}
+	options := &contracts.ListOptions{}
+	for {
+		resp, err := client.ListObject(ctx, bucket, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range resp.Results {
+			key := object.GetKey()
+			if !strings.HasPrefix(key, keyPrefix) || strings.HasSuffix(key, "/") {
+				continue
+			}
+			objects[strings.TrimPrefix(key, keyPrefix)] = Entry{Size: int64(object.GetSize()), ModTime: object.GetLastModified()}
+		}
+		if resp.GetContinuationToken() == "" {
+			break
+		}
+		options.ContinuationToken = resp.GetContinuationToken()
+	}
+	return objects, nil
+}
+
+// writeFile replaces a local file with the content of an object, keeping the time the object was stored as
+// the modification time, so the next sync finds them equal
+func writeFile(localPath string, content io.Reader, modTime time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(localPath), os.ModePerm); err != nil {
+		return err
+	}
+	temp, err := os.CreateTemp(filepath.Dir(localPath), ".azion-sync-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	if _, err := io.Copy(temp, content); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	// temporary files are only readable by their owner
+	if err := os.Chmod(temp.Name(), 0644); err != nil {
+		return err
+	}
+	if err := os.Chtimes(temp.Name(), modTime, modTime); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), localPath)
+}
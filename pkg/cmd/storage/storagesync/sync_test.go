@@ -0,0 +1,237 @@
+package storagesync
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string, modTime time.Time) {
+	for name, content := range files {
+		localPath := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(localPath), os.ModePerm))
+		require.NoError(t, os.WriteFile(localPath, []byte(content), 0644))
+		require.NoError(t, os.Chtimes(localPath, modTime, modTime))
+	}
+}
+
+// recorder keeps the keys each operation was called with
+type recorder struct {
+	mu   sync.Mutex
+	keys map[string][]string
+}
+
+func (r *recorder) add(operation, key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.keys == nil {
+		r.keys = map[string][]string{}
+	}
+	r.keys[operation] = append(r.keys[operation], key)
+}
+
+func TestSync(t *testing.T) {
+	stored := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	objects := map[string]Entry{
+		"index.html": {Size: 5, ModTime: stored},
+		"old.html":   {Size: 5, ModTime: stored},
+		"app.js.map": {Size: 5, ModTime: stored},
+	}
+
+	newCmd := func(t *testing.T, calls *recorder) *SyncCmd {
+		f, _, _ := testutils.NewFactory(nil)
+		syncCmd := NewSyncCmd(f)
+		syncCmd.ListObjects = func(ctx context.Context, bucket, prefix string) (map[string]Entry, error) {
+			require.Equal(t, "assets", bucket)
+			require.Equal(t, "v1/static", prefix)
+			listed := map[string]Entry{}
+			for name, entry := range objects {
+				listed[name] = entry
+			}
+			return listed, nil
+		}
+		syncCmd.Upload = func(ctx context.Context, bucket, key, localPath string) error {
+			require.FileExists(t, localPath)
+			calls.add("upload", key)
+			return nil
+		}
+		syncCmd.Download = func(ctx context.Context, bucket, key, localPath string, modTime time.Time) error {
+			calls.add("download", key)
+			return writeFile(localPath, strings.NewReader("hello"), modTime)
+		}
+		syncCmd.DeleteObject = func(ctx context.Context, bucket, key string) error {
+			calls.add("delete", key)
+			return nil
+		}
+		// every object holds "hello"
+		syncCmd.ObjectETag = func(ctx context.Context, bucket, key string) (string, error) {
+			sum := md5.Sum([]byte("hello"))
+			return `"` + hex.EncodeToString(sum[:]) + `"`, nil
+		}
+		return syncCmd
+	}
+
+	t.Run("upload only the files that changed", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"index.html": "hello", "js/app.js": "console.log()"}, stored.Add(-time.Hour))
+		f, stdout, _ := testutils.NewFactory(nil)
+		calls := &recorder{}
+
+		cmd := NewCobraCmd(newCmd(t, calls), f)
+		cmd.SetArgs([]string{dir, "assets/v1/static/", "--delete", "--exclude", "*.map"})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, []string{"v1/static/js/app.js"}, calls.keys["upload"])
+		require.Equal(t, []string{"v1/static/old.html"}, calls.keys["delete"])
+		require.Contains(t, stdout.String(), "Uploaded 1 files to assets/v1/static, deleted 1 and left 1 unchanged")
+	})
+
+	t.Run("download to a new directory", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "public")
+		f, stdout, _ := testutils.NewFactory(nil)
+		calls := &recorder{}
+
+		cmd := NewCobraCmd(newCmd(t, calls), f)
+		cmd.SetArgs([]string{"assets/v1/static", dir, "--include", "*.html"})
+		require.NoError(t, cmd.Execute())
+		require.ElementsMatch(t, []string{"v1/static/index.html", "v1/static/old.html"}, calls.keys["download"])
+		require.Contains(t, stdout.String(), "Downloaded 2 files to "+dir+", deleted 0 and left 0 unchanged")
+
+		info, err := os.Stat(filepath.Join(dir, "index.html"))
+		require.NoError(t, err)
+		require.True(t, info.ModTime().Equal(stored))
+
+		// the files downloaded keep the time of the objects, so nothing is transferred again
+		calls = &recorder{}
+		cmd = NewCobraCmd(newCmd(t, calls), f)
+		cmd.SetArgs([]string{"assets/v1/static", dir, "--include", "*.html"})
+		require.NoError(t, cmd.Execute())
+		require.Empty(t, calls.keys)
+	})
+
+	t.Run("dry run lists the changes without making them", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"index.html": "hello!", "extra.txt": "x"}, stored)
+		f, stdout, _ := testutils.NewFactory(nil)
+		calls := &recorder{}
+
+		cmd := NewCobraCmd(newCmd(t, calls), f)
+		cmd.SetArgs([]string{"assets/v1/static", dir, "--delete", "--dry-run"})
+		require.NoError(t, cmd.Execute())
+		require.Empty(t, calls.keys)
+		require.Equal(t, "download app.js.map\ndownload index.html\ndownload old.html\ndelete extra.txt\n3 files would be transferred and 1 deleted, 0 unchanged\n", stdout.String())
+		require.FileExists(t, filepath.Join(dir, "extra.txt"))
+	})
+
+	t.Run("failed transfers are reported", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"index.html": "HELLO", "about.html": "about"}, stored.Add(time.Hour))
+		f, stdout, _ := testutils.NewFactory(nil)
+		syncCmd := newCmd(t, &recorder{})
+		syncCmd.Upload = func(ctx context.Context, bucket, key, localPath string) error {
+			if key == "v1/static/about.html" {
+				return errors.New("Internal Server Error")
+			}
+			return nil
+		}
+
+		cmd := NewCobraCmd(syncCmd, f)
+		cmd.SetArgs([]string{dir, "assets/v1/static"})
+		require.ErrorContains(t, cmd.Execute(), "Failed to sync 1 files")
+		require.Contains(t, stdout.String(), "Failed to upload 'about.html': Internal Server Error")
+		require.Contains(t, stdout.String(), "Uploaded 1 files to assets/v1/static, deleted 0 and left 0 unchanged")
+	})
+
+	t.Run("files of the same size are compared by content", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"index.html": "hello"}, stored.Add(time.Hour))
+		writeFiles(t, dir, map[string]string{"old.html": "HELLO", "app.js.map": "HELLO"}, stored.Add(-time.Hour))
+		f, stdout, _ := testutils.NewFactory(nil)
+		calls := &recorder{}
+		syncCmd := newCmd(t, calls)
+		syncCmd.ObjectETag = func(ctx context.Context, bucket, key string) (string, error) {
+			switch key {
+			case "v1/static/app.js.map":
+				// objects uploaded in parts carry no MD5, so they are compared by modification time
+				return "d41d8cd98f00b204e9800998ecf8427e-2", nil
+			case "v1/static/old.html":
+				return "", errors.New("Forbidden")
+			}
+			sum := md5.Sum([]byte("hello"))
+			return hex.EncodeToString(sum[:]), nil
+		}
+
+		cmd := NewCobraCmd(syncCmd, f)
+		cmd.SetArgs([]string{dir, "assets/v1/static"})
+		require.NoError(t, cmd.Execute())
+		require.Empty(t, calls.keys)
+		require.Contains(t, stdout.String(), "Uploaded 0 files to assets/v1/static, deleted 0 and left 3 unchanged")
+
+		writeFiles(t, dir, map[string]string{"index.html": "HELLO"}, stored.Add(-time.Hour))
+		cmd = NewCobraCmd(syncCmd, f)
+		cmd.SetArgs([]string{dir, "assets/v1/static"})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, []string{"v1/static/index.html"}, calls.keys["upload"])
+	})
+
+	t.Run("keys out of the local directory are not downloaded", func(t *testing.T) {
+		root := t.TempDir()
+		dir := filepath.Join(root, "public")
+		f, stdout, _ := testutils.NewFactory(nil)
+		calls := &recorder{}
+		syncCmd := newCmd(t, calls)
+		syncCmd.ListObjects = func(ctx context.Context, bucket, prefix string) (map[string]Entry, error) {
+			return map[string]Entry{
+				"../evil.html": {Size: 5, ModTime: stored},
+				"index.html":   {Size: 5, ModTime: stored},
+			}, nil
+		}
+
+		cmd := NewCobraCmd(syncCmd, f)
+		cmd.SetArgs([]string{"assets/v1/static", dir})
+		require.ErrorContains(t, cmd.Execute(), "Failed to sync 1 files")
+		require.Equal(t, []string{"v1/static/index.html"}, calls.keys["download"])
+		require.Contains(t, stdout.String(), "Failed to download '../evil.html': the key is not a relative path inside the destination directory")
+		require.NoFileExists(t, filepath.Join(root, "evil.html"))
+		require.FileExists(t, filepath.Join(dir, "index.html"))
+	})
+
+	t.Run("source files must be directories", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"index.html": "hello"}, stored)
+		f, _, _ := testutils.NewFactory(nil)
+
+		cmd := NewCobraCmd(newCmd(t, &recorder{}), f)
+		cmd.SetArgs([]string{filepath.Join(dir, "index.html"), "assets"})
+		require.ErrorContains(t, cmd.Execute(), "is not a directory")
+	})
+}
+
+func TestParseRemote(t *testing.T) {
+	parsed, err := parseRemote("/assets/v1/static/")
+	require.NoError(t, err)
+	require.Equal(t, remote{Bucket: "assets", Prefix: "v1/static"}, parsed)
+	require.Equal(t, "v1/static/index.html", parsed.Key("index.html"))
+
+	parsed, err = parseRemote("assets")
+	require.NoError(t, err)
+	require.Equal(t, "index.html", parsed.Key("index.html"))
+
+	_, err = parseRemote("/")
+	require.ErrorContains(t, err, "Failed to find the bucket of '/'")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/aziontech/azion-cli/pkg/iostreams"
 	"github.com/aziontech/azion-cli/pkg/logger"
 	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/state"
 	"github.com/aziontech/azion-cli/utils"
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
@@ -34,11 +35,13 @@ type SyncCmd struct {
 }
 
 func NewSyncCmd(f *cmdutil.Factory) *SyncCmd {
+	store := state.New(f)
+	store.Local.WriteAzionJsonContent = utils.WriteAzionJsonContentPreserveOrder
 	return &SyncCmd{
 		F:                     f,
 		Io:                    f.IOStreams,
-		GetAzionJsonContent:   utils.GetAzionJsonContent,
-		WriteAzionJsonContent: utils.WriteAzionJsonContentPreserveOrder,
+		GetAzionJsonContent:   store.Read,
+		WriteAzionJsonContent: store.Write,
 		SyncResources:         SyncLocalResources,
 		ReadEnv:               godotenv.Read,
 		WriteManifest:         WriteManifest,
@@ -77,6 +80,12 @@ func NewCmd(f *cmdutil.Factory) *cobra.Command {
 
 func Run(cmdFac *SyncCmd) error {
 	logger.Debug("Running sync command")
+	lock, err := state.Acquire(ProjectConf, "sync")
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
 	conf, err := cmdFac.GetAzionJsonContent(ProjectConf)
 	if err != nil {
 		logger.Debug("Failed to get Azion JSON content", zap.Error(err))
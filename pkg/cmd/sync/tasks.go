@@ -182,7 +182,7 @@ func (synch *SyncCmd) syncCache(info contracts.SyncOpts, f *cmdutil.Factory, man
 	}
 
 	info.Conf.CacheSettings = cacheAzion
-	err = utils.WriteAzionJsonContentPreserveOrder(info.Conf, ProjectConf)
+	err = synch.WriteAzionJsonContent(info.Conf, ProjectConf)
 	if err != nil {
 		logger.Debug("Error while writing azion.json file", zap.Error(err))
 		return remoteCacheIds, err
@@ -327,7 +327,7 @@ func (synch *SyncCmd) syncRules(info contracts.SyncOpts, f *cmdutil.Factory, man
 
 	// Update the configuration with all rules
 	info.Conf.RulesEngine.Rules = rulesAzion
-	err = utils.WriteAzionJsonContentPreserveOrder(info.Conf, ProjectConf)
+	err = synch.WriteAzionJsonContent(info.Conf, ProjectConf)
 	if err != nil {
 		logger.Debug("Error while writing azion.json file", zap.Error(err))
 		return err
@@ -0,0 +1,94 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/update/kv"
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/iostreams"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type Fields struct {
+	Namespace string
+	Name      string
+}
+
+type UpdateCmd struct {
+	Io       *iostreams.IOStreams
+	AskInput func(string) (string, error)
+	Update   func(ctx context.Context, namespace string, req api.UpdateRequest) error
+}
+
+func NewUpdateCmd(f *cmdutil.Factory) *UpdateCmd {
+	return &UpdateCmd{
+		Io: f.IOStreams,
+		AskInput: func(prompt string) (string, error) {
+			return utils.AskInput(prompt)
+		},
+		Update: func(ctx context.Context, namespace string, req api.UpdateRequest) error {
+			client := api.NewClient(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
+			_, err := client.Update(ctx, namespace, req)
+			return err
+		},
+	}
+}
+
+func NewCobraCmd(update *UpdateCmd, f *cmdutil.Factory) *cobra.Command {
+	fields := &Fields{}
+
+	cobraCmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+		$ azion update kv --namespace "my-namespace" --name "my-new-namespace"
+		`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !cmd.Flags().Changed("name") {
+				return msg.ErrorNoChanges
+			}
+			if !cmd.Flags().Changed("namespace") {
+				answer, err := update.AskInput(msg.AskInputNamespace)
+				if err != nil {
+					logger.Debug("Error while parsing answer", zap.Error(err))
+					return utils.ErrorParseResponse
+				}
+				fields.Namespace = answer
+			}
+
+			request := api.UpdateRequest{}
+			request.SetName(fields.Name)
+
+			if err := update.Update(context.Background(), fields.Namespace, request); err != nil {
+				return fmt.Errorf(msg.ErrorUpdateNamespace, err.Error())
+			}
+
+			updateOut := output.GeneralOutput{
+				Msg:   fmt.Sprintf(msg.UpdateOutputSuccess, fields.Name),
+				Out:   f.IOStreams.Out,
+				Flags: f.Flags,
+			}
+			return output.Print(&updateOut)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&fields.Namespace, "namespace", "", msg.FlagNamespace)
+	cobraCmd.Flags().StringVar(&fields.Name, "name", "", msg.FlagName)
+	cobraCmd.Flags().BoolP("help", "h", false, msg.HelpFlag)
+
+	return cobraCmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewUpdateCmd(f), f)
+}
@@ -0,0 +1,86 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	msg "github.com/aziontech/azion-cli/messages/update/kv"
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestUpdate(t *testing.T) {
+	logger.New(zapcore.DebugLevel)
+
+	tests := []struct {
+		name           string
+		args           []string
+		askInput       func(string) (string, error)
+		updateErr      error
+		expectedOutput string
+		expectedErr    string
+	}{
+		{
+			name:           "rename namespace",
+			args:           []string{"--namespace", "my-namespace", "--name", "new-namespace"},
+			expectedOutput: fmt.Sprintf(msg.UpdateOutputSuccess, "new-namespace"),
+		},
+		{
+			name: "ask for the namespace",
+			args: []string{"--name", "new-namespace"},
+			askInput: func(string) (string, error) {
+				return "my-namespace", nil
+			},
+			expectedOutput: fmt.Sprintf(msg.UpdateOutputSuccess, "new-namespace"),
+		},
+		{
+			name:        "no changes",
+			args:        []string{"--namespace", "my-namespace"},
+			expectedErr: msg.ErrorNoChanges.Error(),
+		},
+		{
+			name: "error in input",
+			args: []string{"--name", "new-namespace"},
+			askInput: func(string) (string, error) {
+				return "", errors.New("interrupted")
+			},
+			expectedErr: utils.ErrorParseResponse.Error(),
+		},
+		{
+			name:        "namespace not found",
+			args:        []string{"--namespace", "my-namespace", "--name", "new-namespace"},
+			updateErr:   utils.ErrorNotFound404,
+			expectedErr: fmt.Sprintf(msg.ErrorUpdateNamespace, utils.ErrorNotFound404.Error()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, stdout, _ := testutils.NewFactory(nil)
+
+			updateCmd := NewUpdateCmd(f)
+			updateCmd.AskInput = tt.askInput
+			updateCmd.Update = func(ctx context.Context, namespace string, req api.UpdateRequest) error {
+				require.Equal(t, "my-namespace", namespace)
+				return tt.updateErr
+			}
+
+			cobraCmd := NewCobraCmd(updateCmd, f)
+			cobraCmd.SetArgs(tt.args)
+
+			err := cobraCmd.Execute()
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedOutput, stdout.String())
+		})
+	}
+}
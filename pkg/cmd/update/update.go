@@ -22,6 +22,7 @@ import (
 	wafExceptions "github.com/aziontech/azion-cli/pkg/cmd/update/waf_exceptions"
 	workloads "github.com/aziontech/azion-cli/pkg/cmd/update/workloads"
 
+	kv "github.com/aziontech/azion-cli/pkg/cmd/update/kv"
 	"github.com/aziontech/azion-cli/pkg/cmdutil"
 	"github.com/spf13/cobra"
 )
@@ -61,6 +62,7 @@ func NewCmd(f *cmdutil.Factory) *cobra.Command {
 	cmd.AddCommand(wafExceptions.NewCmd(f))
 	cmd.AddCommand(digitalcertificate.NewCmd(f))
 	cmd.AddCommand(crl.NewCmd(f))
+	cmd.AddCommand(kv.NewCmd(f))
 
 	cmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
 	return cmd
@@ -0,0 +1,116 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	msg "github.com/aziontech/azion-cli/messages/versions/list"
+	api "github.com/aziontech/azion-cli/pkg/api/storage"
+	deploy "github.com/aziontech/azion-cli/pkg/cmd/deploy_remote"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/output"
+	"github.com/aziontech/azion-cli/pkg/state"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type ListCmd struct {
+	F                   *cmdutil.Factory
+	GetAzionJsonContent func(confPath string) (*contracts.AzionApplicationOptions, error)
+	ListVersions        func(ctx context.Context, bucket string) ([]deploy.Version, error)
+}
+
+func NewListCmd(f *cmdutil.Factory) *ListCmd {
+	client := api.NewClient(f.HttpClient, f.Config.GetString("storage_url"), f.Config.GetString("token"))
+	return &ListCmd{
+		F:                   f,
+		GetAzionJsonContent: state.New(f).Read,
+		ListVersions: func(ctx context.Context, bucket string) ([]deploy.Version, error) {
+			return deploy.ListVersions(ctx, client, bucket)
+		},
+	}
+}
+
+func NewCobraCmd(list *ListCmd) *cobra.Command {
+	var configDir string
+
+	cmd := &cobra.Command{
+		Use:           msg.Usage,
+		Short:         msg.ShortDescription,
+		Long:          msg.LongDescription,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Example: heredoc.Doc(`
+        $ azion versions list
+        $ azion versions list --config-dir azion-staging
+        $ azion versions list --format json
+        `),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(configDir)
+		},
+	}
+
+	cmd.Flags().StringVar(&configDir, "config-dir", "azion", msg.FlagConfigDir)
+	cmd.Flags().BoolP("help", "h", false, msg.FlagHelp)
+
+	return cmd
+}
+
+func NewCmd(f *cmdutil.Factory) *cobra.Command {
+	return NewCobraCmd(NewListCmd(f))
+}
+
+func (cmd *ListCmd) Run(configDir string) error {
+	logger.Debug("Running versions list command")
+
+	conf, err := cmd.GetAzionJsonContent(configDir)
+	if err != nil {
+		logger.Debug("Error while reading azion.json file", zap.Error(err))
+		return err
+	}
+	if conf.Bucket == "" || conf.Prefix == "" {
+		return msg.ErrorNeedsDeploy
+	}
+
+	versions, err := cmd.ListVersions(context.Background(), conf.Bucket)
+	if err != nil {
+		return fmt.Errorf(msg.ErrorListVersions, conf.Bucket, err)
+	}
+
+	listOut := output.ListOutput{}
+	listOut.Columns = []string{"VERSION", "DEPLOYED AT", "FILES", "SIZE", "STATUS"}
+	listOut.Out = cmd.F.IOStreams.Out
+	listOut.Flags = cmd.F.Flags
+
+	for _, version := range versions {
+		deployedAt := version.Prefix
+		if t, err := time.ParseInLocation(deploy.VersionLayout, version.Prefix, time.Local); err == nil {
+			deployedAt = t.Format(time.DateTime)
+		}
+		listOut.Lines = append(listOut.Lines, []string{
+			version.Prefix,
+			deployedAt,
+			strconv.Itoa(version.Files),
+			strconv.FormatInt(version.Size, 10),
+			status(conf, version.Prefix),
+		})
+	}
+	return output.Print(&listOut)
+}
+
+// status tells whether the version is the one served by the storage connectors of the project. During a
+// canary deployment, the current prefix is the new version, receiving part of the traffic.
+func status(conf *contracts.AzionApplicationOptions, prefix string) string {
+	if prefix != conf.Prefix {
+		return ""
+	}
+	if canary := conf.Workloads.Canary; canary != nil {
+		return fmt.Sprintf(msg.StatusCanary, canary.Percentage)
+	}
+	return msg.StatusLive
+}
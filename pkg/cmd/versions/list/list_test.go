@@ -0,0 +1,66 @@
+package list
+
+import (
+	"context"
+	"testing"
+
+	msg "github.com/aziontech/azion-cli/messages/versions/list"
+	deploy "github.com/aziontech/azion-cli/pkg/cmd/deploy_remote"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+func TestList(t *testing.T) {
+	t.Run("lists the versions with the live one", func(t *testing.T) {
+		f, stdout, _ := testutils.NewFactory(nil)
+		listCmd := NewListCmd(f)
+		listCmd.GetAzionJsonContent = func(confPath string) (*contracts.AzionApplicationOptions, error) {
+			require.Equal(t, "azion", confPath)
+			return &contracts.AzionApplicationOptions{Bucket: "bucket", Prefix: "20240102030405"}, nil
+		}
+		listCmd.ListVersions = func(ctx context.Context, bucket string) ([]deploy.Version, error) {
+			require.Equal(t, "bucket", bucket)
+			return []deploy.Version{
+				{Prefix: "20240102030405", Files: 12, Size: 4096},
+				{Prefix: "20240101000000", Files: 10, Size: 2048},
+			}, nil
+		}
+
+		cmd := NewCobraCmd(listCmd)
+		cmd.SetArgs([]string{})
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, stdout.String(), "20240102030405")
+		require.Contains(t, stdout.String(), "2024-01-02 03:04:05")
+		require.Contains(t, stdout.String(), "4096")
+		require.Contains(t, stdout.String(), msg.StatusLive)
+		require.Contains(t, stdout.String(), "20240101000000")
+	})
+
+	t.Run("project not deployed", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(nil)
+		listCmd := NewListCmd(f)
+		listCmd.GetAzionJsonContent = func(confPath string) (*contracts.AzionApplicationOptions, error) {
+			return &contracts.AzionApplicationOptions{}, nil
+		}
+
+		cmd := NewCobraCmd(listCmd)
+		cmd.SetArgs([]string{})
+		require.ErrorIs(t, cmd.Execute(), msg.ErrorNeedsDeploy)
+	})
+}
+
+func TestStatus(t *testing.T) {
+	conf := &contracts.AzionApplicationOptions{Prefix: "20240102030405"}
+	require.Equal(t, msg.StatusLive, status(conf, "20240102030405"))
+	require.Equal(t, "", status(conf, "20240101000000"))
+
+	conf.Workloads.Canary = &contracts.AzionJsonDataCanary{Percentage: 10}
+	require.Equal(t, "canary (10%)", status(conf, "20240102030405"))
+}
@@ -103,5 +103,10 @@ func RunCommandStreamOutput(out io.Writer, envVars []string, comm string) error
 		return fmt.Errorf(utils.ErrorRunningCommandStream.Error(), err)
 	}
 
+	// wait for the command to exit, so that a failure is reported
+	if err := command.Wait(); err != nil {
+		return fmt.Errorf(utils.ErrorRunningCommandStream.Error(), err)
+	}
+
 	return nil
 }
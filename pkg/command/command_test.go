@@ -140,6 +140,13 @@ func TestRunCommandStreamOutput(t *testing.T) {
 			wantOutput: "bar\n",
 			wantErr:    false,
 		},
+		{
+			name:       "Failing command",
+			envVars:    nil,
+			comm:       "echo 'failed' && exit 3",
+			wantOutput: "failed\n",
+			wantErr:    true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -13,6 +13,15 @@ type FileOps struct {
 	MimeType    string
 	FileContent *os.File
 	VersionID   string
+	// CopySource is the key of an object of the bucket with the same content, copied instead of uploading FileContent
+	CopySource string
+	// LocalPath is the file on disk, opened only when it is transferred if FileContent is not set
+	LocalPath string
+	// Compression is the encoding the file is compressed with before it is transferred
+	Compression     string
+	ContentEncoding string
+	CacheControl    string
+	Metadata        map[string]string
 }
 
 type BuildInfo struct {
@@ -61,6 +70,7 @@ type AzionApplicationOptions struct {
 	NotFirstRun   bool                         `json:"not-first-run,omitempty"`
 	Function      []AzionJsonDataFunction      `json:"function"`
 	Application   AzionJsonDataApplication     `json:"application"`
+	Applications  []AzionJsonDataApplications  `json:"applications,omitempty"`
 	Domain        AzionJsonDataDomain          `json:"domain"`
 	RtPurge       AzionJsonDataPurge           `json:"rt-purge"`
 	Origin        []AzionJsonDataOrigin        `json:"origin"`
@@ -69,6 +79,45 @@ type AzionApplicationOptions struct {
 	Workloads     AzionJsonDataWorkload        `json:"workloads"`
 	Connectors    []AzionJsonDataConnectors    `json:"connectors"`
 	Firewalls     []AzionJsonDataFirewall      `json:"firewalls,omitempty"`
+	KV            []AzionJsonDataKV            `json:"kv,omitempty"`
+	State         *AzionJsonState              `json:"state,omitempty"`
+	Hooks         *AzionJsonHooks              `json:"hooks,omitempty"`
+	HealthCheck   *AzionJsonHealthCheck        `json:"health-check,omitempty"`
+}
+
+// AzionJsonHooks lists the commands run at each stage of a deploy, declared in azion.json or in azion.config,
+// which writes them to the manifest. A failing command aborts the deploy, except for post-deploy ones, which
+// may roll a local deploy back instead.
+type AzionJsonHooks struct {
+	PreBuild   []string `json:"pre-build,omitempty"`
+	PostBuild  []string `json:"post-build,omitempty"`
+	PreUpload  []string `json:"pre-upload,omitempty"`
+	PostUpload []string `json:"post-upload,omitempty"`
+	PostDeploy []string `json:"post-deploy,omitempty"`
+	// RollbackOnFailure rolls the deploy back to the previous version when a post-deploy command fails
+	RollbackOnFailure bool `json:"rollback-on-failure,omitempty"`
+}
+
+// AzionJsonHealthCheck describes the requests that must succeed against the workload after a local deploy.
+// Zero values fall back to the defaults of the deploy command.
+type AzionJsonHealthCheck struct {
+	Paths  []string `json:"paths,omitempty"`
+	Status int      `json:"status,omitempty"`
+	// Body is a substring expected in the response
+	Body    string `json:"body,omitempty"`
+	Retries int    `json:"retries,omitempty"`
+	// Interval and Timeout are in seconds
+	Interval int `json:"interval,omitempty"`
+	Timeout  int `json:"timeout,omitempty"`
+	// RollbackOnFailure rolls the deploy back to the previous version when the check fails, which is the default
+	RollbackOnFailure *bool `json:"rollback-on-failure,omitempty"`
+}
+
+// AzionJsonState tells where the azion.json of a project is kept. Without it, the local file is used.
+type AzionJsonState struct {
+	Backend string `json:"backend"` // local or storage
+	Bucket  string `json:"bucket,omitempty"`
+	Key     string `json:"key,omitempty"`
 }
 
 type AzionApplicationOptionsV3 struct {
@@ -96,6 +145,11 @@ type AzionJsonDataConnectors struct {
 	Address []edgesdk.Address `json:"address,omitempty"`
 }
 
+// AzionJsonDataKV is a KV namespace applied from the manifest. Namespaces are identified by their name.
+type AzionJsonDataKV struct {
+	Name string `json:"name"`
+}
+
 type Results struct {
 	Result Result `json:"result"`
 }
@@ -148,13 +202,14 @@ type AzionApplicationSimple struct {
 }
 
 type AzionJsonDataFunction struct {
-	ID           int64  `json:"id"`
-	Name         string `json:"name"`
-	File         string `json:"file"`
-	Args         string `json:"args"`
-	InstanceID   int64  `json:"instance-id"`
-	InstanceName string `json:"instance-name"`
-	CacheId      int64  `json:"cache-id"`
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	File          string `json:"file"`
+	Args          string `json:"args"`
+	InstanceID    int64  `json:"instance-id"`
+	InstanceName  string `json:"instance-name"`
+	CacheId       int64  `json:"cache-id"`
+	ApplicationID int64  `json:"application-id,omitempty"`
 }
 
 type AzionJsonDataApplication struct {
@@ -162,6 +217,15 @@ type AzionJsonDataApplication struct {
 	Name string `json:"name"`
 }
 
+// AzionJsonDataApplications represents an application declared in the manifest along with
+// the resources scoped to it. Entries are keyed by the application name.
+type AzionJsonDataApplications struct {
+	ID            int64                        `json:"id"`
+	Name          string                       `json:"name"`
+	RulesEngine   AzionJsonDataRulesEngine     `json:"rules-engine"`
+	CacheSettings []AzionJsonDataCacheSettings `json:"cache-settings"`
+}
+
 type AzionJsonDataOrigin struct {
 	OriginId  int64    `json:"origin-id"`
 	OriginKey string   `json:"origin-key"`
@@ -182,6 +246,8 @@ type AzionJsonDataWorkload struct {
 	Domains     []string      `json:"domains"`
 	Url         string        `json:"url"`
 	Deployments []Deployments `json:"deployment_id"`
+	// Canary is the rollout of a version deployed with --canary, until it is promoted or aborted
+	Canary *AzionJsonDataCanary `json:"canary,omitempty"`
 }
 
 type Deployments struct {
@@ -189,6 +255,19 @@ type Deployments struct {
 	Name string `json:"name"`
 }
 
+// AzionJsonDataCanary tracks a new version receiving part of the traffic of the workload through its own
+// deployment, along with the copies of the project resources created to serve it
+type AzionJsonDataCanary struct {
+	DeploymentId   int64                       `json:"deployment-id"`
+	DeploymentName string                      `json:"deployment-name"`
+	Percentage     int64                       `json:"percentage"`
+	Prefix         string                      `json:"prefix"`
+	PreviousPrefix string                      `json:"previous-prefix,omitempty"`
+	Applications   []AzionJsonDataApplications `json:"applications,omitempty"`
+	Function       []AzionJsonDataFunction     `json:"function,omitempty"`
+	Connectors     []AzionJsonDataConnectors   `json:"connectors,omitempty"`
+}
+
 type AzionJsonDataPurge struct {
 	PurgeOnPublish bool `json:"purge_on_publish"`
 }
@@ -255,6 +334,7 @@ type MemoryFS struct {
 type ManifestV4 struct {
 	Build               Build                      `json:"build"`
 	Storage             []StorageManifest          `json:"storage"`
+	KV                  []KVManifest               `json:"kv,omitempty"`
 	Functions           []Function                 `json:"functions"`
 	Applications        []Applications             `json:"applications"`
 	Connectors          []edgesdk.ConnectorRequest `json:"connectors"`
@@ -262,6 +342,7 @@ type ManifestV4 struct {
 	WorkloadDeployments []WorkloadDeployment       `json:"workload_deployments,omitempty"`
 	Firewalls           []FirewallManifest         `json:"firewall,omitempty"`
 	Purge               []PurgeManifest            `json:"purge"`
+	Hooks               *AzionJsonHooks            `json:"hooks,omitempty"`
 }
 
 type FirewallManifest struct {
@@ -542,6 +623,31 @@ type StorageManifest struct {
 	WorkloadsAccess string `json:"workloads_access"` // read_write, read_only, etc.
 	Dir             string `json:"dir"`              // Directory path
 	Prefix          string `json:"prefix"`
+	// Compression lists the encodings, br and gzip, of the variants uploaded along with each text asset
+	Compression []string `json:"compression,omitempty"`
+	// Objects sets the headers and metadata of the objects whose path matches a glob pattern
+	Objects []StorageObjectSettings `json:"objects,omitempty"`
+	// Include and Exclude are globs, in the syntax of .gitignore, selecting the files of Dir that are uploaded
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+	// KeepVersions is how many deployed versions are kept in the bucket, removing older ones after each deploy
+	KeepVersions int `json:"keep_versions,omitempty"`
+}
+
+// StorageObjectSettings sets how the files matching Pattern are stored. Patterns without a slash match the
+// name of the file in any directory, and later entries override the settings of earlier ones.
+type StorageObjectSettings struct {
+	Pattern         string            `json:"pattern"`
+	CacheControl    string            `json:"cache_control,omitempty"`
+	ContentEncoding string            `json:"content_encoding,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+}
+
+// KVManifest represents a KV namespace entry in the manifest.json file
+type KVManifest struct {
+	Name string `json:"name"`
+	// Data is a JSON, NDJSON or CSV file whose records are written to the namespace when it is created
+	Data string `json:"data,omitempty"`
 }
 
 type Applications struct {
@@ -559,8 +665,13 @@ type StorageBinding struct {
 	Prefix string `json:"prefix,omitempty"`
 }
 
+type KVBinding struct {
+	Namespace string `json:"namespace,omitempty"`
+}
+
 type FunctionBindings struct {
 	Storage StorageBinding `json:"storage,omitempty"`
+	KV      KVBinding      `json:"kv,omitempty"`
 }
 
 type Function struct {
@@ -6,7 +6,6 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
-	"strings"
 
 	msg "github.com/aziontech/azion-cli/messages/dryrun"
 	"github.com/aziontech/azion-cli/pkg/cmd/build"
@@ -20,8 +19,6 @@ import (
 	"go.uber.org/zap"
 )
 
-//TODO: FIX EVERYTHING
-
 type DryrunStruct struct {
 	Io                    *iostreams.IOStreams
 	GetWorkDir            func() (string, error)
@@ -123,155 +120,31 @@ func (dry *DryrunStruct) SimulateDeploy(workingDir, projConf string) error {
 		logger.FInfoFlags(dry.Io.Out, msg.SkipManifest, dry.F.Format, dry.F.Out)
 		msgs = append(msgs, msg.SkipManifest)
 	} else if !skipManifest {
-		// Initialize maps to track resources
-		manifestInt.CacheIds = make(map[string]int64)
-		manifestInt.CacheIdsBackup = make(map[string]int64)
-		manifestInt.RuleIds = make(map[string]contracts.RuleIdsStruct)
-		manifestInt.ConnectorIds = make(map[string]int64)
-		manifestInt.DeploymentIds = make(map[string]int64)
-		manifestInt.FunctionIds = make(map[string]contracts.AzionJsonDataFunction)
-
-		// Local maps for origin tracking (not in manifest package)
-		OriginKeys := make(map[string]string)
-		OriginIds := make(map[string]int64)
-
-		for _, cacheConf := range conf.CacheSettings {
-			manifestInt.CacheIds[cacheConf.Name] = cacheConf.Id
-		}
-
-		for _, ruleConf := range conf.RulesEngine.Rules {
-			manifestInt.RuleIds[ruleConf.Name] = contracts.RuleIdsStruct{
-				Id:    ruleConf.Id,
-				Phase: ruleConf.Phase,
-			}
-		}
-
-		for _, funcConf := range conf.Function {
-			manifestInt.FunctionIds[funcConf.Name] = funcConf
-		}
-
-		for _, deploymentConf := range conf.Workloads.Deployments {
-			manifestInt.DeploymentIds[deploymentConf.Name] = deploymentConf.Id
-		}
-
-		for _, originConf := range conf.Origin {
-			OriginKeys[originConf.Name] = originConf.OriginKey
-			OriginIds[originConf.Name] = originConf.OriginId
-			// Also initialize ConnectorIds with the same data
-			manifestInt.ConnectorIds[originConf.Name] = originConf.OriginId
-		}
-
 		if len(manifestStructure.Workloads) > 0 && manifestStructure.Workloads[0].Name != "" {
 			skip = true
-			logger.Debug("", zap.Any("Workload Payload", manifestStructure.Workloads))
-			if conf.Domain.Id > 0 {
-				msgf := fmt.Sprintf(msg.UpdateDomain, conf.Domain.Id, manifestStructure.Workloads[0].Name)
-				msgs = append(msgs, msgf)
-				logger.FInfoFlags(dry.Io.Out, msgf, dry.F.Format, dry.F.Out)
-			} else {
-				msgf := fmt.Sprintf(msg.CreateDomain, manifestStructure.Workloads[0].Name)
-				msgs = append(msgs, msgf)
-				logger.FInfoFlags(dry.Io.Out, msgf, dry.F.Format, dry.F.Out)
-			}
 		}
 
-		// for _, connector := range manifestStructure.EdgeConnectors {
-		// 	logger.Debug("", zap.Any("Edge Connector Payload", connector))
-		// 	if id, ok := manifestInt.ConnectorIds[connector.Name]; ok && id > 0 {
-		// 		msgf := fmt.Sprintf(msg.UpdateOrigin, id, OriginKeys[connector.Name], connector.Name)
-		// 		msgs = append(msgs, msgf)
-		// 		logger.FInfoFlags(dry.Io.Out, msgf, dry.F.Format, dry.F.Out)
-		// 	} else {
-		// 		msgf := fmt.Sprintf(msg.CreateOrigin, connector.Name)
-		// 		msgs = append(msgs, msgf)
-		// 		logger.FInfoFlags(dry.Io.Out, msgf, dry.F.Format, dry.F.Out)
-		// 	}
-		// }
-
-		// for _, app := range manifestStructure.EdgeApplications {
-		// 	for _, cache := range app.Cache {
-		// 		logger.Debug("", zap.Any("Cache Setting Payload", cache))
-		// 		if id, ok := manifestInt.CacheIds[cache.Name]; ok && id > 0 {
-		// 			msgf := fmt.Sprintf(msg.UpdateCacheSetting, id, cache.Name)
-		// 			msgs = append(msgs, msgf)
-		// 			logger.FInfoFlags(dry.Io.Out, msgf, dry.F.Format, dry.F.Out)
-		// 		} else {
-		// 			msgf := fmt.Sprintf(msg.CreateCacheSetting, cache.Name)
-		// 			msgs = append(msgs, msgf)
-		// 			logger.FInfoFlags(dry.Io.Out, msgf, dry.F.Format, dry.F.Out)
-		// 		}
-		// 	}
-		// }
-
-		//backup cache ids
-		for k, v := range manifestInt.CacheIds {
-			manifestInt.CacheIdsBackup[k] = v
+		// the plan is computed from azion.json alone, so it works without reaching the API
+		rc := manifestInt.NewResourceContext(dry.F, conf, manifestStructure, projConf, &msgs, dry.WriteAzionJsonContent)
+		plan, err := rc.Plan(false)
+		if err != nil {
+			logger.Debug("Failed to compute the changes found in the manifest", zap.Error(err))
+			return err
 		}
 
-		// Process rules from manifest
-		// for _, rule := range manifestStructure.Rules {
-		// 	logger.Debug("", zap.Any("Rule Engine Payload", rule))
-		// 	if r, ok := manifestInt.RuleIds[rule.Name]; ok && r.Id > 0 {
-		// 		msgf := fmt.Sprintf(msg.UpdateRule, r.Id, rule.Name)
-		// 		msgs = append(msgs, msgf)
-		// 		logger.FInfoFlags(dry.Io.Out, msgf, dry.F.Format, dry.F.Out)
-		// 		delete(manifestInt.RuleIds, rule.Name)
-		// 		for _, v := range rule.Behaviors {
-		// 			if v.RulesEngineBehaviorString != nil {
-		// 				if v.RulesEngineBehaviorString.Name == "set_cache_policy" {
-		// 					if id, ok := manifestInt.CacheIdsBackup[v.RulesEngineBehaviorString.Target]; ok && id > 0 {
-		// 						delete(manifestInt.CacheIds, v.RulesEngineBehaviorString.Target)
-		// 					}
-		// 				} else if v.RulesEngineBehaviorString.Name == "set_origin" {
-		// 					if id, ok := OriginIds[v.RulesEngineBehaviorString.Target]; ok && id > 0 {
-		// 						delete(OriginKeys, v.RulesEngineBehaviorString.Target)
-		// 					}
-		// 				}
-		// 			}
-		// 		}
-		// 	} else {
-		// 		msgf := fmt.Sprintf(msg.CreateRule, rule.Name)
-		// 		msgs = append(msgs, msgf)
-		// 		logger.FInfoFlags(dry.Io.Out, msgf, dry.F.Format, dry.F.Out)
-		// 		for _, v := range rule.Behaviors {
-		// 			if v.RulesEngineBehaviorString != nil {
-		// 				if v.RulesEngineBehaviorString.Name == "set_cache_policy" {
-		// 					if id, ok := manifestInt.CacheIdsBackup[v.RulesEngineBehaviorString.Target]; ok && id > 0 {
-		// 						delete(manifestInt.CacheIds, v.RulesEngineBehaviorString.Target)
-		// 					}
-		// 				} else if v.RulesEngineBehaviorString.Name == "set_origin" {
-		// 					if id, ok := OriginIds[v.RulesEngineBehaviorString.Target]; ok && id > 0 {
-		// 						delete(OriginKeys, v.RulesEngineBehaviorString.Target)
-		// 					}
-		// 				}
-		// 			}
-		// 		}
-		// 	}
-		// }
-
-		for key, value := range manifestInt.RuleIds {
-			msgf := fmt.Sprintf(msg.DeletingRuleEngine, value.Id, key)
-			msgs = append(msgs, msgf)
-			logger.FInfoFlags(dry.Io.Out, msgf, dry.F.Format, dry.F.Out)
-		}
-
-		for key, value := range OriginKeys {
-			if strings.Contains(key, "_single") {
-				continue
-			}
-			if id, ok := OriginIds[key]; ok {
-				msgf := fmt.Sprintf(msg.DeletingOrigin, id, value, key)
-				msgs = append(msgs, msgf)
-				logger.FInfoFlags(dry.Io.Out, msgf, dry.F.Format, dry.F.Out)
+		for _, change := range plan.Changes {
+			var msgf string
+			switch change.Action {
+			case manifestInt.ActionCreate:
+				msgf = fmt.Sprintf(msg.CreateResource, change.KindName(), change.Name)
+			case manifestInt.ActionUpdate:
+				msgf = fmt.Sprintf(msg.UpdateResource, change.KindName(), change.ID, change.Name)
+			case manifestInt.ActionDelete:
+				msgf = fmt.Sprintf(msg.DeleteResource, change.KindName(), change.ID, change.Name)
 			}
-		}
-
-		for key, value := range manifestInt.CacheIds {
-			msgf := fmt.Sprintf(msg.DeletingCacheSetting, value, key)
 			msgs = append(msgs, msgf)
 			logger.FInfoFlags(dry.Io.Out, msgf, dry.F.Format, dry.F.Out)
 		}
-
 	}
 
 	if !skip {
@@ -0,0 +1,76 @@
+package ignore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aziontech/azion-cli/pkg/logger"
+	gitignore "github.com/sabhiram/go-gitignore"
+	"go.uber.org/zap"
+)
+
+// FileName is the file, at the root of the project, listing the files deploy does not upload.
+// It follows the syntax of .gitignore, with paths relative to the directory being uploaded.
+const FileName = ".azionignore"
+
+// defaultPatterns are files left by operating systems, never uploaded
+var defaultPatterns = []string{".DS_Store", "Thumbs.db"}
+
+// Matcher decides which files of a directory are uploaded
+type Matcher struct {
+	ignore  *gitignore.GitIgnore
+	include *gitignore.GitIgnore
+	exclude *gitignore.GitIgnore
+}
+
+// New reads the .azionignore file of the project in projectDir, if there is one. The include and exclude
+// globs come from the manifest: when include is set only the files matching it are uploaded, and files
+// matching exclude are never uploaded.
+func New(projectDir string, include, exclude []string) (*Matcher, error) {
+	lines := append([]string{}, defaultPatterns...)
+	content, err := os.ReadFile(filepath.Join(projectDir, FileName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		logger.Debug("Reading " + FileName)
+		lines = append(lines, strings.Split(string(content), "\n")...)
+	}
+
+	matcher := &Matcher{ignore: gitignore.CompileIgnoreLines(lines...)}
+	if len(include) > 0 {
+		matcher.include = gitignore.CompileIgnoreLines(include...)
+	}
+	if len(exclude) > 0 {
+		matcher.exclude = gitignore.CompileIgnoreLines(exclude...)
+	}
+	return matcher, nil
+}
+
+// Ignored reports whether a file or directory, with its path relative to the directory being uploaded,
+// is left out of the upload. Files of ignored directories are ignored as well.
+func (m *Matcher) Ignored(relPath string, isDir bool) bool {
+	if m == nil {
+		return false
+	}
+	relPath = strings.TrimPrefix(filepath.ToSlash(relPath), "/")
+	if relPath == "" || relPath == "." {
+		return false
+	}
+	if isDir {
+		relPath += "/"
+	}
+
+	if m.ignore.MatchesPath(relPath) || (m.exclude != nil && m.exclude.MatchesPath(relPath)) {
+		logger.Debug("Ignoring path", zap.String("path", relPath))
+		return true
+	}
+	// directories are walked to look for the files included
+	if !isDir && m.include != nil && !m.include.MatchesPath(relPath) {
+		logger.Debug("Path not included", zap.String("path", relPath))
+		return true
+	}
+	return false
+}
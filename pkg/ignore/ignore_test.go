@@ -0,0 +1,54 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+func TestMatcher(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, FileName), []byte("# source maps\n*.map\ndrafts/\n!keep.map\n"), 0644))
+
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{name: "regular file", path: "/index.html"},
+		{name: "operating system files", path: "/assets/.DS_Store", ignored: true},
+		{name: "pattern of .azionignore", path: "/assets/app.js.map", ignored: true},
+		{name: "negated pattern", path: "/assets/keep.map"},
+		{name: "ignored directory", path: "/drafts", isDir: true, ignored: true},
+		{name: "file of ignored directory", path: "/drafts/post.html", ignored: true},
+		{name: "excluded by the manifest", exclude: []string{"*.txt"}, path: "/robots.txt", ignored: true},
+		{name: "not included by the manifest", include: []string{"assets/**"}, path: "/index.html", ignored: true},
+		{name: "included by the manifest", include: []string{"assets/**"}, path: "/assets/app.js"},
+		{name: "directories are walked for included files", include: []string{"assets/**"}, path: "/images", isDir: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := New(dir, tt.include, tt.exclude)
+			require.NoError(t, err)
+			require.Equal(t, tt.ignored, matcher.Ignored(tt.path, tt.isDir))
+		})
+	}
+
+	t.Run("without .azionignore", func(t *testing.T) {
+		matcher, err := New(t.TempDir(), nil, nil)
+		require.NoError(t, err)
+		require.False(t, matcher.Ignored("/app.js.map", false))
+		require.True(t, matcher.Ignored("/.DS_Store", false))
+	})
+}
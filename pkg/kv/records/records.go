@@ -0,0 +1,192 @@
+// Package records reads and writes the keys of a KV namespace as JSON, NDJSON or CSV files
+package records
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	msg "github.com/aziontech/azion-cli/messages/kv"
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+)
+
+type Format string
+
+const (
+	JSON   Format = "json"
+	NDJSON Format = "ndjson"
+	CSV    Format = "csv"
+)
+
+// Columns are the columns of CSV files, of which only key and value are required
+var Columns = []string{"key", "value", "expiration", "metadata"}
+
+// Record is a key read from a file. Err is set when the record is malformed, so that it is reported
+// without stopping the reading of the remaining records.
+type Record struct {
+	// Line is the line of the record in NDJSON and CSV files, and its position in JSON ones
+	Line int
+	Item api.Item
+	Err  error
+}
+
+// FormatOf returns the format of a file, given explicitly or told by the extension of its name
+func FormatOf(name, format string) (Format, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".json":
+			return JSON, nil
+		case ".ndjson", ".jsonl":
+			return NDJSON, nil
+		case ".csv":
+			return CSV, nil
+		}
+		return "", fmt.Errorf(msg.ErrorUnknownFormat, name)
+	}
+	switch Format(strings.ToLower(format)) {
+	case JSON:
+		return JSON, nil
+	case NDJSON, "jsonl":
+		return NDJSON, nil
+	case CSV:
+		return CSV, nil
+	}
+	return "", fmt.Errorf(msg.ErrorInvalidFormat, format)
+}
+
+// Read streams the records of a file to fn, as they are decoded. It stops only when the file can no longer
+// be read, such as a JSON file that is not an array of records; malformed records are handed to fn instead.
+func Read(r io.Reader, format Format, fn func(Record)) error {
+	switch format {
+	case JSON:
+		return readJSON(r, fn)
+	case NDJSON:
+		return readNDJSON(r, fn)
+	case CSV:
+		return readCSV(r, fn)
+	}
+	return fmt.Errorf(msg.ErrorInvalidFormat, format)
+}
+
+func checked(record Record) Record {
+	if record.Err == nil && record.Item.Key == "" {
+		record.Err = msg.ErrorMissingKey
+	}
+	return record
+}
+
+func readJSON(r io.Reader, fn func(Record)) error {
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return msg.ErrorJSONArray
+	}
+	for position := 1; decoder.More(); position++ {
+		record := Record{Line: position}
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return fmt.Errorf(msg.ErrorReadRecords, err)
+		}
+		record.Err = json.Unmarshal(raw, &record.Item)
+		fn(checked(record))
+	}
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf(msg.ErrorReadRecords, err)
+	}
+	return nil
+}
+
+func readNDJSON(r io.Reader, fn func(Record)) error {
+	// values can be larger than the buffer of a scanner, so lines are read whole
+	reader := bufio.NewReader(r)
+	for number := 1; ; number++ {
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf(msg.ErrorReadRecords, err)
+		}
+		if strings.TrimSpace(line) != "" {
+			record := Record{Line: number}
+			record.Err = json.Unmarshal([]byte(line), &record.Item)
+			fn(checked(record))
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+}
+
+func readCSV(r io.Reader, fn func(Record)) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf(msg.ErrorReadRecords, err)
+	}
+	index := map[string]int{}
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !slices.Contains(Columns, column) {
+			return fmt.Errorf(msg.ErrorCSVColumn, column)
+		}
+		index[column] = i
+	}
+	if _, ok := index["key"]; !ok {
+		return fmt.Errorf(msg.ErrorCSVMissingColumn, "key")
+	}
+	if _, ok := index["value"]; !ok {
+		return fmt.Errorf(msg.ErrorCSVMissingColumn, "value")
+	}
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return fmt.Errorf(msg.ErrorReadRecords, err)
+			}
+			fn(Record{Line: parseErr.Line, Err: err})
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		record := Record{Line: line}
+		record.Item, record.Err = itemFromRow(row, index)
+		fn(checked(record))
+	}
+}
+
+func itemFromRow(row []string, index map[string]int) (api.Item, error) {
+	cell := func(column string) string {
+		if i, ok := index[column]; ok && i < len(row) {
+			return row[i]
+		}
+		return ""
+	}
+
+	item := api.Item{Key: cell("key"), Value: cell("value")}
+	if expiration := strings.TrimSpace(cell("expiration")); expiration != "" {
+		value, err := strconv.ParseInt(expiration, 10, 64)
+		if err != nil {
+			return item, fmt.Errorf(msg.ErrorExpiration, expiration)
+		}
+		item.Expiration = value
+	}
+	if metadata := strings.TrimSpace(cell("metadata")); metadata != "" {
+		if err := json.Unmarshal([]byte(metadata), &item.Metadata); err != nil {
+			return item, fmt.Errorf(msg.ErrorMetadata, err)
+		}
+	}
+	return item, nil
+}
@@ -0,0 +1,132 @@
+package records
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	msg "github.com/aziontech/azion-cli/messages/kv"
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		format string
+		want   Format
+		err    bool
+	}{
+		{name: "json extension", file: "data.json", want: JSON},
+		{name: "ndjson extension", file: "data.ndjson", want: NDJSON},
+		{name: "jsonl extension", file: "data.JSONL", want: NDJSON},
+		{name: "csv extension", file: "data.csv", want: CSV},
+		{name: "explicit format", file: "data.txt", format: "csv", want: CSV},
+		{name: "unknown extension", file: "data.txt", err: true},
+		{name: "invalid format", file: "data.json", format: "xml", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := FormatOf(tt.file, tt.format)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, format)
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	read := func(t *testing.T, format Format, content string) ([]Record, error) {
+		recs := []Record{}
+		err := Read(strings.NewReader(content), format, func(record Record) {
+			recs = append(recs, record)
+		})
+		return recs, err
+	}
+
+	t.Run("json", func(t *testing.T) {
+		recs, err := read(t, JSON, `[
+			{"key": "flag:dark", "value": "on", "metadata": {"owner": "web"}},
+			{"value": "no key"},
+			{"key": "flag:beta", "value": "off", "expiration": 1735689600}
+		]`)
+		require.NoError(t, err)
+		require.Len(t, recs, 3)
+		require.Equal(t, api.Item{Key: "flag:dark", Value: "on", Metadata: map[string]interface{}{"owner": "web"}}, recs[0].Item)
+		require.ErrorIs(t, recs[1].Err, msg.ErrorMissingKey)
+		require.Equal(t, 3, recs[2].Line)
+		require.Equal(t, int64(1735689600), recs[2].Item.Expiration)
+	})
+
+	t.Run("json that is not an array", func(t *testing.T) {
+		_, err := read(t, JSON, `{"key": "a"}`)
+		require.ErrorIs(t, err, msg.ErrorJSONArray)
+	})
+
+	t.Run("ndjson with a malformed line", func(t *testing.T) {
+		recs, err := read(t, NDJSON, "{\"key\": \"a\", \"value\": \"1\"}\n\n{\"key\": \"b\", \n{\"key\": \"c\", \"value\": \"3\"}")
+		require.NoError(t, err)
+		require.Len(t, recs, 3)
+		require.Equal(t, "a", recs[0].Item.Key)
+		require.Equal(t, 3, recs[1].Line)
+		require.Error(t, recs[1].Err)
+		require.Equal(t, 4, recs[2].Line)
+		require.Equal(t, "3", recs[2].Item.Value)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		recs, err := read(t, CSV, "key,value,expiration,metadata\n/old,/new,,\n/a,\"/b,c\",1735689600,\"{\"\"code\"\": 301}\"\n/x,/y,tomorrow,\n")
+		require.NoError(t, err)
+		require.Len(t, recs, 3)
+		require.Equal(t, api.Item{Key: "/old", Value: "/new"}, recs[0].Item)
+		require.Equal(t, api.Item{Key: "/a", Value: "/b,c", Expiration: 1735689600, Metadata: map[string]interface{}{"code": float64(301)}}, recs[1].Item)
+		require.Equal(t, 4, recs[2].Line)
+		require.ErrorContains(t, recs[2].Err, "invalid expiration 'tomorrow'")
+	})
+
+	t.Run("csv without value column", func(t *testing.T) {
+		_, err := read(t, CSV, "key,metadata\na,{}\n")
+		require.ErrorContains(t, err, "missing the column 'value'")
+	})
+
+	t.Run("csv with unknown column", func(t *testing.T) {
+		_, err := read(t, CSV, "key,value,ttl\na,1,60\n")
+		require.ErrorContains(t, err, "Invalid CSV column 'ttl'")
+	})
+}
+
+func TestWriter(t *testing.T) {
+	items := []api.Item{
+		{Key: "a", Value: "1"},
+		{Key: "b", Value: "2, 3", Expiration: 1735689600, Metadata: map[string]interface{}{"owner": "web"}},
+	}
+
+	for _, format := range []Format{JSON, NDJSON, CSV} {
+		t.Run(string(format)+" round trip", func(t *testing.T) {
+			var out bytes.Buffer
+			writer := NewWriter(&out, format)
+			for _, item := range items {
+				require.NoError(t, writer.Write(item))
+			}
+			require.NoError(t, writer.Close())
+
+			read := []api.Item{}
+			err := Read(&out, format, func(record Record) {
+				require.NoError(t, record.Err)
+				read = append(read, record.Item)
+			})
+			require.NoError(t, err)
+			require.Equal(t, items, read)
+		})
+	}
+
+	t.Run("empty json", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, NewWriter(&out, JSON).Close())
+		require.Equal(t, "[]\n", out.String())
+	})
+}
@@ -0,0 +1,94 @@
+package records
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	api "github.com/aziontech/azion-cli/pkg/api/kv"
+)
+
+// Writer writes records to a file as they come, in the format given to NewWriter
+type Writer struct {
+	out     io.Writer
+	format  Format
+	csv     *csv.Writer
+	written int
+}
+
+func NewWriter(out io.Writer, format Format) *Writer {
+	writer := &Writer{out: out, format: format}
+	if format == CSV {
+		writer.csv = csv.NewWriter(out)
+	}
+	return writer
+}
+
+func (w *Writer) Write(item api.Item) error {
+	defer func() { w.written++ }()
+
+	switch w.format {
+	case CSV:
+		if w.written == 0 {
+			if err := w.csv.Write(Columns); err != nil {
+				return err
+			}
+		}
+		return w.csv.Write(row(item))
+	case NDJSON:
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w.out, "%s\n", data)
+		return err
+	default:
+		data, err := json.MarshalIndent(item, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		separator := ",\n  "
+		if w.written == 0 {
+			separator = "[\n  "
+		}
+		_, err = fmt.Fprintf(w.out, "%s%s", separator, data)
+		return err
+	}
+}
+
+// Close ends the file. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	switch w.format {
+	case CSV:
+		if w.written == 0 {
+			if err := w.csv.Write(Columns); err != nil {
+				return err
+			}
+		}
+		w.csv.Flush()
+		return w.csv.Error()
+	case NDJSON:
+		return nil
+	default:
+		if w.written == 0 {
+			_, err := fmt.Fprint(w.out, "[]\n")
+			return err
+		}
+		_, err := fmt.Fprint(w.out, "\n]\n")
+		return err
+	}
+}
+
+func row(item api.Item) []string {
+	expiration, metadata := "", ""
+	if item.Expiration != 0 {
+		expiration = strconv.FormatInt(item.Expiration, 10)
+	}
+	if len(item.Metadata) > 0 {
+		data, _ := json.Marshal(item.Metadata)
+		metadata = string(data)
+	}
+	return []string{item.Key, item.Value, expiration, metadata}
+}
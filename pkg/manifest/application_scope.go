@@ -0,0 +1,171 @@
+package manifest
+
+import (
+	"fmt"
+	"slices"
+
+	msg "github.com/aziontech/azion-cli/messages/manifest"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+)
+
+// applicationScope holds the state of a single manifest application: its azion.json entry
+// and the ID maps used while applying its cache settings and rules engine.
+type applicationScope struct {
+	Conf           contracts.AzionJsonDataApplications
+	CacheIds       map[string]int64
+	CacheIdsBackup map[string]int64
+	RuleIds        map[string]contracts.RuleIdsStruct
+}
+
+func newApplicationScope(conf contracts.AzionJsonDataApplications) *applicationScope {
+	scope := &applicationScope{
+		Conf:           conf,
+		CacheIds:       make(map[string]int64),
+		CacheIdsBackup: make(map[string]int64),
+		RuleIds:        make(map[string]contracts.RuleIdsStruct),
+	}
+	for _, cacheConf := range conf.CacheSettings {
+		scope.CacheIds[cacheConf.Name] = cacheConf.Id
+	}
+	for _, ruleConf := range conf.RulesEngine.Rules {
+		scope.RuleIds[ruleConf.Name] = contracts.RuleIdsStruct{
+			Id:    ruleConf.Id,
+			Phase: ruleConf.Phase,
+		}
+	}
+	return scope
+}
+
+// populateApplicationsFromConfig loads the applications tracked in azion.json.
+// Files written before multiple applications were supported only track a single application,
+// which always belonged to the first application of the manifest.
+func (rc *ResourceContext) populateApplicationsFromConfig() {
+	for _, appConf := range rc.Conf.Applications {
+		rc.addApplicationScope(appConf.Name, newApplicationScope(appConf))
+	}
+
+	primary := rc.primaryApplication()
+	if len(rc.Conf.Applications) == 0 && rc.Conf.Application.ID > 0 && primary != "" {
+		rc.addApplicationScope(primary, newApplicationScope(contracts.AzionJsonDataApplications{
+			ID:            rc.Conf.Application.ID,
+			Name:          rc.Conf.Application.Name,
+			RulesEngine:   rc.Conf.RulesEngine,
+			CacheSettings: rc.Conf.CacheSettings,
+		}))
+	}
+
+	// function instances tracked before multiple applications were supported belong to the
+	// application found in azion.json
+	for name, funcConf := range rc.FunctionIds {
+		if funcConf.InstanceID > 0 && funcConf.ApplicationID == 0 {
+			funcConf.ApplicationID = rc.Conf.Application.ID
+			rc.FunctionIds[name] = funcConf
+		}
+	}
+}
+
+func (rc *ResourceContext) addApplicationScope(name string, scope *applicationScope) {
+	if _, ok := rc.applications[name]; !ok {
+		rc.applicationNames = append(rc.applicationNames, name)
+	}
+	rc.applications[name] = scope
+}
+
+// primaryApplication returns the name of the first application of the manifest, which is
+// the one mirrored into the top-level application fields of azion.json.
+func (rc *ResourceContext) primaryApplication() string {
+	if rc.Manifest == nil || len(rc.Manifest.Applications) == 0 {
+		return ""
+	}
+	return rc.Manifest.Applications[0].Name
+}
+
+// UseApplication scopes the ResourceContext to the manifest application with the given name.
+// The application ID, cache settings and rules engine found in rc.Conf, as well as the cache
+// and rule ID maps, refer to the selected application until another one is selected.
+// Selected applications have their orphaned resources removed by DeleteOrphanedResources.
+func (rc *ResourceContext) UseApplication(name string) {
+	if rc.activeApplication == name {
+		return
+	}
+	rc.saveActiveApplication()
+
+	scope, ok := rc.applications[name]
+	if !ok {
+		scope = newApplicationScope(contracts.AzionJsonDataApplications{Name: name})
+		rc.addApplicationScope(name, scope)
+	}
+	if !slices.Contains(rc.appliedApplications, name) {
+		rc.appliedApplications = append(rc.appliedApplications, name)
+	}
+
+	rc.activeApplication = name
+	rc.Conf.Application = contracts.AzionJsonDataApplication{
+		ID:   scope.Conf.ID,
+		Name: scope.Conf.Name,
+	}
+	rc.Conf.RulesEngine = scope.Conf.RulesEngine
+	rc.Conf.CacheSettings = scope.Conf.CacheSettings
+	rc.CacheIds = scope.CacheIds
+	rc.CacheIdsBackup = scope.CacheIdsBackup
+	rc.RuleIds = scope.RuleIds
+}
+
+// saveActiveApplication stores the state of the selected application back into its scope
+// and refreshes the applications list of azion.json.
+func (rc *ResourceContext) saveActiveApplication() {
+	if rc.activeApplication == "" {
+		return
+	}
+	scope := rc.applications[rc.activeApplication]
+	scope.Conf.ID = rc.Conf.Application.ID
+	scope.Conf.Name = rc.Conf.Application.Name
+	if scope.Conf.Name == "" {
+		scope.Conf.Name = rc.activeApplication
+	}
+	scope.Conf.RulesEngine = rc.Conf.RulesEngine
+	scope.Conf.CacheSettings = rc.Conf.CacheSettings
+
+	apps := make([]contracts.AzionJsonDataApplications, 0, len(rc.applicationNames))
+	for _, name := range rc.applicationNames {
+		apps = append(apps, rc.applications[name].Conf)
+	}
+	rc.Conf.Applications = apps
+}
+
+// isPrimaryApplication reports whether the selected application is the first application of the manifest.
+func (rc *ResourceContext) isPrimaryApplication() bool {
+	return rc.activeApplication == "" || rc.activeApplication == rc.primaryApplication()
+}
+
+// applicationIdByName returns the ID of the manifest application with the given name,
+// falling back to the selected application when the name is empty or unknown.
+func (rc *ResourceContext) applicationIdByName(name *string) int64 {
+	rc.saveActiveApplication()
+	if name != nil {
+		if scope, ok := rc.applications[*name]; ok && scope.Conf.ID > 0 {
+			return scope.Conf.ID
+		}
+	}
+	return rc.Conf.Application.ID
+}
+
+// ValidateApplications checks that the applications of the manifest can be tracked by name
+// in azion.json: application names and function instance names must be unique.
+func ValidateApplications(apps []contracts.Applications) error {
+	appNames := make(map[string]bool)
+	instanceNames := make(map[string]bool)
+	for _, app := range apps {
+		if appNames[app.Name] {
+			return fmt.Errorf(msg.ErrorDuplicateApplication, app.Name)
+		}
+		appNames[app.Name] = true
+		for _, instance := range app.FunctionsInstances {
+			if instanceNames[instance.Name] {
+				return fmt.Errorf(msg.ErrorDuplicateFunctionInstance, instance.Name)
+			}
+			instanceNames[instance.Name] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseApplication(t *testing.T) {
+	f, _, _ := testutils.NewFactory(nil)
+	msgs := []string{}
+
+	t.Run("legacy azion.json is mapped to the first application", func(t *testing.T) {
+		conf := &contracts.AzionApplicationOptions{
+			Application:   contracts.AzionJsonDataApplication{ID: 10, Name: "api"},
+			CacheSettings: []contracts.AzionJsonDataCacheSettings{{Id: 100, Name: "api-cache"}},
+			Function:      []contracts.AzionJsonDataFunction{{ID: 1, Name: "handler", InstanceID: 2}},
+		}
+		manifest := &contracts.ManifestV4{
+			Applications: []contracts.Applications{{Name: "api"}, {Name: "site"}},
+		}
+		rc := NewResourceContext(f, conf, manifest, "azion", &msgs, nil)
+
+		require.Equal(t, int64(10), rc.FunctionIds["handler"].ApplicationID)
+
+		rc.UseApplication("api")
+		require.Equal(t, int64(10), rc.Conf.Application.ID)
+		require.Equal(t, int64(100), rc.CacheIds["api-cache"])
+
+		rc.UseApplication("site")
+		require.Equal(t, int64(0), rc.Conf.Application.ID)
+		require.Empty(t, rc.CacheIds)
+		rc.Conf.Application = contracts.AzionJsonDataApplication{ID: 20, Name: "site"}
+
+		rc.UseApplication("api")
+		require.Equal(t, int64(10), rc.Conf.Application.ID)
+		require.Len(t, rc.Conf.Applications, 2)
+		require.Equal(t, int64(20), rc.Conf.Applications[1].ID)
+		require.Equal(t, int64(20), rc.applicationIdByName(&manifest.Applications[1].Name))
+	})
+
+	t.Run("applications are loaded by name", func(t *testing.T) {
+		conf := &contracts.AzionApplicationOptions{
+			Applications: []contracts.AzionJsonDataApplications{
+				{ID: 10, Name: "api"},
+				{ID: 20, Name: "site", CacheSettings: []contracts.AzionJsonDataCacheSettings{{Id: 200, Name: "site-cache"}}},
+			},
+		}
+		manifest := &contracts.ManifestV4{
+			Applications: []contracts.Applications{{Name: "site"}, {Name: "api"}},
+		}
+		rc := NewResourceContext(f, conf, manifest, "azion", &msgs, nil)
+
+		rc.UseApplication("site")
+		require.Equal(t, int64(20), rc.Conf.Application.ID)
+		require.Equal(t, int64(200), rc.CacheIds["site-cache"])
+	})
+}
+
+func TestValidateApplications(t *testing.T) {
+	err := ValidateApplications([]contracts.Applications{{Name: "api"}, {Name: "site"}})
+	require.NoError(t, err)
+
+	err = ValidateApplications([]contracts.Applications{{Name: "api"}, {Name: "api"}})
+	require.Error(t, err)
+
+	err = ValidateApplications([]contracts.Applications{
+		{Name: "api", FunctionsInstances: []contracts.FunctionInstance{{Name: "main"}}},
+		{Name: "site", FunctionsInstances: []contracts.FunctionInstance{{Name: "main"}}},
+	})
+	require.Error(t, err)
+}
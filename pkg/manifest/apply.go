@@ -0,0 +1,196 @@
+package manifest
+
+import (
+	"time"
+
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/workers"
+	"go.uber.org/zap"
+)
+
+const (
+	stepStorage             = "ManifestStorage"
+	stepKV                  = "ManifestKV"
+	stepConnectors          = "ManifestConnectors"
+	stepFunctions           = "ManifestFunctions"
+	stepEdgeApplication     = "ManifestEdgeApplication"
+	stepFunctionInstances   = "ManifestFunctionInstances"
+	stepCacheSettings       = "ManifestCacheSettings"
+	stepRulesEngine         = "ManifestRulesEngine"
+	stepWorkloads           = "ManifestWorkloads"
+	stepWorkloadDeployments = "ManifestWorkloadDeployments"
+	stepFirewalls           = "ManifestFirewalls"
+	stepPurge               = "ManifestPurge"
+)
+
+// ApplyManifest applies every resource declared in the manifest, storage buckets included when withStorage is set.
+// Resources are applied as soon as the ones they refer to are done, so independent resources are applied
+// concurrently: functions wait for the KV namespaces, rules wait for the cache settings, function instances and
+// connectors of their application, firewalls wait for the functions and deployments wait for the workload,
+// the firewalls and the applications.
+// Applications are applied one after the other, since cache settings and rules refer to the selected one.
+// When a resource fails, the changes already made are rolled back unless SkipRollback is set.
+// It returns how many groups of resources were applied.
+func (rc *ResourceContext) ApplyManifest(withStorage bool) (int, error) {
+	graph := rc.applyGraph(withStorage)
+	if len(graph.steps) == 0 {
+		return 0, nil
+	}
+
+	rc.snapshotConfig()
+	rc.concurrent = true
+	err := graph.run(workers.CalculateOptimal(0))
+	rc.concurrent = false
+	if err != nil {
+		return 0, rc.rollback(err)
+	}
+	rc.journal = nil
+
+	// the top-level application fields of azion.json always describe the first application
+	if len(rc.Manifest.Applications) > 0 {
+		rc.UseApplication(rc.Manifest.Applications[0].Name)
+	}
+
+	return len(graph.steps), nil
+}
+
+// applyGraph builds the steps needed to apply the manifest along with their dependencies
+func (rc *ResourceContext) applyGraph(withStorage bool) *applyGraph {
+	manifest := rc.Manifest
+	graph := newApplyGraph()
+
+	if withStorage && len(manifest.Storage) > 0 {
+		rc.addStep(graph, stepStorage, stepStorage, func() error {
+			return rc.ApplyStorage(manifest.Storage)
+		})
+	}
+
+	if len(manifest.KV) > 0 {
+		rc.addStep(graph, stepKV, stepKV, func() error {
+			logger.Debug("Applying KV namespaces")
+			return rc.ApplyKV(manifest.KV)
+		})
+	}
+
+	if len(manifest.Connectors) > 0 {
+		rc.addStep(graph, stepConnectors, stepConnectors, func() error {
+			logger.Debug("Applying connectors")
+			return rc.ApplyConnectors(manifest.Connectors)
+		})
+	}
+
+	if len(manifest.Functions) > 0 {
+		// functions may be bound to the KV namespaces, which must exist before they run
+		rc.addStep(graph, stepFunctions, stepFunctions, func() error {
+			return rc.ApplyFunctions(manifest.Functions)
+		}, existing(graph, stepKV)...)
+	}
+
+	// steps of the previously declared application, the next one waits for all of them
+	var previous []string
+	for _, app := range manifest.Applications {
+		appStep := stepEdgeApplication + ":" + app.Name
+		rc.addStep(graph, appStep, stepEdgeApplication, func() error {
+			rc.UseApplication(app.Name)
+			logger.Debug("Applying edge application", zap.String("name", app.Name))
+			return rc.ApplyEdgeApplication(app)
+		}, previous...)
+		current := []string{appStep}
+
+		instancesStep := stepFunctionInstances + ":" + app.Name
+		if len(app.FunctionsInstances) > 0 {
+			rc.addStep(graph, instancesStep, stepFunctionInstances, func() error {
+				logger.Debug("Applying function instances")
+				return rc.ApplyFunctionInstances(app.FunctionsInstances)
+			}, existing(graph, appStep, stepFunctions)...)
+			current = append(current, instancesStep)
+		}
+
+		cacheStep := stepCacheSettings + ":" + app.Name
+		if len(app.CacheSettings) > 0 {
+			rc.addStep(graph, cacheStep, stepCacheSettings, func() error {
+				logger.Debug("Applying cache settings")
+				return rc.ApplyCacheSettings(app.CacheSettings)
+			}, appStep)
+			current = append(current, cacheStep)
+		}
+
+		if len(app.Rules) > 0 {
+			rulesStep := stepRulesEngine + ":" + app.Name
+			rc.addStep(graph, rulesStep, stepRulesEngine, func() error {
+				logger.Debug("Applying rules engine")
+				return rc.ApplyRulesEngine(app.Rules)
+			}, existing(graph, appStep, instancesStep, cacheStep, stepConnectors, stepFunctions)...)
+			current = append(current, rulesStep)
+		}
+
+		previous = current
+	}
+
+	if len(manifest.Workloads) > 0 {
+		rc.addStep(graph, stepWorkloads, stepWorkloads, func() error {
+			// workloads are bound to the first application
+			if len(manifest.Applications) > 0 {
+				rc.UseApplication(manifest.Applications[0].Name)
+			}
+			logger.Debug("Applying workloads")
+			return rc.ApplyWorkloads(manifest.Workloads)
+		}, previous...)
+	}
+
+	if len(manifest.Firewalls) > 0 {
+		rc.addStep(graph, stepFirewalls, stepFirewalls, func() error {
+			logger.Debug("Applying firewalls")
+			return rc.ApplyFirewalls(manifest.Firewalls)
+		}, existing(graph, stepFunctions)...)
+	}
+
+	if len(manifest.WorkloadDeployments) > 0 {
+		rc.addStep(graph, stepWorkloadDeployments, stepWorkloadDeployments, func() error {
+			logger.Debug("Applying workload deployments")
+			return rc.ApplyWorkloadDeployments(manifest.WorkloadDeployments)
+		}, append(existing(graph, stepWorkloads, stepFirewalls), previous...)...)
+	}
+
+	if len(manifest.Purge) > 0 {
+		// the cache is only purged once every other resource is in place
+		deps := make([]string, 0, len(graph.steps))
+		for _, step := range graph.steps {
+			deps = append(deps, step.name)
+		}
+		rc.addStep(graph, stepPurge, stepPurge, func() error {
+			logger.Debug("Applying purge")
+			return rc.ApplyPurge(manifest.Purge)
+		}, deps...)
+	}
+
+	return graph
+}
+
+// addStep adds a step that holds the state lock while it runs and reports its duration with the given timing name
+func (rc *ResourceContext) addStep(graph *applyGraph, name, timing string, apply func() error, deps ...string) {
+	graph.add(name, func() error {
+		rc.mu.Lock()
+		defer rc.mu.Unlock()
+
+		start := time.Now()
+		if err := apply(); err != nil {
+			return err
+		}
+		if GlobalTimingCallback != nil {
+			GlobalTimingCallback(timing, time.Since(start))
+		}
+		return nil
+	}, deps...)
+}
+
+// existing filters the given step names, keeping only the ones added to the graph
+func existing(graph *applyGraph, names ...string) []string {
+	deps := make([]string, 0, len(names))
+	for _, name := range names {
+		if graph.has(name) {
+			deps = append(deps, name)
+		}
+	}
+	return deps
+}
@@ -0,0 +1,49 @@
+package manifest
+
+import (
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// bindingsArg is the default argument through which a function receives the bucket and the KV namespace it is
+// bound to, since the functions API has no field of its own for them
+const bindingsArg = "bindings"
+
+// functionArgs returns the default arguments sent for a function: the ones of the manifest along with its
+// bindings. The default arguments of the manifest are left untouched.
+func functionArgs(function contracts.Function) map[string]interface{} {
+	bindings := map[string]interface{}{}
+	if storage := function.Bindings.Storage; storage != (contracts.StorageBinding{}) {
+		bindings["storage"] = stateMap(storage)
+	}
+	if kv := function.Bindings.KV; kv != (contracts.KVBinding{}) {
+		bindings["kv"] = stateMap(kv)
+	}
+	if len(bindings) == 0 {
+		return function.DefaultArgs
+	}
+
+	args := make(map[string]interface{}, len(function.DefaultArgs)+1)
+	for name, value := range function.DefaultArgs {
+		args[name] = value
+	}
+	args[bindingsArg] = bindings
+	return args
+}
+
+// splitBindings moves the bindings sent along with the default arguments of a function back to its bindings
+func splitBindings(function *contracts.Function) {
+	raw, ok := function.DefaultArgs[bindingsArg]
+	if !ok {
+		return
+	}
+	if err := decodeState(raw, &function.Bindings); err != nil {
+		logger.Debug("Function bindings not imported", zap.String("name", function.Name), zap.Error(err))
+		return
+	}
+	delete(function.DefaultArgs, bindingsArg)
+	if len(function.DefaultArgs) == 0 {
+		function.DefaultArgs = nil
+	}
+}
@@ -0,0 +1,42 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctionArgs(t *testing.T) {
+	t.Run("functions without bindings keep their arguments", func(t *testing.T) {
+		args := map[string]interface{}{"env": "prod"}
+		require.Equal(t, args, functionArgs(contracts.Function{DefaultArgs: args}))
+		require.Nil(t, functionArgs(contracts.Function{}))
+	})
+
+	t.Run("bindings are sent along with the arguments", func(t *testing.T) {
+		function := contracts.Function{
+			Name:        "handler",
+			DefaultArgs: map[string]interface{}{"env": "prod"},
+			Bindings: contracts.FunctionBindings{
+				Storage: contracts.StorageBinding{Bucket: "assets", Prefix: "v1"},
+				KV:      contracts.KVBinding{Namespace: "flags"},
+			},
+		}
+
+		args := functionArgs(function)
+		require.Equal(t, map[string]interface{}{
+			"env": "prod",
+			"bindings": map[string]interface{}{
+				"storage": map[string]any{"bucket": "assets", "prefix": "v1"},
+				"kv":      map[string]any{"namespace": "flags"},
+			},
+		}, args)
+		require.Equal(t, map[string]interface{}{"env": "prod"}, function.DefaultArgs)
+
+		// bindings imported back from the arguments match the manifest
+		imported := contracts.Function{Name: "handler", DefaultArgs: stateMap(args)}
+		splitBindings(&imported)
+		require.Equal(t, function, imported)
+	})
+}
@@ -0,0 +1,57 @@
+package manifest
+
+import (
+	"encoding/json"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+)
+
+// CanaryManifest returns a copy of the manifest that creates the resources serving a new version next to the
+// ones already deployed. Applications, functions and connectors are renamed with suffix, along with the
+// references to them, so that applying it creates new resources instead of updating the current ones.
+// Workloads, their deployments, firewalls and purges are shared by both versions and left out.
+func CanaryManifest(manifest *contracts.ManifestV4, suffix string) (*contracts.ManifestV4, error) {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	canary := &contracts.ManifestV4{}
+	if err := json.Unmarshal(data, canary); err != nil {
+		return nil, err
+	}
+
+	canary.Workloads = nil
+	canary.WorkloadDeployments = nil
+	canary.Firewalls = nil
+	canary.Purge = nil
+
+	for i := range canary.Functions {
+		canary.Functions[i].Name += suffix
+	}
+	for _, connector := range canary.Connectors {
+		if connector.ConnectorHTTPRequest != nil {
+			connector.ConnectorHTTPRequest.Name += suffix
+		}
+		if connector.ConnectorRequestBase != nil {
+			connector.ConnectorRequestBase.Name += suffix
+		}
+	}
+	for i := range canary.Applications {
+		app := &canary.Applications[i]
+		app.Name += suffix
+		for j := range app.FunctionsInstances {
+			if function := &app.FunctionsInstances[j].Function; function.Name != "" {
+				function.Name += suffix
+			}
+		}
+		for _, rule := range app.Rules {
+			for _, behavior := range rule.Rule.Behaviors {
+				if name, ok := behavior.Attributes["value"].(string); ok && behavior.Type == "set_connector" {
+					behavior.Attributes["value"] = name + suffix
+				}
+			}
+		}
+	}
+
+	return canary, nil
+}
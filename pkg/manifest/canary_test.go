@@ -0,0 +1,59 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanaryManifest(t *testing.T) {
+	manifest := &contracts.ManifestV4{
+		Functions: []contracts.Function{{Name: "handler"}},
+		Applications: []contracts.Applications{
+			{
+				Name: "api",
+				FunctionsInstances: []contracts.FunctionInstance{
+					{Name: "handler-instance", Function: contracts.FunctionReference{Name: "handler"}},
+					{Name: "external-instance", Function: contracts.FunctionReference{ID: 7}},
+				},
+				Rules: []contracts.ManifestRulesEngine{
+					{Phase: "request", Rule: contracts.ManifestRule{
+						Name: "static",
+						Behaviors: []contracts.ManifestRuleBehavior{
+							{Type: "set_connector", Attributes: map[string]interface{}{"value": "origin"}},
+							{Type: "run_function", Attributes: map[string]interface{}{"value": "handler-instance"}},
+						},
+					}},
+				},
+			},
+		},
+		Workloads:           []contracts.WorkloadManifest{{Name: "site"}},
+		WorkloadDeployments: []contracts.WorkloadDeployment{{Name: "production"}},
+		Firewalls:           []contracts.FirewallManifest{{Name: "waf"}},
+		Purge:               []contracts.PurgeManifest{{Type: "url"}},
+	}
+
+	canary, err := CanaryManifest(manifest, "-canary")
+	require.NoError(t, err)
+
+	require.Equal(t, "handler-canary", canary.Functions[0].Name)
+	app := canary.Applications[0]
+	require.Equal(t, "api-canary", app.Name)
+	require.Equal(t, "handler-instance", app.FunctionsInstances[0].Name)
+	require.Equal(t, "handler-canary", app.FunctionsInstances[0].Function.Name)
+	require.Equal(t, int64(7), app.FunctionsInstances[1].Function.ID)
+	behaviors := app.Rules[0].Rule.Behaviors
+	require.Equal(t, "origin-canary", behaviors[0].Attributes["value"])
+	require.Equal(t, "handler-instance", behaviors[1].Attributes["value"])
+
+	require.Empty(t, canary.Workloads)
+	require.Empty(t, canary.WorkloadDeployments)
+	require.Empty(t, canary.Firewalls)
+	require.Empty(t, canary.Purge)
+
+	// the manifest of the current version is left as it is
+	require.Equal(t, "api", manifest.Applications[0].Name)
+	require.Equal(t, "origin", manifest.Applications[0].Rules[0].Rule.Behaviors[0].Attributes["value"])
+	require.Len(t, manifest.WorkloadDeployments, 1)
+}
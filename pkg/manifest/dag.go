@@ -0,0 +1,117 @@
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	msg "github.com/aziontech/azion-cli/messages/manifest"
+)
+
+// applyStep is a group of manifest resources applied together, e.g. the cache settings of an application
+type applyStep struct {
+	name string
+	deps []string
+	run  func() error
+}
+
+// applyGraph holds the steps needed to apply a manifest and the dependencies between them
+type applyGraph struct {
+	steps []*applyStep
+	index map[string]*applyStep
+}
+
+type stepResult struct {
+	step *applyStep
+	err  error
+}
+
+func newApplyGraph() *applyGraph {
+	return &applyGraph{index: make(map[string]*applyStep)}
+}
+
+// add registers a step that only runs once every one of its dependencies succeeded
+func (g *applyGraph) add(name string, run func() error, deps ...string) {
+	step := &applyStep{name: name, deps: deps, run: run}
+	g.steps = append(g.steps, step)
+	g.index[name] = step
+}
+
+// has informs if a step with the given name was registered
+func (g *applyGraph) has(name string) bool {
+	_, ok := g.index[name]
+	return ok
+}
+
+// run executes the steps using at most the given number of workers. Steps whose dependencies are done run
+// concurrently; after the first failure no other step is started and the error is returned once the running
+// steps finish.
+func (g *applyGraph) run(noOfWorkers int) error {
+	pending := make(map[string]int, len(g.steps))
+	dependents := make(map[string][]*applyStep, len(g.steps))
+	for _, step := range g.steps {
+		for _, dep := range step.deps {
+			if !g.has(dep) {
+				return fmt.Errorf(msg.ErrorUnknownDependency, step.name, dep)
+			}
+			dependents[dep] = append(dependents[dep], step)
+		}
+		pending[step.name] = len(step.deps)
+	}
+
+	jobs := make(chan *applyStep, len(g.steps))
+	results := make(chan stepResult, len(g.steps))
+	defer close(jobs)
+
+	for i := 0; i < noOfWorkers; i++ {
+		go func() {
+			for step := range jobs {
+				results <- stepResult{step: step, err: step.run()}
+			}
+		}()
+	}
+
+	running := 0
+	for _, step := range g.steps {
+		if pending[step.name] == 0 {
+			jobs <- step
+			running++
+		}
+	}
+
+	var firstErr error
+	done := 0
+	for running > 0 {
+		result := <-results
+		running--
+		done++
+		if result.err != nil && firstErr == nil {
+			firstErr = result.err
+		}
+		if firstErr != nil {
+			continue
+		}
+		for _, next := range dependents[result.step.name] {
+			pending[next.name]--
+			if pending[next.name] == 0 {
+				jobs <- next
+				running++
+			}
+		}
+	}
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	if done < len(g.steps) {
+		blocked := []string{}
+		for _, step := range g.steps {
+			if pending[step.name] > 0 {
+				blocked = append(blocked, step.name)
+			}
+		}
+		return fmt.Errorf(msg.ErrorDependencyCycle, strings.Join(blocked, ", "))
+	}
+
+	return nil
+}
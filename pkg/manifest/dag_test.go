@@ -0,0 +1,148 @@
+package manifest
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyGraphRun(t *testing.T) {
+	t.Run("steps run after their dependencies", func(t *testing.T) {
+		var mu sync.Mutex
+		order := []string{}
+		record := func(name string) func() error {
+			return func() error {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, name)
+				return nil
+			}
+		}
+
+		graph := newApplyGraph()
+		graph.add("rules", record("rules"), "cache", "connectors")
+		graph.add("cache", record("cache"), "application")
+		graph.add("application", record("application"))
+		graph.add("connectors", record("connectors"))
+
+		require.NoError(t, graph.run(4))
+		require.Len(t, order, 4)
+		position := make(map[string]int)
+		for i, name := range order {
+			position[name] = i
+		}
+		require.Less(t, position["application"], position["cache"])
+		require.Less(t, position["cache"], position["rules"])
+		require.Less(t, position["connectors"], position["rules"])
+	})
+
+	t.Run("independent steps run concurrently within the worker bound", func(t *testing.T) {
+		var running, maxRunning int64
+		release := make(chan struct{})
+		started := make(chan struct{}, 6)
+		step := func() error {
+			current := atomic.AddInt64(&running, 1)
+			for {
+				seen := atomic.LoadInt64(&maxRunning)
+				if current <= seen || atomic.CompareAndSwapInt64(&maxRunning, seen, current) {
+					break
+				}
+			}
+			started <- struct{}{}
+			<-release
+			atomic.AddInt64(&running, -1)
+			return nil
+		}
+
+		graph := newApplyGraph()
+		for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+			graph.add(name, step)
+		}
+
+		done := make(chan error)
+		go func() { done <- graph.run(3) }()
+		for i := 0; i < 3; i++ {
+			<-started
+		}
+		close(release)
+
+		require.NoError(t, <-done)
+		require.Equal(t, int64(3), maxRunning)
+	})
+
+	t.Run("no step starts after a failure", func(t *testing.T) {
+		var calls int64
+		graph := newApplyGraph()
+		graph.add("functions", func() error { return errors.New("failed") })
+		graph.add("instances", func() error {
+			atomic.AddInt64(&calls, 1)
+			return nil
+		}, "functions")
+
+		require.EqualError(t, graph.run(2), "failed")
+		require.Zero(t, calls)
+	})
+
+	t.Run("cycles and unknown dependencies are reported", func(t *testing.T) {
+		noop := func() error { return nil }
+
+		cycle := newApplyGraph()
+		cycle.add("a", noop, "b")
+		cycle.add("b", noop, "a")
+		cycle.add("c", noop)
+		require.ErrorContains(t, cycle.run(2), "a, b")
+
+		unknown := newApplyGraph()
+		unknown.add("a", noop, "missing")
+		require.ErrorContains(t, unknown.run(2), "missing")
+	})
+}
+
+func TestApplyGraphDependencies(t *testing.T) {
+	f, _, _ := testutils.NewFactory(nil)
+	msgs := []string{}
+	manifest := &contracts.ManifestV4{
+		KV:        []contracts.KVManifest{{Name: "flags"}},
+		Functions: []contracts.Function{{Name: "handler", Bindings: contracts.FunctionBindings{KV: contracts.KVBinding{Namespace: "flags"}}}},
+		Applications: []contracts.Applications{
+			{
+				Name:               "api",
+				FunctionsInstances: []contracts.FunctionInstance{{Name: "handler-instance"}},
+				CacheSettings:      []contracts.ManifestCacheSetting{{Name: "assets"}},
+				Rules:              []contracts.ManifestRulesEngine{{Phase: "request"}},
+			},
+			{Name: "web"},
+		},
+		Workloads:           []contracts.WorkloadManifest{{Name: "workload"}},
+		WorkloadDeployments: []contracts.WorkloadDeployment{{Name: "deployment"}},
+		Firewalls:           []contracts.FirewallManifest{{Name: "waf"}},
+		Purge:               []contracts.PurgeManifest{{Type: "url"}},
+	}
+
+	rc := NewResourceContext(f, &contracts.AzionApplicationOptions{}, manifest, "azion", &msgs, nil)
+	graph := rc.applyGraph(false)
+
+	deps := make(map[string][]string)
+	for _, step := range graph.steps {
+		deps[step.name] = step.deps
+	}
+
+	require.Equal(t, map[string][]string{
+		"ManifestKV":                    nil,
+		"ManifestFunctions":             {"ManifestKV"},
+		"ManifestEdgeApplication:api":   nil,
+		"ManifestFunctionInstances:api": {"ManifestEdgeApplication:api", "ManifestFunctions"},
+		"ManifestCacheSettings:api":     {"ManifestEdgeApplication:api"},
+		"ManifestRulesEngine:api":       {"ManifestEdgeApplication:api", "ManifestFunctionInstances:api", "ManifestCacheSettings:api", "ManifestFunctions"},
+		"ManifestEdgeApplication:web":   {"ManifestEdgeApplication:api", "ManifestFunctionInstances:api", "ManifestCacheSettings:api", "ManifestRulesEngine:api"},
+		"ManifestWorkloads":             {"ManifestEdgeApplication:web"},
+		"ManifestFirewalls":             {"ManifestFunctions"},
+		"ManifestWorkloadDeployments":   {"ManifestWorkloads", "ManifestFirewalls", "ManifestEdgeApplication:web"},
+		"ManifestPurge":                 {"ManifestKV", "ManifestFunctions", "ManifestEdgeApplication:api", "ManifestFunctionInstances:api", "ManifestCacheSettings:api", "ManifestRulesEngine:api", "ManifestEdgeApplication:web", "ManifestWorkloads", "ManifestFirewalls", "ManifestWorkloadDeployments"},
+	}, deps)
+}
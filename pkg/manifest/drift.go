@@ -0,0 +1,368 @@
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/utils"
+	"go.uber.org/zap"
+)
+
+// FieldDrift is a field of a resource whose value on Azion Platform differs from the manifest.
+// Missing is set when the resource tracked in azion.json no longer exists, in which case no field is informed.
+type FieldDrift struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	ID          int64  `json:"id"`
+	Application string `json:"application,omitempty"`
+	Field       string `json:"field,omitempty"`
+	Expected    any    `json:"expected,omitempty"`
+	Actual      any    `json:"actual,omitempty"`
+	Missing     bool   `json:"missing,omitempty"`
+}
+
+// KindName returns the human readable name of the kind of the drifted resource
+func (d FieldDrift) KindName() string {
+	return PlanChange{Kind: d.Kind}.KindName()
+}
+
+// referenceResolver returns the ID of the resource a behavior or strategy refers to by name
+type referenceResolver func(behaviorType, name string) (int64, bool)
+
+// DetectDrift fetches every resource declared in the manifest and tracked in azion.json, and compares the fields
+// set in the manifest with the ones found on Azion Platform. Names used to refer to other resources are resolved
+// to the IDs kept in azion.json before comparing. Fields the API does not return are not compared, and resources
+// not created yet are skipped, as applying the manifest creates them. It never changes azion.json nor the remote state.
+func (rc *ResourceContext) DetectDrift() ([]FieldDrift, error) {
+	d := &driftDetector{rc: rc, drifts: []FieldDrift{}}
+	manifest := rc.Manifest
+
+	for _, connector := range manifest.Connectors {
+		name, _ := getConnectorName(connector, rc.Conf.Name)
+		if err := d.compare(name, "", resourceRef{Kind: kindConnector, ID: rc.ConnectorIds[name]}, stateMap(connector), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, funcMan := range manifest.Functions {
+		ref := resourceRef{Kind: kindFunction, ID: rc.FunctionIds[funcMan.Name].ID}
+		if err := d.compare(funcMan.Name, "", ref, functionState(funcMan), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, app := range manifest.Applications {
+		if err := d.application(app); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(manifest.Workloads) > 0 {
+		workload := manifest.Workloads[0]
+		if err := d.compare(workload.Name, "", resourceRef{Kind: kindWorkload, ID: rc.Conf.Workloads.Id}, stateMap(workload), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, deployment := range manifest.WorkloadDeployments {
+		ref := resourceRef{Kind: kindDeployment, ID: rc.DeploymentIds[deployment.Name], ParentID: rc.Conf.Workloads.Id}
+		if err := d.compare(deployment.Name, "", ref, rc.deploymentState(deployment), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, fwMan := range manifest.Firewalls {
+		if err := d.firewall(fwMan); err != nil {
+			return nil, err
+		}
+	}
+
+	return d.drifts, nil
+}
+
+// driftDetector accumulates the differences found between the manifest and the remote state
+type driftDetector struct {
+	rc     *ResourceContext
+	drifts []FieldDrift
+}
+
+func (d *driftDetector) application(app contracts.Applications) error {
+	rc := d.rc
+	scope, ok := rc.applications[app.Name]
+	if !ok || scope.Conf.ID == 0 {
+		return nil
+	}
+	appID := scope.Conf.ID
+
+	desired := stateMap(app, "rules", "cache_settings", "functions_instances")
+	if err := d.compare(app.Name, app.Name, resourceRef{Kind: kindApplication, ID: appID}, desired, nil); err != nil {
+		return err
+	}
+
+	for _, instance := range app.FunctionsInstances {
+		funcConf := rc.FunctionIds[instance.Name]
+		if funcConf.ApplicationID != appID {
+			continue
+		}
+		ref := resourceRef{Kind: kindFunctionInstance, ID: funcConf.InstanceID, ParentID: appID}
+		if err := d.compare(instance.Name, app.Name, ref, rc.functionInstanceState(instance), nil); err != nil {
+			return err
+		}
+	}
+
+	for _, cache := range app.CacheSettings {
+		ref := resourceRef{Kind: kindCacheSetting, ID: scope.CacheIds[cache.Name], ParentID: appID}
+		if err := d.compare(cache.Name, app.Name, ref, stateMap(cache), nil); err != nil {
+			return err
+		}
+	}
+
+	resolver := rc.ruleResolver(scope, appID)
+	for _, rule := range app.Rules {
+		existing := scope.RuleIds[rule.Rule.Name]
+		ref := resourceRef{Kind: kindRule, ID: existing.Id, ParentID: appID, Phase: existing.Phase}
+		if err := d.compare(rule.Rule.Name, app.Name, ref, stateMap(rule.Rule), resolver); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *driftDetector) firewall(fwMan contracts.FirewallManifest) error {
+	rc := d.rc
+	firewallID := rc.FirewallIds[fwMan.Name]
+	if firewallID == 0 {
+		return nil
+	}
+
+	desired := stateMap(fwMan, "rules_engine", "functions_instances")
+	if err := d.compare(fwMan.Name, "", resourceRef{Kind: kindFirewall, ID: firewallID}, desired, nil); err != nil {
+		return err
+	}
+
+	for _, instance := range fwMan.FunctionsInstances {
+		instRef := rc.FirewallFunctionInstIds[instance.Name]
+		ref := resourceRef{Kind: kindFirewallFunctionInstance, ID: instRef.FunctionInstanceId, ParentID: instRef.FirewallId}
+		if err := d.compare(instance.Name, "", ref, rc.functionInstanceState(instance), nil); err != nil {
+			return err
+		}
+	}
+
+	resolver := rc.firewallRuleResolver()
+	for _, rule := range fwMan.RulesEngine {
+		ruleRef := rc.FirewallRuleIds[rule.Name]
+		ref := resourceRef{Kind: kindFirewallRule, ID: ruleRef.RuleId, ParentID: ruleRef.FirewallId}
+		if err := d.compare(rule.Name, "", ref, stateMap(rule), resolver); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compare fetches a tracked resource and records the fields that differ from the desired state.
+// Resources without an ID were not created yet and are skipped.
+func (d *driftDetector) compare(name, application string, ref resourceRef, desired map[string]any, resolve referenceResolver) error {
+	if ref.ID == 0 || desired == nil {
+		return nil
+	}
+
+	state, err := d.rc.liveState(ref)
+	if err != nil {
+		if errors.Is(err, utils.ErrorNotFound404) {
+			logger.Debug("Tracked resource not found", zap.String("kind", ref.Kind), zap.Int64("id", ref.ID))
+			d.drifts = append(d.drifts, FieldDrift{Kind: ref.Kind, Name: name, ID: ref.ID, Application: application, Missing: true})
+			return nil
+		}
+		return err
+	}
+
+	diffResource(desired, resolve, state, func(field string, expected, actualValue any) {
+		d.drifts = append(d.drifts, FieldDrift{
+			Kind:        ref.Kind,
+			Name:        name,
+			ID:          ref.ID,
+			Application: application,
+			Field:       field,
+			Expected:    expected,
+			Actual:      actualValue,
+		})
+	})
+	return nil
+}
+
+// diffResource reports every field of the desired state of a resource that differs from its remote state,
+// once the names its behaviors refer to are resolved
+func diffResource(desired map[string]any, resolve referenceResolver, state any, report func(field string, expected, actual any)) {
+	if resolve != nil {
+		resolveBehaviors(desired, resolve)
+	}
+	diffState("", desired, stateMap(state), report)
+}
+
+// functionState returns the desired state of a function. Its code is compared by deploying it, not field by
+// field, and its bindings are compared as the default arguments they are sent as.
+func functionState(funcMan contracts.Function) map[string]any {
+	funcMan.DefaultArgs = functionArgs(funcMan)
+	return stateMap(funcMan, "path", "bindings", "argument")
+}
+
+// functionInstanceState returns the desired state of a function instance, with the function it refers to by
+// name replaced with its ID
+func (rc *ResourceContext) functionInstanceState(instance contracts.FunctionInstance) map[string]any {
+	desired := stateMap(instance)
+	if instance.Function.ID > 0 {
+		return desired
+	}
+	if funcConf, ok := rc.FunctionIds[instance.Function.Name]; ok && funcConf.ID > 0 {
+		desired["function"] = float64(funcConf.ID)
+		return desired
+	}
+	delete(desired, "function")
+	return desired
+}
+
+// deploymentState returns the desired state of a workload deployment, with the application and firewall of its
+// strategy replaced with their IDs
+func (rc *ResourceContext) deploymentState(deployment contracts.WorkloadDeployment) map[string]any {
+	desired := stateMap(deployment)
+	resolveStrategy(desired, func(field, name string) (int64, bool) {
+		if field == "application" {
+			if scope, ok := rc.applications[name]; ok && scope.Conf.ID > 0 {
+				return scope.Conf.ID, true
+			}
+			return 0, false
+		}
+		id := rc.FirewallIds[name]
+		return id, id > 0
+	})
+	return desired
+}
+
+// ruleResolver resolves the function instances, cache settings and connectors the rules of an application refer to
+func (rc *ResourceContext) ruleResolver(scope *applicationScope, appID int64) referenceResolver {
+	return func(behaviorType, name string) (int64, bool) {
+		var id int64
+		switch behaviorType {
+		case "run_function":
+			if funcConf := rc.FunctionIds[name]; funcConf.ApplicationID == appID {
+				id = funcConf.InstanceID
+			}
+		case "set_cache_policy":
+			id = scope.CacheIds[name]
+		case "set_connector":
+			id = rc.ConnectorIds[name]
+		}
+		return id, id > 0
+	}
+}
+
+// firewallRuleResolver resolves the function instances the rules of a firewall refer to
+func (rc *ResourceContext) firewallRuleResolver() referenceResolver {
+	return func(behaviorType, name string) (int64, bool) {
+		instRef, ok := rc.FirewallFunctionInstIds[name]
+		return instRef.FunctionInstanceId, ok && behaviorType == "run_function" && instRef.FunctionInstanceId > 0
+	}
+}
+
+// stateMap returns the JSON representation of a resource as a map, without the given fields
+func stateMap(resource any, without ...string) map[string]any {
+	b, err := json.Marshal(resource)
+	if err != nil {
+		logger.Debug("Failed to encode resource", zap.Error(err))
+		return nil
+	}
+	state := map[string]any{}
+	if err := json.Unmarshal(b, &state); err != nil {
+		logger.Debug("Failed to decode resource", zap.Error(err))
+		return nil
+	}
+	for _, field := range without {
+		delete(state, field)
+	}
+	return state
+}
+
+// resolveBehaviors replaces the names behaviors refer to with IDs. Values that cannot be resolved are
+// not compared, since the resource they refer to was not created yet.
+func resolveBehaviors(desired map[string]any, resolve referenceResolver) {
+	behaviors, _ := desired["behaviors"].([]any)
+	for _, item := range behaviors {
+		behavior, _ := item.(map[string]any)
+		attributes, _ := behavior["attributes"].(map[string]any)
+		name, ok := attributes["value"].(string)
+		if !ok {
+			continue
+		}
+		behaviorType, _ := behavior["type"].(string)
+		if id, found := resolve(behaviorType, name); found {
+			attributes["value"] = float64(id)
+		} else if slices.Contains([]string{"run_function", "set_cache_policy", "set_connector"}, behaviorType) {
+			delete(attributes, "value")
+		}
+	}
+}
+
+// resolveStrategy replaces the application and firewall names of a deployment strategy with their IDs
+func resolveStrategy(desired map[string]any, resolve func(field, name string) (int64, bool)) {
+	strategy, _ := desired["strategy"].(map[string]any)
+	attributes, _ := strategy["attributes"].(map[string]any)
+	for _, field := range []string{"application", "firewall"} {
+		name, ok := attributes[field].(string)
+		if !ok {
+			continue
+		}
+		if id, found := resolve(field, name); found {
+			attributes[field] = float64(id)
+		} else {
+			delete(attributes, field)
+		}
+	}
+}
+
+// diffState reports every value of expected that differs from actual. Fields missing from actual are not
+// reported, since the API does not return every field it accepts; lists are compared item by item when their
+// lengths match.
+func diffState(field string, expected, actual any, report func(field string, expected, actual any)) {
+	if expected == nil {
+		return
+	}
+	switch exp := expected.(type) {
+	case map[string]any:
+		act, ok := actual.(map[string]any)
+		if !ok {
+			report(field, expected, actual)
+			return
+		}
+		for _, key := range sortedKeys(exp) {
+			value, found := act[key]
+			if !found {
+				continue
+			}
+			child := key
+			if field != "" {
+				child = field + "." + key
+			}
+			diffState(child, exp[key], value, report)
+		}
+	case []any:
+		act, ok := actual.([]any)
+		if !ok || len(act) != len(exp) {
+			report(field, expected, actual)
+			return
+		}
+		for i := range exp {
+			diffState(fmt.Sprintf("%s[%d]", field, i), exp[i], act[i], report)
+		}
+	default:
+		if !reflect.DeepEqual(expected, actual) {
+			report(field, expected, actual)
+		}
+	}
+}
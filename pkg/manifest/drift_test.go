@@ -0,0 +1,115 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectDrift(t *testing.T) {
+	f, _, _ := testutils.NewFactory(nil)
+	msgs := []string{}
+	active, debug := true, false
+
+	conf := &contracts.AzionApplicationOptions{
+		Applications: []contracts.AzionJsonDataApplications{
+			{
+				ID:            10,
+				Name:          "api",
+				CacheSettings: []contracts.AzionJsonDataCacheSettings{{Id: 100, Name: "assets"}},
+				RulesEngine: contracts.AzionJsonDataRulesEngine{
+					Rules: []contracts.AzionJsonDataRules{{Id: 200, Name: "cache assets", Phase: "request"}},
+				},
+			},
+		},
+		Function:  []contracts.AzionJsonDataFunction{{ID: 1, Name: "handler"}},
+		Workloads: contracts.AzionJsonDataWorkload{Id: 30, Name: "api", Deployments: []contracts.Deployments{{Id: 31, Name: "api"}}},
+	}
+	application := "api"
+	manifest := &contracts.ManifestV4{
+		Functions: []contracts.Function{
+			{Name: "handler", Path: "./handler.js", Runtime: "azion_js", Bindings: contracts.FunctionBindings{KV: contracts.KVBinding{Namespace: "flags"}}},
+			{Name: "new-handler"},
+		},
+		Applications: []contracts.Applications{
+			{
+				Name:          "api",
+				Active:        &active,
+				Debug:         &debug,
+				CacheSettings: []contracts.ManifestCacheSetting{{Name: "assets"}},
+				Rules: []contracts.ManifestRulesEngine{
+					{
+						Phase: "request",
+						Rule: contracts.ManifestRule{
+							Name: "cache assets",
+							Behaviors: []contracts.ManifestRuleBehavior{
+								{Type: "set_cache_policy", Attributes: map[string]interface{}{"value": "assets"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		Workloads: []contracts.WorkloadManifest{{Name: "api", Domains: []string{"example.com"}}},
+		WorkloadDeployments: []contracts.WorkloadDeployment{
+			{Name: "api", Strategy: contracts.WorkloadStrategy{Type: "default", Attributes: contracts.WorkloadStrategyAttrs{Application: &application}}},
+		},
+	}
+
+	rc := NewResourceContext(f, conf, manifest, "azion", &msgs, nil)
+	remote := map[string]any{
+		kindFunction: map[string]any{"id": 1, "name": "handler", "runtime": "azion_js", "code": "changed",
+			"default_args": map[string]any{"bindings": map[string]any{"kv": map[string]any{"namespace": "sessions"}}}},
+		kindApplication:  map[string]any{"id": 10, "name": "api", "active": true, "debug": true},
+		kindCacheSetting: map[string]any{"id": 100, "name": "assets"},
+		kindRule: map[string]any{"id": 200, "name": "cache assets", "behaviors": []any{
+			map[string]any{"type": "set_cache_policy", "attributes": map[string]any{"value": 101}},
+		}},
+		kindDeployment: map[string]any{"id": 31, "name": "api", "strategy": map[string]any{
+			"type": "default", "attributes": map[string]any{"application": 10},
+		}},
+	}
+	fetched := []string{}
+	rc.liveState = func(ref resourceRef) (any, error) {
+		fetched = append(fetched, ref.Kind)
+		if state, ok := remote[ref.Kind]; ok {
+			return state, nil
+		}
+		return nil, utils.ErrorNotFound404
+	}
+
+	drifts, err := rc.DetectDrift()
+	require.NoError(t, err)
+	require.Equal(t, []FieldDrift{
+		{Kind: kindFunction, Name: "handler", ID: 1, Field: "default_args.bindings.kv.namespace", Expected: "flags", Actual: "sessions"},
+		{Kind: kindApplication, Name: "api", ID: 10, Application: "api", Field: "debug", Expected: false, Actual: true},
+		{Kind: kindRule, Name: "cache assets", ID: 200, Application: "api", Field: "behaviors[0].attributes.value", Expected: float64(100), Actual: float64(101)},
+		{Kind: kindWorkload, Name: "api", ID: 30, Missing: true},
+	}, drifts)
+
+	// resources not created yet are not fetched
+	require.Equal(t, []string{kindFunction, kindApplication, kindCacheSetting, kindRule, kindWorkload, kindDeployment}, fetched)
+}
+
+func TestDiffState(t *testing.T) {
+	type diff struct {
+		Field    string
+		Expected any
+		Actual   any
+	}
+	diffs := []diff{}
+	diffState("",
+		map[string]any{"name": "a", "modules": map[string]any{"cache": true}, "domains": []any{"a.com"}, "unknown": 1.0},
+		map[string]any{"name": "a", "modules": map[string]any{"cache": false}, "domains": []any{"a.com", "b.com"}},
+		func(field string, expected, actual any) {
+			diffs = append(diffs, diff{field, expected, actual})
+		})
+
+	require.Equal(t, []diff{
+		{"domains", []any{"a.com"}, []any{"a.com", "b.com"}},
+		{"modules.cache", true, false},
+	}, diffs)
+}
@@ -0,0 +1,470 @@
+package manifest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	msg "github.com/aziontech/azion-cli/messages/manifest"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/utils"
+	edgesdk "github.com/aziontech/azionapi-v4-go-sdk-dev/azion-api"
+	"go.uber.org/zap"
+)
+
+// ImportIDs are the resources on Azion Platform adopted by a project
+type ImportIDs struct {
+	Applications []int64
+	Workload     int64
+	Firewalls    []int64
+}
+
+// ImportResult is the manifest describing the imported resources, along with the code of their functions
+// indexed by the path set for each function in the manifest
+type ImportResult struct {
+	Manifest *contracts.ManifestV4
+	Code     map[string]string
+}
+
+// ImportResources walks the given resources on Azion Platform along with the ones they refer to, tracking their
+// IDs in azion.json and describing them in a manifest. References between resources are written by name, the same
+// way they are declared by hand. The applications and firewalls a workload deploys are imported along with it.
+func (rc *ResourceContext) ImportResources(ids ImportIDs) (*ImportResult, error) {
+	im := &importer{
+		rc: rc,
+		result: &ImportResult{
+			Manifest: &contracts.ManifestV4{
+				Functions:    []contracts.Function{},
+				Applications: []contracts.Applications{},
+				Connectors:   []edgesdk.ConnectorRequest{},
+				Workloads:    []contracts.WorkloadManifest{},
+				Purge:        []contracts.PurgeManifest{},
+			},
+			Code: make(map[string]string),
+		},
+		applications: make(map[int64]string),
+		firewalls:    make(map[int64]string),
+		functions:    make(map[int64]string),
+		connectors:   make(map[int64]string),
+	}
+
+	applicationIDs, firewallIDs := ids.Applications, ids.Firewalls
+	var workload map[string]any
+	var deployments []map[string]any
+	if ids.Workload > 0 {
+		var err error
+		if workload, err = im.fetch(resourceRef{Kind: kindWorkload, ID: ids.Workload}); err != nil {
+			return nil, err
+		}
+		if deployments, err = im.children(resourceRef{Kind: kindDeployment, ParentID: ids.Workload}); err != nil {
+			return nil, err
+		}
+		for _, deployment := range deployments {
+			attributes := strategyAttributes(deployment)
+			applicationIDs = append(applicationIDs, idField(attributes, "application"))
+			firewallIDs = append(firewallIDs, idField(attributes, "firewall"))
+		}
+	}
+
+	for _, id := range applicationIDs {
+		if err := im.application(id); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range firewallIDs {
+		if err := im.firewall(id); err != nil {
+			return nil, err
+		}
+	}
+	if workload != nil {
+		im.workload(workload, deployments)
+	}
+
+	if rc.Conf.Name == "" {
+		switch {
+		case len(im.result.Manifest.Applications) > 0:
+			rc.Conf.Name = im.result.Manifest.Applications[0].Name
+		case len(im.result.Manifest.Workloads) > 0:
+			rc.Conf.Name = im.result.Manifest.Workloads[0].Name
+		}
+	}
+
+	return im.result, nil
+}
+
+// importer keeps the names given to the imported resources, so that references between them can be written by name
+type importer struct {
+	rc           *ResourceContext
+	result       *ImportResult
+	applications map[int64]string
+	firewalls    map[int64]string
+	functions    map[int64]string
+	connectors   map[int64]string
+}
+
+func (im *importer) application(appID int64) error {
+	if _, ok := im.applications[appID]; ok || appID == 0 {
+		return nil
+	}
+	rc := im.rc
+
+	state, err := im.fetch(resourceRef{Kind: kindApplication, ID: appID})
+	if err != nil {
+		return err
+	}
+	app := contracts.Applications{Rules: []contracts.ManifestRulesEngine{}, CacheSettings: []contracts.ManifestCacheSetting{}}
+	if err := decodeFields(state, &app); err != nil {
+		logger.Debug("Application fields not imported", zap.Int64("id", appID), zap.Error(err))
+	}
+	appConf := contracts.AzionJsonDataApplications{ID: appID, Name: app.Name}
+	im.applications[appID] = app.Name
+	im.imported(kindApplication, app.Name, appID)
+
+	caches, err := im.children(resourceRef{Kind: kindCacheSetting, ParentID: appID})
+	if err != nil {
+		return err
+	}
+	cacheNames := make(map[int64]string)
+	for _, cache := range caches {
+		cacheSetting := contracts.ManifestCacheSetting{}
+		if err := decodeFields(cache, &cacheSetting); err != nil {
+			logger.Debug("Cache Setting fields not imported", zap.String("name", cacheSetting.Name), zap.Error(err))
+		}
+		id := idField(cache, "id")
+		cacheNames[id] = cacheSetting.Name
+		app.CacheSettings = append(app.CacheSettings, cacheSetting)
+		appConf.CacheSettings = append(appConf.CacheSettings, contracts.AzionJsonDataCacheSettings{Id: id, Name: cacheSetting.Name})
+	}
+
+	instances, err := im.children(resourceRef{Kind: kindFunctionInstance, ParentID: appID})
+	if err != nil {
+		return err
+	}
+	instanceNames := make(map[int64]string)
+	for _, item := range instances {
+		instance, funcID, err := im.functionInstance(item)
+		if err != nil {
+			return err
+		}
+		id := idField(item, "id")
+		instanceNames[id] = instance.Name
+		app.FunctionsInstances = append(app.FunctionsInstances, instance)
+		rc.Conf.Function = append(rc.Conf.Function, contracts.AzionJsonDataFunction{
+			ID:            funcID,
+			Name:          instance.Name,
+			InstanceID:    id,
+			ApplicationID: appID,
+		})
+	}
+
+	for _, phase := range []string{"request", "response"} {
+		rules, err := im.children(resourceRef{Kind: kindRule, ParentID: appID, Phase: phase})
+		if err != nil {
+			return err
+		}
+		for _, state := range rules {
+			rule := contracts.ManifestRule{}
+			if err := decodeFields(state, &rule); err != nil {
+				return fmt.Errorf(msg.ErrorImportRule, rule.Name, err)
+			}
+			// created along with every application, the same way sync leaves them out
+			if rule.Name == "Default Rule" || rule.Name == "enable gzip" {
+				continue
+			}
+			for _, behavior := range rule.Behaviors {
+				id, ok := behaviorReference(behavior.Attributes)
+				if !ok {
+					continue
+				}
+				switch behavior.Type {
+				case "run_function":
+					nameReference(behavior.Attributes, instanceNames[id])
+				case "set_cache_policy":
+					nameReference(behavior.Attributes, cacheNames[id])
+				case "set_connector":
+					name, err := im.connector(id)
+					if err != nil {
+						return err
+					}
+					nameReference(behavior.Attributes, name)
+				}
+			}
+			id := idField(state, "id")
+			app.Rules = append(app.Rules, contracts.ManifestRulesEngine{Phase: phase, Rule: rule})
+			appConf.RulesEngine.Rules = append(appConf.RulesEngine.Rules, contracts.AzionJsonDataRules{Id: id, Name: rule.Name, Phase: phase})
+		}
+	}
+
+	im.result.Manifest.Applications = append(im.result.Manifest.Applications, app)
+	rc.Conf.Applications = append(rc.Conf.Applications, appConf)
+	if rc.Conf.Application.ID == 0 {
+		rc.Conf.Application.ID = appID
+		rc.Conf.Application.Name = app.Name
+		rc.Conf.CacheSettings = appConf.CacheSettings
+		rc.Conf.RulesEngine = appConf.RulesEngine
+	}
+	return nil
+}
+
+func (im *importer) firewall(firewallID int64) error {
+	if _, ok := im.firewalls[firewallID]; ok || firewallID == 0 {
+		return nil
+	}
+	rc := im.rc
+
+	state, err := im.fetch(resourceRef{Kind: kindFirewall, ID: firewallID})
+	if err != nil {
+		return err
+	}
+	fwMan := contracts.FirewallManifest{}
+	if err := decodeFields(state, &fwMan); err != nil {
+		logger.Debug("Firewall fields not imported", zap.Int64("id", firewallID), zap.Error(err))
+	}
+	fwConf := contracts.AzionJsonDataFirewall{Id: firewallID, Name: fwMan.Name}
+	im.firewalls[firewallID] = fwMan.Name
+	im.imported(kindFirewall, fwMan.Name, firewallID)
+
+	instances, err := im.children(resourceRef{Kind: kindFirewallFunctionInstance, ParentID: firewallID})
+	if err != nil {
+		return err
+	}
+	instanceNames := make(map[int64]string)
+	for _, item := range instances {
+		instance, funcID, err := im.functionInstance(item)
+		if err != nil {
+			return err
+		}
+		id := idField(item, "id")
+		instanceNames[id] = instance.Name
+		fwMan.FunctionsInstances = append(fwMan.FunctionsInstances, instance)
+		fwConf.FunctionInstances = append(fwConf.FunctionInstances, contracts.AzionJsonDataFirewallFunctionInstance{
+			Id:         id,
+			Name:       instance.Name,
+			FunctionId: funcID,
+			Args:       instance.Args,
+			Active:     instance.Active,
+		})
+	}
+
+	rules, err := im.children(resourceRef{Kind: kindFirewallRule, ParentID: firewallID})
+	if err != nil {
+		return err
+	}
+	for _, state := range rules {
+		rule := contracts.FirewallManifestRule{}
+		if err := decodeFields(state, &rule); err != nil {
+			return fmt.Errorf(msg.ErrorImportRule, rule.Name, err)
+		}
+		for _, behavior := range rule.Behaviors {
+			if id, ok := behaviorReference(behavior.Attributes); ok && behavior.Type == "run_function" {
+				nameReference(behavior.Attributes, instanceNames[id])
+			}
+		}
+		fwMan.RulesEngine = append(fwMan.RulesEngine, rule)
+		fwConf.Rules = append(fwConf.Rules, contracts.AzionJsonDataFirewallRule{Id: idField(state, "id"), Name: rule.Name})
+	}
+
+	im.result.Manifest.Firewalls = append(im.result.Manifest.Firewalls, fwMan)
+	rc.Conf.Firewalls = append(rc.Conf.Firewalls, fwConf)
+	return nil
+}
+
+// workload describes a workload and its deployments, which refer to the applications and firewalls imported before
+func (im *importer) workload(state map[string]any, deployments []map[string]any) {
+	rc := im.rc
+	workload := contracts.WorkloadManifest{}
+	if err := decodeFields(state, &workload); err != nil {
+		logger.Debug("Workload fields not imported", zap.String("name", workload.Name), zap.Error(err))
+	}
+	id := idField(state, "id")
+	rc.Conf.Workloads = contracts.AzionJsonDataWorkload{Id: id, Name: workload.Name, Domains: workload.Domains}
+	if domain, ok := state["workload_domain"].(string); ok {
+		rc.Conf.Workloads.Url = utils.Concat("https://", domain)
+	}
+	im.result.Manifest.Workloads = append(im.result.Manifest.Workloads, workload)
+	im.imported(kindWorkload, workload.Name, id)
+
+	for _, deployment := range deployments {
+		manifestDeployment := contracts.WorkloadDeployment{}
+		if err := decodeFields(withoutField(deployment, "strategy"), &manifestDeployment); err != nil {
+			logger.Debug("Workload Deployment fields not imported", zap.String("name", manifestDeployment.Name), zap.Error(err))
+		}
+		strategy, _ := deployment["strategy"].(map[string]any)
+		manifestDeployment.Strategy.Type, _ = strategy["type"].(string)
+		attributes := strategyAttributes(deployment)
+		if name, ok := im.applications[idField(attributes, "application")]; ok {
+			manifestDeployment.Strategy.Attributes.Application = &name
+		}
+		if name, ok := im.firewalls[idField(attributes, "firewall")]; ok {
+			manifestDeployment.Strategy.Attributes.Firewall = &name
+		}
+
+		im.result.Manifest.WorkloadDeployments = append(im.result.Manifest.WorkloadDeployments, manifestDeployment)
+		rc.Conf.Workloads.Deployments = append(rc.Conf.Workloads.Deployments, contracts.Deployments{
+			Id:   idField(deployment, "id"),
+			Name: manifestDeployment.Name,
+		})
+	}
+}
+
+// functionInstance describes a function instance, importing the function it runs. It returns the ID of the function.
+func (im *importer) functionInstance(state map[string]any) (contracts.FunctionInstance, int64, error) {
+	instance := contracts.FunctionInstance{}
+	if err := decodeFields(withoutField(state, "function"), &instance); err != nil {
+		logger.Debug("Function Instance fields not imported", zap.String("name", instance.Name), zap.Error(err))
+	}
+	funcID := idField(state, "function")
+	name, err := im.function(funcID)
+	if err != nil {
+		return instance, 0, err
+	}
+	instance.Function = contracts.FunctionReference{Name: name}
+	return instance, funcID, nil
+}
+
+// function describes a function once, keeping its code to be written to the path set in the manifest
+func (im *importer) function(funcID int64) (string, error) {
+	if name, ok := im.functions[funcID]; ok {
+		return name, nil
+	}
+
+	state, err := im.fetch(resourceRef{Kind: kindFunction, ID: funcID})
+	if err != nil {
+		return "", err
+	}
+	funcMan := contracts.Function{}
+	if err := decodeFields(withoutField(state, "bindings"), &funcMan); err != nil {
+		logger.Debug("Function fields not imported", zap.Int64("id", funcID), zap.Error(err))
+	}
+	splitBindings(&funcMan)
+	funcMan.Path = fmt.Sprintf("./functions/%s.js", functionFileName(funcMan.Name))
+	code, _ := state["code"].(string)
+	im.result.Code[funcMan.Path] = code
+
+	im.functions[funcID] = funcMan.Name
+	im.result.Manifest.Functions = append(im.result.Manifest.Functions, funcMan)
+	im.rc.Conf.Function = append(im.rc.Conf.Function, contracts.AzionJsonDataFunction{ID: funcID, Name: funcMan.Name})
+	im.imported(kindFunction, funcMan.Name, funcID)
+	return funcMan.Name, nil
+}
+
+// connector describes a connector once. Connectors the manifest cannot describe keep being referred to by ID.
+func (im *importer) connector(connectorID int64) (string, error) {
+	if name, ok := im.connectors[connectorID]; ok {
+		return name, nil
+	}
+
+	state, err := im.fetch(resourceRef{Kind: kindConnector, ID: connectorID})
+	if err != nil {
+		return "", err
+	}
+	connector := edgesdk.ConnectorRequest{}
+	if err := decodeState(state, &connector); err != nil {
+		logger.Debug("Connector not imported", zap.Int64("id", connectorID), zap.Error(err))
+		im.connectors[connectorID] = ""
+		return "", nil
+	}
+	name, _ := state["name"].(string)
+
+	im.connectors[connectorID] = name
+	im.result.Manifest.Connectors = append(im.result.Manifest.Connectors, connector)
+	im.rc.Conf.Connectors = append(im.rc.Conf.Connectors, contracts.AzionJsonDataConnectors{Id: connectorID, Name: name})
+	im.imported(kindConnector, name, connectorID)
+	return name, nil
+}
+
+func (im *importer) fetch(ref resourceRef) (map[string]any, error) {
+	state, err := im.rc.liveState(ref)
+	if err != nil {
+		logger.Debug("Error while fetching resource to import", zap.String("kind", ref.Kind), zap.Int64("id", ref.ID), zap.Error(err))
+		return nil, fmt.Errorf(msg.ErrorImportResource, kindNames[ref.Kind], ref.ID, err)
+	}
+	return stateMap(state), nil
+}
+
+// children lists the resources that belong to a parent, decoded from their JSON representation
+func (im *importer) children(ref resourceRef) ([]map[string]any, error) {
+	items, err := im.rc.liveChildren(ref)
+	if err != nil {
+		logger.Debug("Error while listing resources to import", zap.String("kind", ref.Kind), zap.Int64("parent", ref.ParentID), zap.Error(err))
+		return nil, err
+	}
+	children := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		children = append(children, stateMap(item))
+	}
+	return children, nil
+}
+
+func (im *importer) imported(kind, name string, id int64) {
+	msgf := fmt.Sprintf(msg.ManifestImportResource, kindNames[kind], name, id)
+	logger.FInfoFlags(im.rc.Factory.IOStreams.Out, msgf, im.rc.Factory.Format, im.rc.Factory.Out)
+	*im.rc.Msgs = append(*im.rc.Msgs, msgf)
+}
+
+// decodeFields decodes the remote state of a resource into its manifest type field by field, so that a field the
+// strict SDK request types reject is left out instead of the whole resource. It returns the first error found.
+func decodeFields(state map[string]any, dst any) error {
+	var firstErr error
+	value := reflect.ValueOf(dst).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		raw, ok := state[name]
+		if !ok || raw == nil || name == "" || name == "-" {
+			continue
+		}
+		if err := decodeState(raw, value.Field(i).Addr().Interface()); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return firstErr
+}
+
+// behaviorReference returns the ID a behavior refers to in its value attribute
+func behaviorReference(attributes map[string]interface{}) (int64, bool) {
+	id, ok := attributes["value"].(float64)
+	return int64(id), ok
+}
+
+// nameReference replaces the ID a behavior refers to with the name of the imported resource, when there is one
+func nameReference(attributes map[string]interface{}, name string) {
+	if name != "" {
+		attributes["value"] = name
+	}
+}
+
+func strategyAttributes(deployment map[string]any) map[string]any {
+	strategy, _ := deployment["strategy"].(map[string]any)
+	attributes, _ := strategy["attributes"].(map[string]any)
+	return attributes
+}
+
+func idField(state map[string]any, field string) int64 {
+	id, _ := state[field].(float64)
+	return int64(id)
+}
+
+func withoutField(state map[string]any, field string) map[string]any {
+	copied := make(map[string]any, len(state))
+	for key, value := range state {
+		if key != field {
+			copied[key] = value
+		}
+	}
+	return copied
+}
+
+// functionFileName turns the name of a function into a file name, replacing characters not allowed in paths
+func functionFileName(name string) string {
+	fileName := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ' ' || r == ':' {
+			return '-'
+		}
+		return r
+	}, name)
+	if fileName == "" {
+		return "function"
+	}
+	return fileName
+}
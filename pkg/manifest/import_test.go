@@ -0,0 +1,107 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportResources(t *testing.T) {
+	f, _, _ := testutils.NewFactory(nil)
+	msgs := []string{}
+	conf := &contracts.AzionApplicationOptions{}
+	rc := NewResourceContext(f, conf, &contracts.ManifestV4{}, "azion", &msgs, nil)
+
+	remote := map[string]map[int64]any{
+		kindWorkload:    {30: map[string]any{"id": 30, "name": "site", "domains": []any{"example.com"}, "workload_domain": "abc.map.azionedge.net"}},
+		kindApplication: {10: map[string]any{"id": 10, "name": "site", "active": true, "debug": false}},
+		kindFirewall:    {20: map[string]any{"id": 20, "name": "shield", "active": true}},
+		kindFunction: {1: map[string]any{"id": 1, "name": "handler", "runtime": "azion_js", "code": "export default {}", "active": true,
+			"default_args": map[string]any{"env": "prod", "bindings": map[string]any{"kv": map[string]any{"namespace": "flags"}}}}},
+	}
+	children := map[string][]any{
+		kindDeployment: {map[string]any{"id": 31, "name": "site", "active": true, "current": true, "strategy": map[string]any{
+			"type": "default", "attributes": map[string]any{"application": 10, "firewall": 20},
+		}}},
+		kindCacheSetting:     {map[string]any{"id": 100, "name": "assets"}},
+		kindFunctionInstance: {map[string]any{"id": 40, "name": "handler-instance", "function": 1, "active": true}},
+		kindRule + "request": {
+			map[string]any{"id": 200, "name": "Default Rule", "behaviors": []any{}},
+			map[string]any{"id": 201, "name": "run handler", "active": true, "behaviors": []any{
+				map[string]any{"type": "run_function", "attributes": map[string]any{"value": 40}},
+				map[string]any{"type": "set_cache_policy", "attributes": map[string]any{"value": 100}},
+			}},
+		},
+		kindFirewallFunctionInstance: {map[string]any{"id": 50, "name": "waf", "function": 1, "active": true}},
+		kindFirewallRule: {map[string]any{"id": 60, "name": "block", "behaviors": []any{
+			map[string]any{"type": "run_function", "attributes": map[string]any{"value": 50}},
+		}}},
+	}
+
+	fetched := map[string]int{}
+	rc.liveState = func(ref resourceRef) (any, error) {
+		fetched[ref.Kind]++
+		if state, ok := remote[ref.Kind][ref.ID]; ok {
+			return state, nil
+		}
+		return nil, utils.ErrorNotFound404
+	}
+	rc.liveChildren = func(ref resourceRef) ([]any, error) {
+		return children[ref.Kind+ref.Phase], nil
+	}
+
+	result, err := rc.ImportResources(ImportIDs{Workload: 30, Applications: []int64{10}})
+	require.NoError(t, err)
+	manifest := result.Manifest
+
+	// the function shared by both instances is imported once
+	require.Equal(t, 1, fetched[kindFunction])
+	// the bindings sent as default arguments are imported as bindings
+	require.Equal(t, []contracts.Function{{
+		Name:        "handler",
+		Path:        "./functions/handler.js",
+		Runtime:     "azion_js",
+		Active:      true,
+		DefaultArgs: map[string]interface{}{"env": "prod"},
+		Bindings:    contracts.FunctionBindings{KV: contracts.KVBinding{Namespace: "flags"}},
+	}}, manifest.Functions)
+	require.Equal(t, map[string]string{"./functions/handler.js": "export default {}"}, result.Code)
+
+	require.Len(t, manifest.Applications, 1)
+	app := manifest.Applications[0]
+	require.Equal(t, "site", app.Name)
+	require.Equal(t, []contracts.ManifestCacheSetting{{Name: "assets"}}, app.CacheSettings)
+	require.Equal(t, "handler", app.FunctionsInstances[0].Function.Name)
+	require.Len(t, app.Rules, 1)
+	require.Equal(t, "handler-instance", app.Rules[0].Rule.Behaviors[0].Attributes["value"])
+	require.Equal(t, "assets", app.Rules[0].Rule.Behaviors[1].Attributes["value"])
+
+	require.Len(t, manifest.Firewalls, 1)
+	require.Equal(t, "waf", manifest.Firewalls[0].RulesEngine[0].Behaviors[0].Attributes["value"])
+
+	require.Len(t, manifest.WorkloadDeployments, 1)
+	strategy := manifest.WorkloadDeployments[0].Strategy.Attributes
+	require.Equal(t, "site", *strategy.Application)
+	require.Equal(t, "shield", *strategy.Firewall)
+
+	require.Equal(t, "site", conf.Name)
+	require.Equal(t, int64(10), conf.Application.ID)
+	require.Equal(t, []contracts.AzionJsonDataRules{{Id: 201, Name: "run handler", Phase: "request"}}, conf.RulesEngine.Rules)
+	require.Equal(t, []contracts.AzionJsonDataFunction{
+		{ID: 1, Name: "handler"},
+		{ID: 1, Name: "handler-instance", InstanceID: 40, ApplicationID: 10},
+	}, conf.Function)
+	require.Equal(t, "https://abc.map.azionedge.net", conf.Workloads.Url)
+	require.Equal(t, []contracts.Deployments{{Id: 31, Name: "site"}}, conf.Workloads.Deployments)
+	require.Equal(t, int64(50), conf.Firewalls[0].FunctionInstances[0].Id)
+
+	t.Run("missing resource", func(t *testing.T) {
+		rc := NewResourceContext(f, &contracts.AzionApplicationOptions{}, &contracts.ManifestV4{}, "azion", &msgs, nil)
+		rc.liveState = func(ref resourceRef) (any, error) { return nil, utils.ErrorNotFound404 }
+		_, err := rc.ImportResources(ImportIDs{Applications: []int64{99}})
+		require.ErrorIs(t, err, utils.ErrorNotFound404)
+	})
+}
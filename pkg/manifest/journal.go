@@ -0,0 +1,315 @@
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	msg "github.com/aziontech/azion-cli/messages/manifest"
+	apiApplications "github.com/aziontech/azion-cli/pkg/api/applications"
+	apiCache "github.com/aziontech/azion-cli/pkg/api/cache_setting"
+	apiConnector "github.com/aziontech/azion-cli/pkg/api/connector"
+	apiFirewall "github.com/aziontech/azion-cli/pkg/api/firewall"
+	apiFirewallInstance "github.com/aziontech/azion-cli/pkg/api/firewall_instance"
+	functionsApi "github.com/aziontech/azion-cli/pkg/api/function"
+	apiWorkloads "github.com/aziontech/azion-cli/pkg/api/workloads"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/utils"
+	edgesdk "github.com/aziontech/azionapi-v4-go-sdk-dev/azion-api"
+	"go.uber.org/zap"
+)
+
+// journalEntry records a change made to the account while applying the manifest.
+// Request is the update request built from the manifest, and Previous holds the values the fields it sets had
+// before the update, as returned by the API.
+type journalEntry struct {
+	Action   string
+	Ref      resourceRef
+	Name     string
+	Request  any
+	Previous map[string]any
+}
+
+// journalCreate records a resource created while applying the manifest, so it is deleted on rollback
+func (rc *ResourceContext) journalCreate(ref resourceRef, name string) {
+	if rc.SkipRollback {
+		return
+	}
+	rc.journal = append(rc.journal, journalEntry{Action: ActionCreate, Ref: ref, Name: name})
+}
+
+// journalUpdate records the current value of the fields a resource is about to be updated with, so they are
+// restored on rollback. The state read while planning is reused, otherwise it is read before the update.
+// Resources whose state cannot be read are left as they are on rollback.
+func (rc *ResourceContext) journalUpdate(ref resourceRef, name string, request any) {
+	if rc.SkipRollback {
+		return
+	}
+	current, ok := rc.observed[ref]
+	if ok {
+		delete(rc.observed, ref)
+	} else {
+		var err error
+		current, err = callAPIWithResult(rc, func() (any, error) {
+			return rc.liveState(ref)
+		})
+		if err != nil {
+			logger.Debug("Failed to read the resource before updating it, it will not be restored on rollback",
+				zap.String("kind", ref.Kind), zap.Int64("id", ref.ID), zap.Error(err))
+			return
+		}
+	}
+	previous := writableState(stateMap(current), stateMap(request))
+	rc.journal = append(rc.journal, journalEntry{Action: ActionUpdate, Ref: ref, Name: name, Request: request, Previous: previous})
+}
+
+// writableState keeps the fields of a resource state that the update request sets. Those are the fields the
+// update changes, and the only ones known to be writable, unlike the IDs and audit fields returned by the API.
+func writableState(state, request map[string]any) map[string]any {
+	previous := make(map[string]any, len(request))
+	for field, value := range request {
+		current, ok := state[field]
+		if !ok {
+			continue
+		}
+		nestedRequest, isObject := value.(map[string]any)
+		nestedState, wasObject := current.(map[string]any)
+		if isObject && wasObject {
+			previous[field] = writableState(nestedState, nestedRequest)
+			continue
+		}
+		previous[field] = current
+	}
+	return previous
+}
+
+// snapshotConfig keeps a copy of azion.json as it was before applying the manifest
+func (rc *ResourceContext) snapshotConfig() {
+	original, err := json.Marshal(rc.Conf)
+	if err != nil {
+		logger.Debug("Failed to keep a copy of azion.json, it will not be restored on rollback", zap.Error(err))
+		return
+	}
+	rc.originalConf = original
+}
+
+// rollback undoes the changes found in the journal, newest first: created resources are deleted and updated
+// resources get their previous state back. When every change is undone azion.json is restored as well,
+// otherwise it keeps tracking the resources left behind and the returned error lists them.
+func (rc *ResourceContext) rollback(cause error) error {
+	if len(rc.journal) == 0 {
+		return cause
+	}
+
+	logger.FInfoFlags(rc.Factory.IOStreams.Out, msg.RollingBack, rc.Factory.Format, rc.Factory.Out)
+	*rc.Msgs = append(*rc.Msgs, msg.RollingBack)
+
+	failed := []string{}
+	for i := len(rc.journal) - 1; i >= 0; i-- {
+		entry := rc.journal[i]
+		kindName := kindNames[entry.Ref.Kind]
+
+		var err error
+		successMsg := msg.RollbackDelete
+		if entry.Action == ActionCreate {
+			err = rc.deleteResource(entry.Ref, entry.Name)
+		} else {
+			successMsg = msg.RollbackRestore
+			err = rc.restoreResource(entry)
+		}
+
+		if errors.Is(err, utils.ErrorNotFound404) {
+			logger.Debug("Resource not found. Skipping rollback", zap.String("name", entry.Name), zap.Int64("id", entry.Ref.ID))
+			continue
+		}
+		if err != nil {
+			logger.Debug("Error while rolling back resource", zap.String("name", entry.Name), zap.Int64("id", entry.Ref.ID), zap.Error(err))
+			failed = append(failed, fmt.Sprintf("%s %s (%s)", kindName, entry.Name, entry.Action))
+			continue
+		}
+
+		msgf := fmt.Sprintf(successMsg, kindName, entry.Name, entry.Ref.ID)
+		logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
+		*rc.Msgs = append(*rc.Msgs, msgf)
+	}
+	rc.journal = nil
+
+	if len(failed) > 0 {
+		return fmt.Errorf(msg.ErrorRollback, cause, strings.Join(failed, ", "))
+	}
+
+	if rc.originalConf != nil {
+		original := contracts.AzionApplicationOptions{}
+		if err := json.Unmarshal(rc.originalConf, &original); err != nil {
+			return err
+		}
+		*rc.Conf = original
+		if err := rc.WriteConfigFunc(rc.Conf, rc.ProjectConf); err != nil {
+			logger.Debug("Error while restoring azion.json file", zap.Error(err))
+			return err
+		}
+	}
+
+	logger.FInfoFlags(rc.Factory.IOStreams.Out, msg.RollbackDone, rc.Factory.Format, rc.Factory.Out)
+	*rc.Msgs = append(*rc.Msgs, msg.RollbackDone)
+	return cause
+}
+
+// deleteResource removes a resource created while applying the manifest
+func (rc *ResourceContext) deleteResource(ref resourceRef, name string) error {
+	switch ref.Kind {
+	case kindStorage:
+		return rc.StorageClient.DeleteBucket(rc.Ctx, name)
+	case kindKV:
+		return rc.KVClient.Delete(rc.Ctx, name)
+	case kindFunction:
+		return rc.FunctionClient.Delete(rc.Ctx, ref.ID)
+	case kindApplication:
+		return rc.ApplicationClient.Delete(rc.Ctx, ref.ID)
+	case kindFunctionInstance:
+		return rc.ApplicationClient.DeleteFunctionInstance(rc.Ctx, ref.ParentID, ref.ID)
+	case kindCacheSetting:
+		_, err := rc.CacheClient.Delete(rc.Ctx, ref.ParentID, ref.ID)
+		return err
+	case kindRule:
+		var err error
+		if ref.Phase == "response" {
+			_, err = rc.ApplicationClient.DeleteRulesEngineResponse(rc.Ctx, ref.ParentID, ref.Phase, ref.ID)
+		} else {
+			_, err = rc.ApplicationClient.DeleteRulesEngineRequest(rc.Ctx, ref.ParentID, "request", ref.ID)
+		}
+		return err
+	case kindConnector:
+		return rc.ConnectorClient.Delete(rc.Ctx, ref.ID)
+	case kindWorkload:
+		return rc.WorkloadClient.Delete(rc.Ctx, ref.ID)
+	case kindDeployment:
+		return rc.WorkloadClient.DeleteDeployment(rc.Ctx, ref.ParentID, ref.ID)
+	case kindFirewall:
+		return rc.FirewallClient.Delete(rc.Ctx, ref.ID)
+	case kindFirewallRule:
+		return rc.FirewallClient.DeleteRule(rc.Ctx, ref.ParentID, ref.ID)
+	case kindFirewallFunctionInstance:
+		return rc.FirewallFunctionInstClient.Delete(rc.Ctx, ref.ParentID, ref.ID)
+	}
+	return fmt.Errorf("unknown resource kind %q", ref.Kind)
+}
+
+// restoreResource sends back the values the fields of a resource had before being updated
+func (rc *ResourceContext) restoreResource(entry journalEntry) error {
+	ref, previous := entry.Ref, entry.Previous
+	switch ref.Kind {
+	case kindFunction:
+		request := functionsApi.UpdateRequest{}
+		if err := decodeState(previous, &request.PatchedFunctionsRequest); err != nil {
+			return err
+		}
+		_, err := rc.FunctionClient.Update(rc.Ctx, &request, ref.ID)
+		return err
+	case kindApplication:
+		request := apiApplications.UpdateRequest{Id: ref.ID}
+		if err := decodeState(previous, &request.PatchedApplicationRequest); err != nil {
+			return err
+		}
+		_, err := rc.ApplicationClient.Update(rc.Ctx, &request)
+		return err
+	case kindFunctionInstance:
+		request := apiApplications.UpdateInstanceRequest{}
+		if err := decodeState(previous, &request.PatchedFunctionInstanceRequest); err != nil {
+			return err
+		}
+		_, err := rc.ApplicationClient.UpdateInstance(rc.Ctx, &request, ref.ParentID, ref.ID)
+		return err
+	case kindCacheSetting:
+		request := apiCache.RequestUpdate{}
+		if err := decodeState(previous, &request.PatchedCacheSettingRequest); err != nil {
+			return err
+		}
+		_, err := rc.CacheClient.Update(rc.Ctx, &request, ref.ParentID, ref.ID)
+		return err
+	case kindRule:
+		if ref.Phase == "response" {
+			request := apiApplications.UpdateRulesEngineResponse{IdApplication: ref.ParentID, Phase: ref.Phase, Id: ref.ID}
+			if err := decodeState(previous, &request.PatchedResponsePhaseRuleRequest); err != nil {
+				return err
+			}
+			_, err := rc.ApplicationClient.UpdateRulesEngineResponse(rc.Ctx, &request)
+			return err
+		}
+		request := apiApplications.UpdateRulesEngineRequest{IdApplication: ref.ParentID, Phase: "request", Id: ref.ID}
+		if err := decodeState(previous, &request.PatchedRequestPhaseRule); err != nil {
+			return err
+		}
+		_, err := rc.ApplicationClient.UpdateRulesEngineRequest(rc.Ctx, &request)
+		return err
+	case kindConnector:
+		// the restored fields are sent as the same kind of connector the update was
+		updated, ok := entry.Request.(edgesdk.PatchedConnectorRequest)
+		if !ok {
+			return fmt.Errorf("unexpected request for connector %d", ref.ID)
+		}
+		request := apiConnector.NewUpdateRequest()
+		if updated.PatchedConnectorHTTPRequest != nil {
+			body := edgesdk.PatchedConnectorHTTPRequest{}
+			if err := decodeState(previous, &body); err != nil {
+				return err
+			}
+			request.PatchedConnectorHTTPRequest = &body
+		} else {
+			body := edgesdk.PatchedConnectorRequestBase{}
+			if err := decodeState(previous, &body); err != nil {
+				return err
+			}
+			request.PatchedConnectorRequest.PatchedConnectorRequestBase = &body
+		}
+		_, err := rc.ConnectorClient.Update(rc.Ctx, request, ref.ID)
+		return err
+	case kindWorkload:
+		request := apiWorkloads.UpdateRequest{Id: ref.ID}
+		if err := decodeState(previous, &request.PatchedWorkloadRequest); err != nil {
+			return err
+		}
+		_, err := rc.WorkloadClient.Update(rc.Ctx, &request)
+		return err
+	case kindDeployment:
+		request := edgesdk.PatchedWorkloadDeploymentRequest{}
+		if err := decodeState(previous, &request); err != nil {
+			return err
+		}
+		_, err := rc.WorkloadClient.UpdateDeployment(rc.Ctx, request, ref.ParentID, ref.ID)
+		return err
+	case kindFirewall:
+		request := apiFirewall.NewUpdateRequest()
+		if err := decodeState(previous, &request.PatchedFirewallRequest); err != nil {
+			return err
+		}
+		_, err := rc.FirewallClient.Update(rc.Ctx, request, ref.ID)
+		return err
+	case kindFirewallRule:
+		request := edgesdk.PatchedFirewallRuleRequest{}
+		if err := decodeState(previous, &request); err != nil {
+			return err
+		}
+		_, err := rc.FirewallClient.UpdateRule(rc.Ctx, ref.ParentID, ref.ID, request)
+		return err
+	case kindFirewallFunctionInstance:
+		request := apiFirewallInstance.NewUpdateRequest()
+		if err := decodeState(previous, &request.PatchedFirewallFunctionInstanceRequest); err != nil {
+			return err
+		}
+		_, err := rc.FirewallFunctionInstClient.Update(rc.Ctx, ref.ParentID, ref.ID, request)
+		return err
+	}
+	return fmt.Errorf("unknown resource kind %q", ref.Kind)
+}
+
+// decodeState fills an update request with the fields of a resource as returned by the API
+func decodeState(state any, request any) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, request)
+}
@@ -0,0 +1,239 @@
+package manifest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/httpmock"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	edgesdk "github.com/aziontech/azionapi-v4-go-sdk-dev/azion-api"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRollback(t *testing.T) {
+	logger.New(zapcore.DebugLevel)
+	cause := errors.New("failed to create rule")
+
+	t.Run("created resources are deleted and azion.json is restored", func(t *testing.T) {
+		mock := &httpmock.Registry{}
+		mock.Register(
+			httpmock.REST("DELETE", "workspace/applications/1234/request_rules/3456"),
+			httpmock.StatusStringResponse(204, ""),
+		)
+		f, _, _ := testutils.NewFactory(mock)
+		msgs := []string{}
+
+		var written *contracts.AzionApplicationOptions
+		writeConf := func(conf *contracts.AzionApplicationOptions, confPath string) error {
+			written = conf
+			return nil
+		}
+
+		rc := NewResourceContext(f, &contracts.AzionApplicationOptions{Name: "project"}, &contracts.ManifestV4{}, "azion", &msgs, writeConf)
+		rc.snapshotConfig()
+		rc.Conf.Name = "changed"
+		rc.journalCreate(resourceRef{Kind: kindRule, ID: 3456, ParentID: 1234, Phase: "request"}, "cache assets")
+
+		err := rc.rollback(cause)
+		require.ErrorIs(t, err, cause)
+		require.NotNil(t, written)
+		require.Equal(t, "project", written.Name)
+		require.Contains(t, msgs, "Rollback: Rule Engine cache assets with id 3456 deleted\n")
+		require.Empty(t, rc.journal)
+	})
+
+	t.Run("changes that could not be undone are reported", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(&httpmock.Registry{})
+		msgs := []string{}
+
+		written := false
+		writeConf := func(conf *contracts.AzionApplicationOptions, confPath string) error {
+			written = true
+			return nil
+		}
+
+		rc := NewResourceContext(f, &contracts.AzionApplicationOptions{}, &contracts.ManifestV4{}, "azion", &msgs, writeConf)
+		rc.snapshotConfig()
+		rc.journalCreate(resourceRef{Kind: kindFunction, ID: 2}, "handler")
+
+		err := rc.rollback(cause)
+		require.ErrorIs(t, err, cause)
+		require.ErrorContains(t, err, "Function handler (create)")
+		require.False(t, written)
+	})
+
+	t.Run("nothing is recorded when rollback is disabled", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(nil)
+		msgs := []string{}
+
+		rc := NewResourceContext(f, &contracts.AzionApplicationOptions{}, &contracts.ManifestV4{}, "azion", &msgs, nil)
+		rc.SkipRollback = true
+		rc.liveState = func(ref resourceRef) (any, error) {
+			t.Fatalf("unexpected request for %s %d", ref.Kind, ref.ID)
+			return nil, nil
+		}
+		rc.journalCreate(resourceRef{Kind: kindFunction, ID: 1}, "handler")
+		rc.journalUpdate(resourceRef{Kind: kindFunction, ID: 2}, "other", map[string]any{"name": "other"})
+
+		require.Empty(t, rc.journal)
+		require.Equal(t, cause, rc.rollback(cause))
+	})
+
+	t.Run("updated resources keep the previous value of the fields updated", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(nil)
+		msgs := []string{}
+
+		rc := NewResourceContext(f, &contracts.AzionApplicationOptions{}, &contracts.ManifestV4{}, "azion", &msgs, nil)
+		rc.liveState = func(ref resourceRef) (any, error) {
+			return map[string]any{
+				"id":            2,
+				"name":          "before",
+				"last_modified": "2024-01-01T00:00:00Z",
+				"modules":       map[string]any{"cache": map[string]any{"enabled": false, "read_only": true}},
+			}, nil
+		}
+		request := map[string]any{"name": "after", "active": true, "modules": map[string]any{"cache": map[string]any{"enabled": true}}}
+		rc.journalUpdate(resourceRef{Kind: kindApplication, ID: 2}, "app", request)
+
+		require.Len(t, rc.journal, 1)
+		require.Equal(t, map[string]any{
+			"name":    "before",
+			"modules": map[string]any{"cache": map[string]any{"enabled": false}},
+		}, rc.journal[0].Previous)
+	})
+
+	t.Run("states read while planning are not read again", func(t *testing.T) {
+		f, _, _ := testutils.NewFactory(nil)
+		msgs := []string{}
+
+		rc := NewResourceContext(f, &contracts.AzionApplicationOptions{}, &contracts.ManifestV4{}, "azion", &msgs, nil)
+		rc.liveState = func(ref resourceRef) (any, error) {
+			t.Fatalf("unexpected request for %s %d", ref.Kind, ref.ID)
+			return nil, nil
+		}
+		ref := resourceRef{Kind: kindFunction, ID: 2}
+		rc.observed[ref] = map[string]any{"id": 2, "name": "before"}
+		rc.journalUpdate(ref, "handler", map[string]any{"name": "after"})
+
+		require.Len(t, rc.journal, 1)
+		require.Equal(t, map[string]any{"name": "before"}, rc.journal[0].Previous)
+		require.Empty(t, rc.observed)
+	})
+}
+
+func TestRollbackUpdate(t *testing.T) {
+	logger.New(zapcore.DebugLevel)
+	cause := errors.New("failed to update rule")
+
+	t.Run("application is restored with the fields updated", func(t *testing.T) {
+		var payload map[string]interface{}
+		mock := &httpmock.Registry{}
+		mock.Register(
+			httpmock.REST("PATCH", "workspace/applications/1234"),
+			httpmock.RESTPayload(200, applicationResponse, func(body map[string]interface{}) {
+				payload = body
+			}),
+		)
+		f, _, _ := testutils.NewFactory(mock)
+		msgs := []string{}
+
+		rc := NewResourceContext(f, &contracts.AzionApplicationOptions{}, &contracts.ManifestV4{}, "azion", &msgs,
+			func(conf *contracts.AzionApplicationOptions, confPath string) error { return nil })
+		rc.liveState = func(ref resourceRef) (any, error) {
+			return map[string]any{
+				"id":              1234,
+				"name":            "before",
+				"active":          true,
+				"debug":           false,
+				"last_editor":     "someone",
+				"last_modified":   "2024-01-01T00:00:00Z",
+				"product_version": "1.0",
+			}, nil
+		}
+		request := edgesdk.PatchedApplicationRequest{}
+		request.SetName("after")
+		request.SetActive(false)
+		rc.journalUpdate(resourceRef{Kind: kindApplication, ID: 1234}, "after", request)
+
+		err := rc.rollback(cause)
+		require.Equal(t, cause, err)
+		require.Equal(t, map[string]interface{}{"name": "before", "active": true}, payload)
+		require.Contains(t, msgs, "Rollback: Application after with id 1234 restored\n")
+		mock.Verify(t)
+	})
+
+	t.Run("connector is restored as the same kind of connector", func(t *testing.T) {
+		var payload map[string]interface{}
+		mock := &httpmock.Registry{}
+		mock.Register(
+			httpmock.REST("PATCH", "workspace/connectors/4444"),
+			httpmock.RESTPayload(200, connectorResponse, func(body map[string]interface{}) {
+				payload = body
+			}),
+		)
+		f, _, _ := testutils.NewFactory(mock)
+		msgs := []string{}
+
+		rc := NewResourceContext(f, &contracts.AzionApplicationOptions{}, &contracts.ManifestV4{}, "azion", &msgs,
+			func(conf *contracts.AzionApplicationOptions, confPath string) error { return nil })
+		rc.liveState = func(ref resourceRef) (any, error) {
+			return map[string]any{
+				"id":            4444,
+				"name":          "origin",
+				"type":          "http",
+				"active":        false,
+				"last_editor":   "someone",
+				"last_modified": "2024-01-01T00:00:00Z",
+			}, nil
+		}
+		body := edgesdk.PatchedConnectorHTTPRequest{}
+		body.SetName("origin")
+		body.SetType("http")
+		body.SetActive(true)
+		rc.journalUpdate(resourceRef{Kind: kindConnector, ID: 4444}, "origin", edgesdk.PatchedConnectorRequest{PatchedConnectorHTTPRequest: &body})
+
+		err := rc.rollback(cause)
+		require.ErrorIs(t, err, cause)
+		require.Equal(t, map[string]interface{}{"name": "origin", "type": "http", "active": false}, payload)
+		mock.Verify(t)
+	})
+}
+
+const applicationResponse = `{
+  "state": "executed",
+  "data": {
+    "id": 1234,
+    "name": "before",
+    "last_editor": "someone",
+    "last_modified": "2024-01-01T00:00:00Z",
+    "modules": {
+      "cache": {"enabled": true},
+      "functions": {"enabled": true},
+      "application_accelerator": {"enabled": false},
+      "image_processor": {"enabled": false}
+    },
+    "active": true,
+    "debug": false,
+    "product_version": "1.0"
+  }
+}`
+
+const connectorResponse = `{
+  "state": "executed",
+  "data": {
+    "id": 4444,
+    "name": "origin",
+    "type": "http",
+    "active": false,
+    "last_editor": "someone",
+    "last_modified": "2024-01-01T00:00:00Z",
+    "product_version": "1.0",
+    "attributes": {
+      "addresses": [{"address": "origin.example.com", "active": true, "http_port": 80, "https_port": 443}],
+      "connection_options": {"dns_resolution": "both", "transport_policy": "preserve", "host": "${host}"}
+    }
+  }
+}`
@@ -0,0 +1,153 @@
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	msg "github.com/aziontech/azion-cli/messages/manifest"
+	apiKV "github.com/aziontech/azion-cli/pkg/api/kv"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/kv/records"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/workers"
+	"github.com/aziontech/azion-cli/utils"
+	"go.uber.org/zap"
+)
+
+// ApplyKV makes sure every KV namespace of the manifest exists and is tracked in azion.json. Namespaces that
+// already exist, such as the ones created by hand, are adopted as they are; the others are created and get
+// the records of their data file, so the keys written by the application are never overwritten.
+func (rc *ResourceContext) ApplyKV(namespaces []contracts.KVManifest) error {
+	for _, kvMan := range namespaces {
+		err := rc.callAPI(func() error {
+			_, err := rc.KVClient.Get(rc.Ctx, kvMan.Name)
+			return err
+		})
+		if err == nil {
+			rc.trackKV(kvMan.Name)
+			msgf := fmt.Sprintf(msg.ManifestExistingKV, kvMan.Name)
+			logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
+			*rc.Msgs = append(*rc.Msgs, msgf)
+			continue
+		}
+		if !errors.Is(err, utils.ErrorNotFound404) {
+			return err
+		}
+
+		request := apiKV.CreateRequest{}
+		request.SetName(kvMan.Name)
+		err = rc.callAPI(func() error {
+			_, err := rc.KVClient.Create(rc.Ctx, request)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		rc.journalCreate(resourceRef{Kind: kindKV}, kvMan.Name)
+		rc.trackKV(kvMan.Name)
+		msgf := fmt.Sprintf(msg.ManifestCreateKV, kvMan.Name)
+		logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
+		*rc.Msgs = append(*rc.Msgs, msgf)
+
+		if kvMan.Data == "" {
+			continue
+		}
+		// the keys are written concurrently, so the state lock is released for the whole file
+		var written int
+		err = rc.unlocked(func() error {
+			var seedErr error
+			written, seedErr = seedKV(rc.Ctx, rc.KVClient, kvMan)
+			return seedErr
+		})
+		if err != nil {
+			return fmt.Errorf(msg.ErrorSeedKV, kvMan.Data, kvMan.Name, err)
+		}
+		msgf = fmt.Sprintf(msg.ManifestSeedKV, written, kvMan.Data, kvMan.Name)
+		logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
+		*rc.Msgs = append(*rc.Msgs, msgf)
+	}
+
+	return rc.WriteConfig()
+}
+
+func (rc *ResourceContext) trackKV(name string) {
+	tracked := slices.ContainsFunc(rc.Conf.KV, func(kv contracts.AzionJsonDataKV) bool {
+		return kv.Name == name
+	})
+	if !tracked {
+		rc.Conf.KV = append(rc.Conf.KV, contracts.AzionJsonDataKV{Name: name})
+	}
+}
+
+// seedKV writes the records of the data file of a namespace, several at a time. Unlike kv import, a malformed
+// record fails the apply, so the namespace just created is rolled back instead of left partially seeded.
+func seedKV(ctx context.Context, client *apiKV.Client, kvMan contracts.KVManifest) (int, error) {
+	format, err := records.FormatOf(kvMan.Data, "")
+	if err != nil {
+		return 0, err
+	}
+	file, err := os.Open(kvMan.Data)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	pending := make(chan records.Record)
+	var (
+		mu       sync.Mutex
+		written  int
+		firstErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers.CalculateOptimal(0); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for record := range pending {
+				if record.Err != nil {
+					fail(fmt.Errorf(msg.ErrorSeedKVRecord, record.Line, record.Item.Key, record.Err))
+					continue
+				}
+				request := apiKV.PutItemRequest{
+					Value:      record.Item.Value,
+					Expiration: record.Item.Expiration,
+					Metadata:   record.Item.Metadata,
+				}
+				err := workers.RetryRateLimited(func() error {
+					return client.PutItem(ctx, kvMan.Name, record.Item.Key, request)
+				})
+				if err != nil {
+					logger.Debug("Error while seeding a KV namespace", zap.String("key", record.Item.Key), zap.Error(err))
+					fail(fmt.Errorf(msg.ErrorSeedKVRecord, record.Line, record.Item.Key, err))
+					continue
+				}
+				mu.Lock()
+				written++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	readErr := records.Read(file, format, func(record records.Record) {
+		pending <- record
+	})
+	close(pending)
+	wg.Wait()
+
+	if readErr != nil {
+		return written, readErr
+	}
+	return written, firstErr
+}
@@ -39,6 +39,8 @@ type ManifestInterpreter struct {
 	FileReader            func(path string) ([]byte, error)
 	GetWorkDir            func() (string, error)
 	WriteAzionJsonContent func(conf *contracts.AzionApplicationOptions, confPath string) error
+	// SkipRollback keeps the changes made so far when creating the resources fails
+	SkipRollback bool
 }
 
 func NewManifestInterpreter() *ManifestInterpreter {
@@ -86,115 +88,14 @@ func (man *ManifestInterpreter) CreateResources(conf *contracts.AzionApplication
 	defer s.Stop()
 
 	rc := NewResourceContext(f, conf, manifest, projectConf, msgs, man.WriteAzionJsonContent)
+	rc.SkipRollback = man.SkipRollback
 
-	if len(manifest.Functions) > 0 {
-		start := time.Now()
-		if err := rc.ApplyFunctions(manifest.Functions); err != nil {
-			return err
-		}
-		if GlobalTimingCallback != nil {
-			GlobalTimingCallback("ManifestFunctions", time.Since(start))
-		}
-	}
-
-	if len(manifest.Applications) > 0 && len(manifest.Applications[0].FunctionsInstances) > 0 {
-		logger.Debug("Applying function instances")
-		start := time.Now()
-		if err := rc.ApplyFunctionInstances(manifest.Applications[0].FunctionsInstances); err != nil {
-			return err
-		}
-		if GlobalTimingCallback != nil {
-			GlobalTimingCallback("ManifestFunctionInstances", time.Since(start))
-		}
-	}
-
-	if len(manifest.Applications) > 0 {
-		edgeappman := manifest.Applications[0]
-		logger.Debug("Applying edge application")
-		start := time.Now()
-		if err := rc.ApplyEdgeApplication(edgeappman); err != nil {
-			return err
-		}
-		if GlobalTimingCallback != nil {
-			GlobalTimingCallback("ManifestEdgeApplication", time.Since(start))
-		}
-
-		if len(edgeappman.CacheSettings) > 0 {
-			logger.Debug("Applying cache settings")
-			start := time.Now()
-			if err := rc.ApplyCacheSettings(edgeappman.CacheSettings); err != nil {
-				return err
-			}
-			if GlobalTimingCallback != nil {
-				GlobalTimingCallback("ManifestCacheSettings", time.Since(start))
-			}
-		}
-
-		if len(manifest.Connectors) > 0 {
-			logger.Debug("Applying connectors")
-			start := time.Now()
-			if err := rc.ApplyConnectors(manifest.Connectors); err != nil {
-				return err
-			}
-			if GlobalTimingCallback != nil {
-				GlobalTimingCallback("ManifestConnectors", time.Since(start))
-			}
-		}
-
-		if len(edgeappman.Rules) > 0 {
-			logger.Debug("Applying rules engine")
-			start := time.Now()
-			if err := rc.ApplyRulesEngine(edgeappman.Rules); err != nil {
-				return err
-			}
-			if GlobalTimingCallback != nil {
-				GlobalTimingCallback("ManifestRulesEngine", time.Since(start))
-			}
-		}
-	}
-
-	if len(manifest.Workloads) > 0 {
-		logger.Debug("Applying workloads")
-		start := time.Now()
-		if err := rc.ApplyWorkloads(manifest.Workloads); err != nil {
-			return err
-		}
-		if GlobalTimingCallback != nil {
-			GlobalTimingCallback("ManifestWorkloads", time.Since(start))
-		}
-	}
-
-	if len(manifest.WorkloadDeployments) > 0 {
-		logger.Debug("Applying workload deployments")
-		start := time.Now()
-		if err := rc.ApplyWorkloadDeployments(manifest.WorkloadDeployments); err != nil {
-			return err
-		}
-		if GlobalTimingCallback != nil {
-			GlobalTimingCallback("ManifestWorkloadDeployments", time.Since(start))
-		}
-	}
-
-	if len(manifest.Firewalls) > 0 {
-		logger.Debug("Applying firewalls")
-		start := time.Now()
-		if err := rc.ApplyFirewalls(manifest.Firewalls); err != nil {
-			return err
-		}
-		if GlobalTimingCallback != nil {
-			GlobalTimingCallback("ManifestFirewalls", time.Since(start))
-		}
+	if err := ValidateApplications(manifest.Applications); err != nil {
+		return err
 	}
 
-	if len(manifest.Purge) > 0 {
-		logger.Debug("Applying purge")
-		start := time.Now()
-		if err := rc.ApplyPurge(manifest.Purge); err != nil {
-			return err
-		}
-		if GlobalTimingCallback != nil {
-			GlobalTimingCallback("ManifestPurge", time.Since(start))
-		}
+	if _, err := rc.ApplyManifest(false); err != nil {
+		return err
 	}
 
 	CacheIds = rc.CacheIds
@@ -212,17 +113,21 @@ func (man *ManifestInterpreter) CreateResources(conf *contracts.AzionApplication
 }
 
 func deleteResources(ctx context.Context, f *cmdutil.Factory, conf *contracts.AzionApplicationOptions, msgs *[]string) error {
-	client := apiApplications.NewClient(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
-	clientCache := apiCache.NewClientV4(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
-	// clientOrigin := apiOrigin.NewClient(f.HttpClient, f.Config.GetString("api_url"), f.Config.GetString("token"))
-
 	if conf.SkipDeletion != nil && *conf.SkipDeletion {
 		logger.FInfoFlags(f.IOStreams.Out, msg.SkipDeletion, f.Format, f.Out)
 		*msgs = append(*msgs, msg.SkipDeletion)
 		return nil
 	}
 
-	for _, value := range RuleIds {
+	return deleteApplicationResources(ctx, f, conf.Application.ID, CacheIds, RuleIds, msgs)
+}
+
+// deleteApplicationResources deletes the given rules and cache settings from the application with the given ID
+func deleteApplicationResources(ctx context.Context, f *cmdutil.Factory, applicationID int64, cacheIds map[string]int64, ruleIds map[string]contracts.RuleIdsStruct, msgs *[]string) error {
+	client := apiApplications.NewClient(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
+	clientCache := apiCache.NewClientV4(f.HttpClient, f.Config.GetString("api_v4_url"), f.Config.GetString("token"))
+
+	for _, value := range ruleIds {
 		//since until [UXE-3599] was carried out we'd only cared about "request" phase, this check guarantees that if Phase is empty
 		// we are probably dealing with a rule engine from a previous version
 		phase := "request"
@@ -233,9 +138,9 @@ func deleteResources(ctx context.Context, f *cmdutil.Factory, conf *contracts.Az
 		var err error
 		switch phase {
 		case "request":
-			statusInt, err = client.DeleteRulesEngineRequest(ctx, conf.Application.ID, phase, value.Id)
+			statusInt, err = client.DeleteRulesEngineRequest(ctx, applicationID, phase, value.Id)
 		case "response":
-			statusInt, err = client.DeleteRulesEngineResponse(ctx, conf.Application.ID, phase, value.Id)
+			statusInt, err = client.DeleteRulesEngineResponse(ctx, applicationID, phase, value.Id)
 		default:
 			return msgrule.ErrorInvalidPhase
 		}
@@ -252,8 +157,8 @@ func deleteResources(ctx context.Context, f *cmdutil.Factory, conf *contracts.Az
 		*msgs = append(*msgs, msgf)
 	}
 
-	for _, value := range CacheIds {
-		status, err := clientCache.Delete(ctx, conf.Application.ID, value)
+	for _, value := range cacheIds {
+		status, err := clientCache.Delete(ctx, applicationID, value)
 		if status == 404 {
 			logger.Debug("Cache Setting not found. Skipping delete")
 			continue
@@ -0,0 +1,351 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	msg "github.com/aziontech/azion-cli/messages/manifest"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/utils"
+	"go.uber.org/zap"
+)
+
+// resource kinds tracked while applying the manifest
+const (
+	kindFunction                 = "function"
+	kindFunctionInstance         = "function-instance"
+	kindConnector                = "connector"
+	kindDeployment               = "deployment"
+	kindFirewall                 = "firewall"
+	kindFirewallRule             = "firewall-rule"
+	kindFirewallFunctionInstance = "firewall-function-instance"
+)
+
+// trackedResources is a snapshot of the resources found in azion.json before the manifest was applied.
+// Workloads are updated in place while storage buckets and KV namespaces hold user data, so none of them is
+// ever pruned.
+type trackedResources struct {
+	Functions   []contracts.AzionJsonDataFunction
+	Connectors  []contracts.AzionJsonDataConnectors
+	Deployments []contracts.Deployments
+	Firewalls   []contracts.AzionJsonDataFirewall
+}
+
+// orphanedResources lists the tracked resources that are no longer declared in the manifest
+type orphanedResources struct {
+	Deployments               []contracts.Deployments
+	FunctionInstances         []contracts.AzionJsonDataFunction
+	Applications              []contracts.AzionJsonDataApplications
+	FirewallRules             []firewallRuleIdRef
+	FirewallFunctionInstances []firewallFunctionInstIdRef
+	Firewalls                 []contracts.AzionJsonDataFirewall
+	Connectors                []contracts.AzionJsonDataConnectors
+	Functions                 []int64
+}
+
+func (rc *ResourceContext) snapshotTrackedResources() {
+	rc.tracked = trackedResources{
+		Functions:   slices.Clone(rc.Conf.Function),
+		Connectors:  slices.Clone(rc.Conf.Connectors),
+		Deployments: slices.Clone(rc.Conf.Workloads.Deployments),
+		Firewalls:   slices.Clone(rc.Conf.Firewalls),
+	}
+}
+
+// markApplied records that the resource with the given ID was created or updated from the manifest
+func (rc *ResourceContext) markApplied(kind string, id int64) {
+	if rc.applied[kind] == nil {
+		rc.applied[kind] = make(map[int64]bool)
+	}
+	rc.applied[kind][id] = true
+}
+
+func (rc *ResourceContext) isApplied(kind string, id int64) bool {
+	return rc.applied[kind][id]
+}
+
+// orphanedResources compares the resources tracked in azion.json with the ones applied from the manifest.
+// A tracked resource is an orphan when it was neither applied nor is declared by name in the manifest.
+func (rc *ResourceContext) orphanedResources() orphanedResources {
+	orphans := orphanedResources{}
+	manifest := rc.Manifest
+	if manifest == nil {
+		manifest = &contracts.ManifestV4{}
+	}
+
+	deploymentNames := make(map[string]bool)
+	for _, deployment := range manifest.WorkloadDeployments {
+		deploymentNames[deployment.Name] = true
+	}
+	for _, deployment := range rc.tracked.Deployments {
+		if deployment.Id > 0 && !rc.isApplied(kindDeployment, deployment.Id) && !deploymentNames[deployment.Name] {
+			orphans.Deployments = append(orphans.Deployments, deployment)
+		}
+	}
+
+	appNames := make(map[string]bool)
+	instanceNames := make(map[string]bool)
+	for _, app := range manifest.Applications {
+		appNames[app.Name] = true
+		for _, instance := range app.FunctionsInstances {
+			instanceNames[instance.Name] = true
+		}
+	}
+	for _, funcConf := range rc.tracked.Functions {
+		if funcConf.InstanceID > 0 && !rc.isApplied(kindFunctionInstance, funcConf.InstanceID) && !instanceNames[funcConf.Name] {
+			if funcConf.ApplicationID == 0 {
+				funcConf.ApplicationID = rc.Conf.Application.ID
+			}
+			orphans.FunctionInstances = append(orphans.FunctionInstances, funcConf)
+		}
+	}
+
+	// without applications in the manifest the application found in azion.json is managed by the deploy command
+	if len(manifest.Applications) > 0 {
+		for _, name := range rc.applicationNames {
+			scope := rc.applications[name]
+			if scope.Conf.ID > 0 && !slices.Contains(rc.appliedApplications, name) && !appNames[name] {
+				orphans.Applications = append(orphans.Applications, scope.Conf)
+			}
+		}
+	}
+
+	firewalls := make(map[string]contracts.FirewallManifest)
+	for _, fwMan := range manifest.Firewalls {
+		firewalls[fwMan.Name] = fwMan
+	}
+	for _, fwConf := range rc.tracked.Firewalls {
+		if fwConf.Id == 0 {
+			continue
+		}
+		fwMan, declared := firewalls[fwConf.Name]
+		if !rc.isApplied(kindFirewall, fwConf.Id) && !declared {
+			orphans.Firewalls = append(orphans.Firewalls, fwConf)
+			continue
+		}
+		for _, ruleConf := range fwConf.Rules {
+			declaredRule := slices.ContainsFunc(fwMan.RulesEngine, func(rule contracts.FirewallManifestRule) bool {
+				return rule.Name == ruleConf.Name
+			})
+			if ruleConf.Id > 0 && !rc.isApplied(kindFirewallRule, ruleConf.Id) && !declaredRule {
+				orphans.FirewallRules = append(orphans.FirewallRules, firewallRuleIdRef{FirewallId: fwConf.Id, RuleId: ruleConf.Id})
+			}
+		}
+		for _, instConf := range fwConf.FunctionInstances {
+			declaredInstance := slices.ContainsFunc(fwMan.FunctionsInstances, func(instance contracts.FunctionInstance) bool {
+				return instance.Name == instConf.Name
+			})
+			if instConf.Id > 0 && !rc.isApplied(kindFirewallFunctionInstance, instConf.Id) && !declaredInstance {
+				orphans.FirewallFunctionInstances = append(orphans.FirewallFunctionInstances, firewallFunctionInstIdRef{FirewallId: fwConf.Id, FunctionInstanceId: instConf.Id})
+			}
+		}
+	}
+
+	connectorNames := make(map[string]bool)
+	for _, connector := range manifest.Connectors {
+		name, _ := getConnectorName(connector, rc.Conf.Name)
+		connectorNames[name] = true
+	}
+	for _, connConf := range rc.tracked.Connectors {
+		if connConf.Id > 0 && !rc.isApplied(kindConnector, connConf.Id) && !connectorNames[connConf.Name] {
+			orphans.Connectors = append(orphans.Connectors, connConf)
+		}
+	}
+
+	keptFunctions := make(map[int64]bool)
+	for _, funcConf := range rc.tracked.Functions {
+		orphanInstance := slices.ContainsFunc(orphans.FunctionInstances, func(instance contracts.AzionJsonDataFunction) bool {
+			return instance.InstanceID == funcConf.InstanceID
+		})
+		if rc.isApplied(kindFunction, funcConf.ID) || rc.declaresFunction(funcConf.Name) || (funcConf.InstanceID > 0 && !orphanInstance) {
+			keptFunctions[funcConf.ID] = true
+		}
+	}
+	for _, funcConf := range rc.tracked.Functions {
+		if funcConf.ID > 0 && !keptFunctions[funcConf.ID] && !slices.Contains(orphans.Functions, funcConf.ID) {
+			orphans.Functions = append(orphans.Functions, funcConf.ID)
+		}
+	}
+
+	return orphans
+}
+
+// deleteOrphans deletes the given resources, dependents first, and removes them from azion.json.
+// Resources that no longer exist on the platform are skipped.
+func (rc *ResourceContext) deleteOrphans(orphans orphanedResources) error {
+	for _, deployment := range orphans.Deployments {
+		err := rc.WorkloadClient.DeleteDeployment(rc.Ctx, rc.Conf.Workloads.Id, deployment.Id)
+		if err := rc.handleOrphanDeletion(err, msg.ManifestDeleteWorkloadDeployment, deployment.Name, deployment.Id); err != nil {
+			return err
+		}
+		rc.Conf.Workloads.Deployments = slices.DeleteFunc(rc.Conf.Workloads.Deployments, func(d contracts.Deployments) bool {
+			return d.Id == deployment.Id
+		})
+		delete(rc.DeploymentIds, deployment.Name)
+	}
+
+	for _, instance := range orphans.FunctionInstances {
+		err := rc.ApplicationClient.DeleteFunctionInstance(rc.Ctx, instance.ApplicationID, instance.InstanceID)
+		if err := rc.handleOrphanDeletion(err, msg.ManifestDeleteFunctionInstance, instance.Name, instance.InstanceID); err != nil {
+			return err
+		}
+		rc.removeFunctionInstance(instance)
+	}
+
+	for _, app := range orphans.Applications {
+		err := rc.ApplicationClient.Delete(rc.Ctx, app.ID)
+		if err := rc.handleOrphanDeletion(err, msg.ManifestDeleteEdgeApplication, app.Name, app.ID); err != nil {
+			return err
+		}
+		delete(rc.applications, app.Name)
+		rc.applicationNames = slices.DeleteFunc(rc.applicationNames, func(name string) bool {
+			return name == app.Name
+		})
+		for name, funcConf := range rc.FunctionIds {
+			if funcConf.InstanceID > 0 && funcConf.ApplicationID == app.ID {
+				rc.removeFunctionInstance(rc.FunctionIds[name])
+			}
+		}
+	}
+
+	for _, rule := range orphans.FirewallRules {
+		err := rc.FirewallClient.DeleteRule(rc.Ctx, rule.FirewallId, rule.RuleId)
+		if err := rc.handleOrphanDeletion(err, msg.ManifestDeleteFirewallRule, firewallRuleName(rc.tracked.Firewalls, rule.RuleId), rule.RuleId); err != nil {
+			return err
+		}
+		for name, ref := range rc.FirewallRuleIds {
+			if ref.RuleId == rule.RuleId {
+				delete(rc.FirewallRuleIds, name)
+			}
+		}
+	}
+
+	for _, instance := range orphans.FirewallFunctionInstances {
+		err := rc.FirewallFunctionInstClient.Delete(rc.Ctx, instance.FirewallId, instance.FunctionInstanceId)
+		if err := rc.handleOrphanDeletion(err, msg.ManifestDeleteFirewallFunctionInstance, firewallFunctionInstanceName(rc.tracked.Firewalls, instance.FunctionInstanceId), instance.FunctionInstanceId); err != nil {
+			return err
+		}
+		for name, ref := range rc.FirewallFunctionInstIds {
+			if ref.FunctionInstanceId == instance.FunctionInstanceId {
+				delete(rc.FirewallFunctionInstIds, name)
+			}
+		}
+	}
+
+	for _, firewall := range orphans.Firewalls {
+		err := rc.FirewallClient.Delete(rc.Ctx, firewall.Id)
+		if err := rc.handleOrphanDeletion(err, msg.ManifestDeleteFirewall, firewall.Name, firewall.Id); err != nil {
+			return err
+		}
+		rc.Conf.Firewalls = slices.DeleteFunc(rc.Conf.Firewalls, func(fw contracts.AzionJsonDataFirewall) bool {
+			return fw.Id == firewall.Id
+		})
+		delete(rc.FirewallIds, firewall.Name)
+	}
+
+	for _, connector := range orphans.Connectors {
+		err := rc.ConnectorClient.Delete(rc.Ctx, connector.Id)
+		if err := rc.handleOrphanDeletion(err, msg.ManifestDeleteConnector, connector.Name, connector.Id); err != nil {
+			return err
+		}
+		rc.Conf.Connectors = slices.DeleteFunc(rc.Conf.Connectors, func(conn contracts.AzionJsonDataConnectors) bool {
+			return conn.Id == connector.Id
+		})
+		delete(rc.ConnectorIds, connector.Name)
+	}
+
+	for _, id := range orphans.Functions {
+		name := ""
+		for funcName, funcConf := range rc.FunctionIds {
+			if funcConf.ID == id {
+				if name == "" || funcConf.InstanceID == 0 {
+					name = funcName
+				}
+				delete(rc.FunctionIds, funcName)
+			}
+		}
+		err := rc.FunctionClient.Delete(rc.Ctx, id)
+		if err := rc.handleOrphanDeletion(err, msg.ManifestDeleteFunction, name, id); err != nil {
+			return err
+		}
+	}
+
+	funcsToWrite := make([]contracts.AzionJsonDataFunction, 0, len(rc.FunctionIds))
+	for _, funcConf := range rc.FunctionIds {
+		funcsToWrite = append(funcsToWrite, funcConf)
+	}
+	slices.SortFunc(funcsToWrite, func(a, b contracts.AzionJsonDataFunction) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	rc.Conf.Function = funcsToWrite
+
+	return rc.WriteConfig()
+}
+
+// handleOrphanDeletion reports the result of deleting an orphaned resource.
+// A resource that was already deleted is not considered an error.
+func (rc *ResourceContext) handleOrphanDeletion(err error, successMsg, name string, id int64) error {
+	if errors.Is(err, utils.ErrorNotFound404) {
+		logger.Debug("Orphaned resource not found. Skipping delete", zap.String("name", name), zap.Int64("id", id))
+		return nil
+	}
+	if err != nil {
+		logger.Debug("Error while deleting orphaned resource", zap.String("name", name), zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+	msgf := fmt.Sprintf(successMsg, name, id)
+	logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
+	*rc.Msgs = append(*rc.Msgs, msgf)
+	return nil
+}
+
+// removeFunctionInstance drops a function instance from the function entries of azion.json.
+// Entries shared by a function and its instance keep tracking the function.
+func (rc *ResourceContext) removeFunctionInstance(instance contracts.AzionJsonDataFunction) {
+	funcConf, ok := rc.FunctionIds[instance.Name]
+	if !ok || funcConf.InstanceID != instance.InstanceID {
+		return
+	}
+	if !rc.isApplied(kindFunction, funcConf.ID) || !rc.declaresFunction(instance.Name) {
+		delete(rc.FunctionIds, instance.Name)
+		return
+	}
+	funcConf.InstanceID = 0
+	funcConf.ApplicationID = 0
+	rc.FunctionIds[instance.Name] = funcConf
+}
+
+// declaresFunction reports whether the manifest declares a function with the given name
+func (rc *ResourceContext) declaresFunction(name string) bool {
+	if rc.Manifest == nil {
+		return false
+	}
+	return slices.ContainsFunc(rc.Manifest.Functions, func(funcMan contracts.Function) bool {
+		return funcMan.Name == name
+	})
+}
+
+func firewallRuleName(firewalls []contracts.AzionJsonDataFirewall, id int64) string {
+	for _, fw := range firewalls {
+		for _, rule := range fw.Rules {
+			if rule.Id == id {
+				return rule.Name
+			}
+		}
+	}
+	return ""
+}
+
+func firewallFunctionInstanceName(firewalls []contracts.AzionJsonDataFirewall, id int64) string {
+	for _, fw := range firewalls {
+		for _, instance := range fw.FunctionInstances {
+			if instance.Id == id {
+				return instance.Name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrphanedResources(t *testing.T) {
+	f, _, _ := testutils.NewFactory(nil)
+	msgs := []string{}
+
+	newConf := func() *contracts.AzionApplicationOptions {
+		return &contracts.AzionApplicationOptions{
+			Applications: []contracts.AzionJsonDataApplications{
+				{ID: 10, Name: "api"},
+				{ID: 20, Name: "legacy"},
+			},
+			Function: []contracts.AzionJsonDataFunction{
+				{ID: 1, Name: "handler"},
+				{ID: 1, Name: "handler-instance", InstanceID: 11, ApplicationID: 10},
+				{ID: 2, Name: "old-handler"},
+				{ID: 2, Name: "old-instance", InstanceID: 21, ApplicationID: 20},
+			},
+			Connectors: []contracts.AzionJsonDataConnectors{{Id: 30, Name: "origin"}, {Id: 31, Name: "old-origin"}},
+			Workloads: contracts.AzionJsonDataWorkload{
+				Id:          40,
+				Deployments: []contracts.Deployments{{Id: 41, Name: "production"}, {Id: 42, Name: "staging"}},
+			},
+			Firewalls: []contracts.AzionJsonDataFirewall{
+				{
+					Id:                50,
+					Name:              "waf",
+					Rules:             []contracts.AzionJsonDataFirewallRule{{Id: 51, Name: "block"}, {Id: 52, Name: "old-rule"}},
+					FunctionInstances: []contracts.AzionJsonDataFirewallFunctionInstance{{Id: 53, Name: "old-fw-instance"}},
+				},
+				{Id: 60, Name: "old-waf"},
+			},
+		}
+	}
+
+	t.Run("resources missing from the manifest are orphans", func(t *testing.T) {
+		manifest := &contracts.ManifestV4{
+			Functions: []contracts.Function{{Name: "handler"}},
+			Applications: []contracts.Applications{
+				{Name: "api", FunctionsInstances: []contracts.FunctionInstance{{Name: "handler-instance"}}},
+			},
+			WorkloadDeployments: []contracts.WorkloadDeployment{{Name: "production"}},
+			Firewalls: []contracts.FirewallManifest{
+				{Name: "waf", RulesEngine: []contracts.FirewallManifestRule{{Name: "block"}}},
+			},
+		}
+		rc := NewResourceContext(f, newConf(), manifest, "azion", &msgs, nil)
+		rc.UseApplication("api")
+		rc.markApplied(kindConnector, 30)
+
+		orphans := rc.orphanedResources()
+
+		require.Equal(t, []contracts.Deployments{{Id: 42, Name: "staging"}}, orphans.Deployments)
+		require.Len(t, orphans.FunctionInstances, 1)
+		require.Equal(t, int64(21), orphans.FunctionInstances[0].InstanceID)
+		require.Len(t, orphans.Applications, 1)
+		require.Equal(t, int64(20), orphans.Applications[0].ID)
+		require.Equal(t, []firewallRuleIdRef{{FirewallId: 50, RuleId: 52}}, orphans.FirewallRules)
+		require.Equal(t, []firewallFunctionInstIdRef{{FirewallId: 50, FunctionInstanceId: 53}}, orphans.FirewallFunctionInstances)
+		require.Len(t, orphans.Firewalls, 1)
+		require.Equal(t, int64(60), orphans.Firewalls[0].Id)
+		require.Equal(t, []contracts.AzionJsonDataConnectors{{Id: 31, Name: "old-origin"}}, orphans.Connectors)
+		require.Equal(t, []int64{2}, orphans.Functions)
+	})
+
+	t.Run("renamed resources are kept once applied", func(t *testing.T) {
+		manifest := &contracts.ManifestV4{
+			Applications: []contracts.Applications{{Name: "api"}},
+			Firewalls:    []contracts.FirewallManifest{{Name: "waf-renamed"}},
+		}
+		rc := NewResourceContext(f, newConf(), manifest, "azion", &msgs, nil)
+		rc.markApplied(kindFirewall, 50)
+		rc.markApplied(kindFunction, 2)
+
+		orphans := rc.orphanedResources()
+
+		require.Len(t, orphans.Firewalls, 1)
+		require.Equal(t, int64(60), orphans.Firewalls[0].Id)
+		require.Equal(t, []int64{1}, orphans.Functions)
+	})
+
+	t.Run("applications are kept when the manifest declares none", func(t *testing.T) {
+		rc := NewResourceContext(f, newConf(), &contracts.ManifestV4{}, "azion", &msgs, nil)
+
+		orphans := rc.orphanedResources()
+
+		require.Empty(t, orphans.Applications)
+		require.Len(t, orphans.FunctionInstances, 2)
+	})
+}
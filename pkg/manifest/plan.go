@@ -0,0 +1,574 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/utils"
+	"go.uber.org/zap"
+)
+
+// PlanVersion is the version of the change set format written by Plan
+const PlanVersion = 2
+
+// actions of a planned change
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+	ActionNoop   = "no-op"
+)
+
+// resource kinds planned along with the ones tracked for orphan removal
+const (
+	kindStorage      = "storage"
+	kindKV           = "kv"
+	kindApplication  = "application"
+	kindCacheSetting = "cache-setting"
+	kindRule         = "rule"
+	kindWorkload     = "workload"
+)
+
+var kindNames = map[string]string{
+	kindStorage:                  "Storage Bucket",
+	kindKV:                       "KV Namespace",
+	kindConnector:                "Connector",
+	kindFunction:                 "Function",
+	kindApplication:              "Application",
+	kindFunctionInstance:         "Function Instance",
+	kindCacheSetting:             "Cache Setting",
+	kindRule:                     "Rule Engine",
+	kindWorkload:                 "Workload",
+	kindDeployment:               "Workload Deployment",
+	kindFirewall:                 "Firewall",
+	kindFirewallRule:             "Firewall Rule",
+	kindFirewallFunctionInstance: "Firewall Function Instance",
+}
+
+// Plan is the change set computed by comparing the manifest with azion.json and the remote state
+type Plan struct {
+	Version      int          `json:"version"`
+	CreatedAt    string       `json:"created_at"`
+	ManifestHash string       `json:"manifest_hash"`
+	Changes      []PlanChange `json:"changes"`
+}
+
+// PlanChange is a single create, update or delete of a resource, or an existing resource left as it is.
+// Fingerprint summarizes the remote state of existing resources at the time the plan was computed,
+// and Fields lists the fields an update changes.
+type PlanChange struct {
+	Action      string      `json:"action"`
+	Kind        string      `json:"kind"`
+	Name        string      `json:"name"`
+	ID          int64       `json:"id,omitempty"`
+	Application string      `json:"application,omitempty"`
+	Fingerprint string      `json:"fingerprint,omitempty"`
+	Fields      []PlanField `json:"fields,omitempty"`
+}
+
+// PlanField is a field of an existing resource whose value on Azion Platform differs from the manifest
+type PlanField struct {
+	Field    string `json:"field"`
+	Expected any    `json:"expected,omitempty"`
+	Actual   any    `json:"actual,omitempty"`
+}
+
+// KindName returns the human readable name of the kind of the changed resource
+func (c PlanChange) KindName() string {
+	if name, ok := kindNames[c.Kind]; ok {
+		return name
+	}
+	return c.Kind
+}
+
+func (c PlanChange) key() string {
+	return c.Kind + "/" + c.Application + "/" + c.Name
+}
+
+// resourceRef identifies a remote resource; ParentID is the application, workload or firewall it belongs to
+type resourceRef struct {
+	Kind     string
+	ID       int64
+	ParentID int64
+	Phase    string
+}
+
+// ManifestHash returns a digest of the manifest, used to tie a saved plan to the manifest it was computed from
+func ManifestHash(manifest *contracts.ManifestV4) (string, error) {
+	b, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Plan computes the changes applying the manifest would make, in the order they are applied.
+// When withLiveState is true every tracked resource is fetched from the API: resources that no longer
+// exist are planned for creation, and the others carry a fingerprint of their remote state and are compared
+// field by field with the manifest, as DetectDrift does. They are planned for update only when a field differs.
+// Without the live state every tracked resource is planned for update. Plan never changes azion.json nor the remote state.
+func (rc *ResourceContext) Plan(withLiveState bool) (*Plan, error) {
+	hash, err := ManifestHash(rc.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{
+		Version:      PlanVersion,
+		CreatedAt:    time.Now().UTC().Format(time.RFC3339),
+		ManifestHash: hash,
+		Changes:      []PlanChange{},
+	}
+	p := &planner{rc: rc, plan: plan, live: withLiveState}
+
+	for _, storage := range rc.Manifest.Storage {
+		action := ActionCreate
+		if rc.Conf.Bucket != "" {
+			action = ActionUpdate
+		}
+		plan.Changes = append(plan.Changes, PlanChange{Action: action, Kind: kindStorage, Name: storage.Name})
+	}
+
+	// namespaces already tracked are left as they are
+	for _, kvMan := range rc.Manifest.KV {
+		tracked := slices.ContainsFunc(rc.Conf.KV, func(kv contracts.AzionJsonDataKV) bool {
+			return kv.Name == kvMan.Name
+		})
+		if !tracked {
+			plan.Changes = append(plan.Changes, PlanChange{Action: ActionCreate, Kind: kindKV, Name: kvMan.Name})
+		}
+	}
+
+	for _, connector := range rc.Manifest.Connectors {
+		name, _ := getConnectorName(connector, rc.Conf.Name)
+		if _, err := p.apply(name, "", resourceRef{Kind: kindConnector, ID: rc.ConnectorIds[name]}, stateMap(connector), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, funcMan := range rc.Manifest.Functions {
+		ref := resourceRef{Kind: kindFunction, ID: rc.FunctionIds[funcMan.Name].ID}
+		if _, err := p.apply(funcMan.Name, "", ref, functionState(funcMan), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, app := range rc.Manifest.Applications {
+		if err := p.planApplication(app); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(rc.Manifest.Workloads) > 0 {
+		workload := rc.Manifest.Workloads[0]
+		if _, err := p.apply(workload.Name, "", resourceRef{Kind: kindWorkload, ID: rc.Conf.Workloads.Id}, stateMap(workload), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, deployment := range rc.Manifest.WorkloadDeployments {
+		ref := resourceRef{Kind: kindDeployment, ID: rc.DeploymentIds[deployment.Name], ParentID: rc.Conf.Workloads.Id}
+		if _, err := p.apply(deployment.Name, "", ref, rc.deploymentState(deployment), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, fwMan := range rc.Manifest.Firewalls {
+		desired := stateMap(fwMan, "rules_engine", "functions_instances")
+		exists, err := p.apply(fwMan.Name, "", resourceRef{Kind: kindFirewall, ID: rc.FirewallIds[fwMan.Name]}, desired, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, instance := range fwMan.FunctionsInstances {
+			ref := resourceRef{Kind: kindFirewallFunctionInstance}
+			if exists {
+				instRef := rc.FirewallFunctionInstIds[instance.Name]
+				ref.ID, ref.ParentID = instRef.FunctionInstanceId, instRef.FirewallId
+			}
+			if _, err := p.apply(instance.Name, "", ref, rc.functionInstanceState(instance), nil); err != nil {
+				return nil, err
+			}
+		}
+		for _, rule := range fwMan.RulesEngine {
+			ref := resourceRef{Kind: kindFirewallRule}
+			if exists {
+				ruleRef := rc.FirewallRuleIds[rule.Name]
+				ref.ID, ref.ParentID = ruleRef.RuleId, ruleRef.FirewallId
+			}
+			if _, err := p.apply(rule.Name, "", ref, stateMap(rule), rc.firewallRuleResolver()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if rc.Conf.SkipDeletion != nil && *rc.Conf.SkipDeletion {
+		return plan, nil
+	}
+
+	if err := p.planOrphans(); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// planner accumulates the changes of a plan
+type planner struct {
+	rc   *ResourceContext
+	plan *Plan
+	live bool
+}
+
+// apply plans the creation or update of a manifest resource, reporting whether it already exists.
+// An existing resource is left as it is when its remote state matches the desired one.
+func (p *planner) apply(name, application string, ref resourceRef, desired map[string]any, resolve referenceResolver) (bool, error) {
+	change := PlanChange{Action: ActionCreate, Kind: ref.Kind, Name: name, Application: application}
+	if ref.ID > 0 {
+		var err error
+		if change, err = p.existing(change, ref, desired, resolve); err != nil {
+			return false, err
+		}
+	}
+	p.plan.Changes = append(p.plan.Changes, change)
+	return change.Action != ActionCreate, nil
+}
+
+// existing plans the update of a tracked resource with the fields that differ from the desired state,
+// or its creation when it no longer exists
+func (p *planner) existing(change PlanChange, ref resourceRef, desired map[string]any, resolve referenceResolver) (PlanChange, error) {
+	state, found, err := p.fetch(ref)
+	if err != nil || !found {
+		return change, err
+	}
+	change.Action = ActionUpdate
+	change.ID = ref.ID
+	if !p.live {
+		return change, nil
+	}
+
+	if change.Fingerprint, err = fingerprint(state); err != nil {
+		return change, err
+	}
+	diffResource(desired, resolve, state, func(field string, expected, actual any) {
+		change.Fields = append(change.Fields, PlanField{Field: field, Expected: expected, Actual: actual})
+	})
+	if len(change.Fields) == 0 {
+		change.Action = ActionNoop
+	}
+	return change, nil
+}
+
+// delete plans the removal of a tracked resource, unless it no longer exists
+func (p *planner) delete(name, application string, ref resourceRef) error {
+	state, found, err := p.fetch(ref)
+	if err != nil || !found {
+		return err
+	}
+	change := PlanChange{Action: ActionDelete, Kind: ref.Kind, Name: name, ID: ref.ID, Application: application}
+	if p.live {
+		if change.Fingerprint, err = fingerprint(state); err != nil {
+			return err
+		}
+	}
+	p.plan.Changes = append(p.plan.Changes, change)
+	return nil
+}
+
+// fetch returns the remote state of a resource and whether it still exists.
+// Without the live state every tracked resource is taken as existing.
+func (p *planner) fetch(ref resourceRef) (any, bool, error) {
+	if !p.live {
+		return nil, true, nil
+	}
+	state, err := p.rc.liveState(ref)
+	if err != nil {
+		if errors.Is(err, utils.ErrorNotFound404) {
+			logger.Debug("Tracked resource not found", zap.String("kind", ref.Kind), zap.Int64("id", ref.ID))
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	p.rc.observed[ref] = state
+	return state, true, nil
+}
+
+// fingerprint returns a digest of the remote state of a resource
+func fingerprint(state any) (string, error) {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// planApplication plans an application of the manifest along with its function instances,
+// cache settings and rules, including the rules and cache settings that would be deleted
+func (p *planner) planApplication(app contracts.Applications) error {
+	rc := p.rc
+	scope, ok := rc.applications[app.Name]
+	if !ok {
+		scope = newApplicationScope(contracts.AzionJsonDataApplications{Name: app.Name})
+	}
+	appID := scope.Conf.ID
+
+	desired := stateMap(app, "rules", "cache_settings", "functions_instances")
+	exists, err := p.apply(app.Name, app.Name, resourceRef{Kind: kindApplication, ID: appID}, desired, nil)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		appID = 0
+	}
+
+	for _, instance := range app.FunctionsInstances {
+		ref := resourceRef{Kind: kindFunctionInstance, ParentID: appID}
+		if funcConf := rc.FunctionIds[instance.Name]; appID > 0 && funcConf.ApplicationID == appID {
+			ref.ID = funcConf.InstanceID
+		}
+		if _, err := p.apply(instance.Name, app.Name, ref, rc.functionInstanceState(instance), nil); err != nil {
+			return err
+		}
+	}
+
+	for _, cache := range app.CacheSettings {
+		ref := resourceRef{Kind: kindCacheSetting, ParentID: appID}
+		if appID > 0 {
+			ref.ID = scope.CacheIds[cache.Name]
+		}
+		if _, err := p.apply(cache.Name, app.Name, ref, stateMap(cache), nil); err != nil {
+			return err
+		}
+	}
+
+	ruleNames := make(map[string]bool)
+	resolver := rc.ruleResolver(scope, appID)
+	for _, rule := range app.Rules {
+		ruleNames[rule.Rule.Name] = true
+		ref := resourceRef{Kind: kindRule, ParentID: appID, Phase: rule.Phase}
+		if appID > 0 {
+			existing := scope.RuleIds[rule.Rule.Name]
+			ref.ID, ref.Phase = existing.Id, existing.Phase
+		}
+		if _, err := p.apply(rule.Rule.Name, app.Name, ref, stateMap(rule.Rule), resolver); err != nil {
+			return err
+		}
+	}
+
+	if appID == 0 || (rc.Conf.SkipDeletion != nil && *rc.Conf.SkipDeletion) {
+		return nil
+	}
+
+	// rules not found in the manifest are deleted, as are the cache settings no rule refers to
+	for _, name := range sortedKeys(scope.RuleIds) {
+		if ruleNames[name] {
+			continue
+		}
+		ref := scope.RuleIds[name]
+		if err := p.delete(name, app.Name, resourceRef{Kind: kindRule, ID: ref.Id, ParentID: appID, Phase: ref.Phase}); err != nil {
+			return err
+		}
+	}
+	referenced := referencedCacheSettings(app.Rules)
+	for _, name := range sortedKeys(scope.CacheIds) {
+		if referenced[name] {
+			continue
+		}
+		if err := p.delete(name, app.Name, resourceRef{Kind: kindCacheSetting, ID: scope.CacheIds[name], ParentID: appID}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// planOrphans plans the deletion of the resources tracked in azion.json that the manifest no longer declares
+func (p *planner) planOrphans() error {
+	rc := p.rc
+	orphans := rc.orphanedResources()
+
+	for _, deployment := range orphans.Deployments {
+		if err := p.delete(deployment.Name, "", resourceRef{Kind: kindDeployment, ID: deployment.Id, ParentID: rc.Conf.Workloads.Id}); err != nil {
+			return err
+		}
+	}
+	for _, instance := range orphans.FunctionInstances {
+		ref := resourceRef{Kind: kindFunctionInstance, ID: instance.InstanceID, ParentID: instance.ApplicationID}
+		if err := p.delete(instance.Name, "", ref); err != nil {
+			return err
+		}
+	}
+	for _, app := range orphans.Applications {
+		if err := p.delete(app.Name, app.Name, resourceRef{Kind: kindApplication, ID: app.ID}); err != nil {
+			return err
+		}
+	}
+	for _, rule := range orphans.FirewallRules {
+		ref := resourceRef{Kind: kindFirewallRule, ID: rule.RuleId, ParentID: rule.FirewallId}
+		if err := p.delete(firewallRuleName(rc.tracked.Firewalls, rule.RuleId), "", ref); err != nil {
+			return err
+		}
+	}
+	for _, instance := range orphans.FirewallFunctionInstances {
+		ref := resourceRef{Kind: kindFirewallFunctionInstance, ID: instance.FunctionInstanceId, ParentID: instance.FirewallId}
+		if err := p.delete(firewallFunctionInstanceName(rc.tracked.Firewalls, instance.FunctionInstanceId), "", ref); err != nil {
+			return err
+		}
+	}
+	for _, firewall := range orphans.Firewalls {
+		if err := p.delete(firewall.Name, "", resourceRef{Kind: kindFirewall, ID: firewall.Id}); err != nil {
+			return err
+		}
+	}
+	for _, connector := range orphans.Connectors {
+		if err := p.delete(connector.Name, "", resourceRef{Kind: kindConnector, ID: connector.Id}); err != nil {
+			return err
+		}
+	}
+	for _, id := range orphans.Functions {
+		if err := p.delete(trackedFunctionName(rc.tracked.Functions, id), "", resourceRef{Kind: kindFunction, ID: id}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchLiveState retrieves a tracked resource through the API clients of the ResourceContext
+func (rc *ResourceContext) fetchLiveState(ref resourceRef) (any, error) {
+	switch ref.Kind {
+	case kindApplication:
+		return rc.ApplicationClient.Get(rc.Ctx, ref.ID)
+	case kindFunctionInstance:
+		return rc.ApplicationClient.GetFuncInstance(rc.Ctx, ref.ParentID, ref.ID)
+	case kindCacheSetting:
+		return rc.CacheClient.Get(rc.Ctx, ref.ParentID, ref.ID)
+	case kindRule:
+		if ref.Phase == "response" {
+			return rc.ApplicationClient.GetRulesEngineResponse(rc.Ctx, ref.ParentID, ref.ID)
+		}
+		return rc.ApplicationClient.GetRulesEngineRequest(rc.Ctx, ref.ParentID, ref.ID)
+	case kindFunction:
+		return rc.FunctionClient.Get(rc.Ctx, ref.ID)
+	case kindConnector:
+		return rc.ConnectorClient.Get(rc.Ctx, ref.ID)
+	case kindWorkload:
+		return rc.WorkloadClient.Get(rc.Ctx, ref.ID)
+	case kindDeployment:
+		return rc.WorkloadClient.GetDeployment(rc.Ctx, ref.ParentID, ref.ID)
+	case kindFirewall:
+		return rc.FirewallClient.Get(rc.Ctx, ref.ID)
+	case kindFirewallRule:
+		return rc.FirewallRuleClient.Get(rc.Ctx, ref.ParentID, ref.ID)
+	case kindFirewallFunctionInstance:
+		return rc.FirewallFunctionInstClient.Get(rc.Ctx, ref.ParentID, ref.ID)
+	}
+	return nil, fmt.Errorf("unknown resource kind %q", ref.Kind)
+}
+
+// fetchLiveChildren lists every resource of the kind of ref that belongs to the parent informed in it,
+// going through all the pages. Each item is returned as the map decoded from its JSON representation.
+func (rc *ResourceContext) fetchLiveChildren(ref resourceRef) ([]any, error) {
+	opts := &contracts.ListOptions{Page: 1, PageSize: 100}
+	children := []any{}
+	for {
+		var page any
+		var err error
+		switch ref.Kind {
+		case kindCacheSetting:
+			page, err = rc.CacheClient.List(rc.Ctx, opts, ref.ParentID)
+		case kindRule:
+			if ref.Phase == "response" {
+				page, err = rc.ApplicationClient.ListRulesEngineResponse(rc.Ctx, opts, ref.ParentID)
+			} else {
+				page, err = rc.ApplicationClient.ListRulesEngineRequest(rc.Ctx, opts, ref.ParentID)
+			}
+		case kindFunctionInstance:
+			page, err = rc.ApplicationClient.EdgeFuncInstancesList(rc.Ctx, opts, ref.ParentID)
+		case kindDeployment:
+			page, err = rc.WorkloadClient.ListDeployments(rc.Ctx, opts, ref.ParentID)
+		case kindFirewallRule:
+			page, err = rc.FirewallRuleClient.List(rc.Ctx, opts, ref.ParentID)
+		case kindFirewallFunctionInstance:
+			page, err = rc.FirewallFunctionInstClient.List(rc.Ctx, opts, ref.ParentID)
+		default:
+			return nil, fmt.Errorf("unknown resource kind %q", ref.Kind)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		results, _ := stateMap(page)["results"].([]any)
+		children = append(children, results...)
+		if len(results) < int(opts.PageSize) {
+			return children, nil
+		}
+		opts.Page++
+	}
+}
+
+// DriftedChanges compares a saved plan with one computed afterwards and returns the changes that differ:
+// resources whose planned action or remote state changed, or that only appear in one of the plans.
+func DriftedChanges(saved, current *Plan) []PlanChange {
+	savedChanges := make(map[string]PlanChange, len(saved.Changes))
+	for _, change := range saved.Changes {
+		savedChanges[change.key()] = change
+	}
+
+	drifted := []PlanChange{}
+	for _, change := range current.Changes {
+		previous, ok := savedChanges[change.key()]
+		delete(savedChanges, change.key())
+		if !ok || previous.Action != change.Action || previous.ID != change.ID || previous.Fingerprint != change.Fingerprint {
+			drifted = append(drifted, change)
+		}
+	}
+	for _, change := range saved.Changes {
+		if _, ok := savedChanges[change.key()]; ok {
+			drifted = append(drifted, change)
+		}
+	}
+	return drifted
+}
+
+// referencedCacheSettings returns the names of the cache settings referred to by the given rules
+func referencedCacheSettings(rules []contracts.ManifestRulesEngine) map[string]bool {
+	referenced := make(map[string]bool)
+	for _, rule := range rules {
+		for _, behavior := range rule.Rule.Behaviors {
+			if behavior.Type != "set_cache_policy" {
+				continue
+			}
+			if name, ok := behavior.Attributes["value"].(string); ok {
+				referenced[name] = true
+			}
+		}
+	}
+	return referenced
+}
+
+func trackedFunctionName(functions []contracts.AzionJsonDataFunction, id int64) string {
+	name := ""
+	for _, funcConf := range functions {
+		if funcConf.ID == id && (name == "" || funcConf.InstanceID == 0) {
+			name = funcConf.Name
+		}
+	}
+	return name
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,152 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan(t *testing.T) {
+	f, _, _ := testutils.NewFactory(nil)
+	msgs := []string{}
+
+	conf := &contracts.AzionApplicationOptions{
+		Applications: []contracts.AzionJsonDataApplications{
+			{
+				ID:            10,
+				Name:          "api",
+				CacheSettings: []contracts.AzionJsonDataCacheSettings{{Id: 100, Name: "assets"}},
+				RulesEngine: contracts.AzionJsonDataRulesEngine{
+					Rules: []contracts.AzionJsonDataRules{{Id: 200, Name: "cache assets", Phase: "request"}, {Id: 201, Name: "old rule", Phase: "request"}},
+				},
+			},
+		},
+		Function: []contracts.AzionJsonDataFunction{{ID: 1, Name: "handler"}, {ID: 2, Name: "gone"}},
+		KV:       []contracts.AzionJsonDataKV{{Name: "flags"}},
+	}
+	manifest := &contracts.ManifestV4{
+		KV:        []contracts.KVManifest{{Name: "flags"}, {Name: "sessions", Data: "sessions.ndjson"}},
+		Functions: []contracts.Function{{Name: "handler"}, {Name: "new-handler"}},
+		Applications: []contracts.Applications{
+			{
+				Name:          "api",
+				CacheSettings: []contracts.ManifestCacheSetting{{Name: "assets"}},
+				Rules: []contracts.ManifestRulesEngine{
+					{
+						Phase: "request",
+						Rule: contracts.ManifestRule{
+							Name: "cache assets",
+							Behaviors: []contracts.ManifestRuleBehavior{
+								{Type: "set_cache_policy", Attributes: map[string]interface{}{"value": "assets"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	rc := NewResourceContext(f, conf, manifest, "azion", &msgs, nil)
+	remote := map[string]any{
+		kindApplication:  map[string]string{"name": "api"},
+		kindCacheSetting: map[string]string{"name": "assets"},
+		kindRule:         map[string]string{"name": "rule"},
+	}
+	rc.liveState = func(ref resourceRef) (any, error) {
+		// the function "gone" was already removed from the platform
+		if ref.Kind == kindFunction && ref.ID == 2 {
+			return nil, utils.ErrorNotFound404
+		}
+		if state, ok := remote[ref.Kind]; ok {
+			return state, nil
+		}
+		return map[string]int64{"id": ref.ID}, nil
+	}
+
+	plan, err := rc.Plan(true)
+	require.NoError(t, err)
+
+	actions := make(map[string]string)
+	fields := make(map[string][]PlanField)
+	for _, change := range plan.Changes {
+		actions[change.Kind+"/"+change.Name] = change.Action
+		if len(change.Fields) > 0 {
+			fields[change.Kind+"/"+change.Name] = change.Fields
+		}
+		if change.Action != ActionCreate {
+			require.NotEmpty(t, change.Fingerprint)
+		}
+	}
+	require.Equal(t, map[string]string{
+		"kv/sessions":          ActionCreate,
+		"function/handler":     ActionNoop,
+		"function/new-handler": ActionCreate,
+		"application/api":      ActionNoop,
+		"cache-setting/assets": ActionNoop,
+		"rule/cache assets":    ActionUpdate,
+		"rule/old rule":        ActionDelete,
+	}, actions)
+	require.Equal(t, map[string][]PlanField{
+		"rule/cache assets": {{Field: "name", Expected: "cache assets", Actual: "rule"}},
+	}, fields)
+	require.Empty(t, DriftedChanges(plan, plan))
+
+	t.Run("remote changes are reported as drift", func(t *testing.T) {
+		remote[kindApplication] = map[string]string{"name": "api", "debug": "true"}
+		current, err := rc.Plan(true)
+		require.NoError(t, err)
+
+		drifted := DriftedChanges(plan, current)
+		require.Len(t, drifted, 1)
+		require.Equal(t, kindApplication, drifted[0].Kind)
+		require.Equal(t, ActionNoop, drifted[0].Action)
+	})
+
+	t.Run("fields changed on the platform are planned for update", func(t *testing.T) {
+		remote[kindApplication] = map[string]string{"name": "legacy-api"}
+		current, err := rc.Plan(true)
+		require.NoError(t, err)
+
+		for _, change := range current.Changes {
+			if change.Kind == kindApplication {
+				require.Equal(t, ActionUpdate, change.Action)
+				require.Equal(t, []PlanField{{Field: "name", Expected: "api", Actual: "legacy-api"}}, change.Fields)
+			}
+		}
+	})
+
+	t.Run("offline plans do not reach the API", func(t *testing.T) {
+		rc.liveState = func(ref resourceRef) (any, error) {
+			t.Fatalf("unexpected request for %s %d", ref.Kind, ref.ID)
+			return nil, nil
+		}
+		offline, err := rc.Plan(false)
+		require.NoError(t, err)
+		require.Len(t, offline.Changes, 8)
+		for _, change := range offline.Changes {
+			require.NotEqual(t, ActionNoop, change.Action)
+			require.Empty(t, change.Fields)
+		}
+	})
+}
+
+func TestDriftedChanges(t *testing.T) {
+	saved := &Plan{Changes: []PlanChange{
+		{Action: ActionUpdate, Kind: kindFunction, Name: "handler", ID: 1, Fingerprint: "a"},
+		{Action: ActionDelete, Kind: kindConnector, Name: "origin", ID: 2, Fingerprint: "b"},
+	}}
+	current := &Plan{Changes: []PlanChange{
+		{Action: ActionUpdate, Kind: kindFunction, Name: "handler", ID: 1, Fingerprint: "a"},
+		{Action: ActionCreate, Kind: kindFirewall, Name: "waf"},
+	}}
+
+	drifted := DriftedChanges(saved, current)
+
+	require.Equal(t, []PlanChange{
+		{Action: ActionCreate, Kind: kindFirewall, Name: "waf"},
+		{Action: ActionDelete, Kind: kindConnector, Name: "origin", ID: 2, Fingerprint: "b"},
+	}, drifted)
+}
@@ -88,7 +88,7 @@ func transformWorkloadRequestUpdate(createRequest contracts.WorkloadManifest) *a
 	return request
 }
 
-func transformWorkloadDeploymentRequestUpdate(updateRequest contracts.WorkloadDeployment, conf *contracts.AzionApplicationOptions) edgesdk.PatchedWorkloadDeploymentRequest {
+func transformWorkloadDeploymentRequestUpdate(updateRequest contracts.WorkloadDeployment, applicationID int64) edgesdk.PatchedWorkloadDeploymentRequest {
 	request := edgesdk.PatchedWorkloadDeploymentRequest{}
 
 	if updateRequest.Name != "" {
@@ -106,14 +106,14 @@ func transformWorkloadDeploymentRequestUpdate(updateRequest contracts.WorkloadDe
 		strategy.SetType(updateRequest.Strategy.Type)
 	}
 
-	attributes.SetApplication(conf.Application.ID)
+	attributes.SetApplication(applicationID)
 	strategy.SetAttributes(attributes)
 	request.SetStrategy(strategy)
 
 	return request
 }
 
-func transformWorkloadDeploymentRequestCreate(createRequest contracts.WorkloadDeployment, conf *contracts.AzionApplicationOptions) edgesdk.WorkloadDeploymentRequest {
+func transformWorkloadDeploymentRequestCreate(createRequest contracts.WorkloadDeployment, applicationID int64) edgesdk.WorkloadDeploymentRequest {
 	request := edgesdk.WorkloadDeploymentRequest{}
 
 	if createRequest.Name != "" {
@@ -130,7 +130,7 @@ func transformWorkloadDeploymentRequestCreate(createRequest contracts.WorkloadDe
 		strategy.SetType(createRequest.Strategy.Type)
 	}
 
-	attributes.SetApplication(conf.Application.ID)
+	attributes.SetApplication(applicationID)
 	strategy.SetAttributes(attributes)
 	request.SetStrategy(strategy)
 
@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"path"
 	"strings"
+	"sync"
 
 	msg "github.com/aziontech/azion-cli/messages/manifest"
 	apiApplications "github.com/aziontech/azion-cli/pkg/api/applications"
@@ -17,7 +18,9 @@ import (
 	apiConnector "github.com/aziontech/azion-cli/pkg/api/connector"
 	apiFirewall "github.com/aziontech/azion-cli/pkg/api/firewall"
 	apiFirewallInstance "github.com/aziontech/azion-cli/pkg/api/firewall_instance"
+	apiFirewallRules "github.com/aziontech/azion-cli/pkg/api/firewall_rules"
 	functionsApi "github.com/aziontech/azion-cli/pkg/api/function"
+	apiKV "github.com/aziontech/azion-cli/pkg/api/kv"
 	apiPurge "github.com/aziontech/azion-cli/pkg/api/realtime_purge"
 	apiStorage "github.com/aziontech/azion-cli/pkg/api/storage"
 	apiWorkloads "github.com/aziontech/azion-cli/pkg/api/workloads"
@@ -25,6 +28,7 @@ import (
 	"github.com/aziontech/azion-cli/pkg/contracts"
 	"github.com/aziontech/azion-cli/pkg/logger"
 	vulcanPkg "github.com/aziontech/azion-cli/pkg/vulcan"
+	"github.com/aziontech/azion-cli/pkg/workers"
 	"github.com/aziontech/azion-cli/utils"
 	edgesdk "github.com/aziontech/azionapi-v4-go-sdk-dev/azion-api"
 	storagesdk "github.com/aziontech/azionapi-v4-go-sdk-dev/storage-api"
@@ -49,7 +53,9 @@ type ResourceContext struct {
 	PurgeClient                *apiPurge.Client
 	FirewallClient             *apiFirewall.Client
 	FirewallFunctionInstClient *apiFirewallInstance.Client
+	FirewallRuleClient         *apiFirewallRules.Client
 	StorageClient              *apiStorage.Client
+	KVClient                   *apiKV.Client
 
 	// ID Mappings - these track created/existing resource IDs
 	CacheIds                map[string]int64
@@ -61,6 +67,33 @@ type ResourceContext struct {
 	FirewallIds             map[string]int64
 	FirewallRuleIds         map[string]firewallRuleIdRef
 	FirewallFunctionInstIds map[string]firewallFunctionInstIdRef
+
+	// Application scopes - cache and rule IDs above refer to the selected application
+	applications        map[string]*applicationScope
+	applicationNames    []string
+	activeApplication   string
+	appliedApplications []string
+
+	// Orphan tracking - resources found in azion.json and the ones applied from the manifest
+	tracked trackedResources
+	applied map[string]map[int64]bool
+
+	// liveState fetches the remote state of a tracked resource while planning
+	liveState func(ref resourceRef) (any, error)
+	// observed keeps the remote states read while planning, so the journal does not read them again
+	observed map[resourceRef]any
+	// liveChildren lists the remote resources that belong to a parent while importing
+	liveChildren func(ref resourceRef) ([]any, error)
+
+	// Rollback journal - changes made to the account, undone when applying the manifest fails
+	SkipRollback bool
+	journal      []journalEntry
+	originalConf []byte
+
+	// mu guards the state above while the manifest is applied concurrently, it is only
+	// released while waiting for API responses
+	mu         sync.Mutex
+	concurrent bool
 }
 
 type firewallRuleIdRef struct {
@@ -103,7 +136,9 @@ func NewResourceContext(
 		PurgeClient:                apiPurge.NewClient(f.HttpClient, apiURL, token),
 		FirewallClient:             apiFirewall.NewClient(f.HttpClient, apiURL, token),
 		FirewallFunctionInstClient: apiFirewallInstance.NewClient(f.HttpClient, apiURL, token),
+		FirewallRuleClient:         apiFirewallRules.NewClient(f.HttpClient, apiURL, token),
 		StorageClient:              apiStorage.NewClient(f.HttpClient, f.Config.GetString("storage_url"), token),
+		KVClient:                   apiKV.NewClient(f.HttpClient, apiURL, token),
 
 		// Initialize ID maps
 		CacheIds:                make(map[string]int64),
@@ -115,10 +150,17 @@ func NewResourceContext(
 		FirewallIds:             make(map[string]int64),
 		FirewallRuleIds:         make(map[string]firewallRuleIdRef),
 		FirewallFunctionInstIds: make(map[string]firewallFunctionInstIdRef),
+		applications:            make(map[string]*applicationScope),
+		applied:                 make(map[string]map[int64]bool),
+		observed:                make(map[resourceRef]any),
 	}
 
 	// Populate ID maps from existing config
 	rc.populateIdMapsFromConfig()
+	rc.populateApplicationsFromConfig()
+	rc.snapshotTrackedResources()
+	rc.liveState = rc.fetchLiveState
+	rc.liveChildren = rc.fetchLiveChildren
 
 	return rc
 }
@@ -165,6 +207,7 @@ func (rc *ResourceContext) populateIdMapsFromConfig() {
 }
 
 func (rc *ResourceContext) WriteConfig() error {
+	rc.saveActiveApplication()
 	err := rc.WriteConfigFunc(rc.Conf, rc.ProjectConf)
 	if err != nil {
 		logger.Debug("Error while writing azion.json file", zap.Error(err))
@@ -173,6 +216,34 @@ func (rc *ResourceContext) WriteConfig() error {
 	return nil
 }
 
+// callAPI performs an API request, retrying it while rate limited. When resources are applied concurrently
+// the state lock is released during the request, so the request must not touch the ResourceContext state.
+func (rc *ResourceContext) callAPI(call func() error) error {
+	return rc.unlocked(func() error {
+		return workers.RetryRateLimited(call)
+	})
+}
+
+// unlocked runs work without holding the state lock while resources are applied concurrently
+func (rc *ResourceContext) unlocked(work func() error) error {
+	if rc.concurrent {
+		rc.mu.Unlock()
+		defer rc.mu.Lock()
+	}
+	return work()
+}
+
+// callAPIWithResult is callAPI for requests that return a response
+func callAPIWithResult[T any](rc *ResourceContext, call func() (T, error)) (T, error) {
+	var result T
+	err := rc.callAPI(func() error {
+		var err error
+		result, err = call()
+		return err
+	})
+	return result, err
+}
+
 // runConfigReplace executes the "config replace --replacements" command to update the azion.config file
 // with the new resource name after a successful creation with a renamed resource.
 // It uses the original name (from manifest) as key and the new name (that succeeded) as value.
@@ -220,14 +291,18 @@ func (rc *ResourceContext) ApplyFunctions(functions []contracts.Function) error
 		if funcConf := rc.FunctionIds[funcMan.Name]; funcConf.ID > 0 {
 			request := functionsApi.UpdateRequest{}
 			request.SetActive(true)
-			request.SetDefaultArgs(funcMan.DefaultArgs)
+			request.SetDefaultArgs(functionArgs(funcMan))
 			request.SetName(funcMan.Name)
 			request.SetCode(string(code))
 			request.SetExecutionEnvironment(funcMan.ExecutionEnvironment)
-			updated, err := rc.FunctionClient.Update(rc.Ctx, &request, funcConf.ID)
+			rc.journalUpdate(resourceRef{Kind: kindFunction, ID: funcConf.ID}, funcMan.Name, request.PatchedFunctionsRequest)
+			updated, err := callAPIWithResult(rc, func() (edgesdk.Functions, error) {
+				return rc.FunctionClient.Update(rc.Ctx, &request, funcConf.ID)
+			})
 			if err != nil {
 				return err
 			}
+			rc.markApplied(kindFunction, updated.GetId())
 			msgf := fmt.Sprintf(msg.ManifestUpdateFunction, updated.GetName(), updated.GetId())
 			logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 			*rc.Msgs = append(*rc.Msgs, msgf)
@@ -239,11 +314,13 @@ func (rc *ResourceContext) ApplyFunctions(functions []contracts.Function) error
 			for {
 				request := functionsApi.CreateRequest{}
 				request.SetActive(true)
-				request.SetDefaultArgs(funcMan.DefaultArgs)
+				request.SetDefaultArgs(functionArgs(funcMan))
 				request.SetName(funcName)
 				request.SetCode(string(code))
 				request.SetExecutionEnvironment(funcMan.ExecutionEnvironment)
-				resp, err := rc.FunctionClient.Create(rc.Ctx, &request)
+				resp, err := callAPIWithResult(rc, func() (edgesdk.Functions, error) {
+					return rc.FunctionClient.Create(rc.Ctx, &request)
+				})
 				if err != nil {
 					// if the name is already in use, we ask for another one
 					if errors.Is(err, utils.ErrorNameInUse) || strings.Contains(err.Error(), utils.ErrorNameInUse.Error()) {
@@ -266,8 +343,10 @@ func (rc *ResourceContext) ApplyFunctions(functions []contracts.Function) error
 					File: funcMan.Argument,
 					Args: "./azion/args.json",
 				}
+				rc.journalCreate(resourceRef{Kind: kindFunction, ID: resp.GetId()}, resp.GetName())
 				rc.FunctionIds[resp.GetName()] = newFunc
 				rc.Conf.Function = append(rc.Conf.Function, newFunc)
+				rc.markApplied(kindFunction, resp.GetId())
 				msgf := fmt.Sprintf(msg.ManifestCreateFunction, resp.GetName(), resp.GetId())
 				logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 				*rc.Msgs = append(*rc.Msgs, msgf)
@@ -327,6 +406,7 @@ func (rc *ResourceContext) ApplyFunctionInstances(instances []contracts.Function
 	if rc.Conf.Application.ID == 0 {
 		return msg.ErrorApplicationIDRequired
 	}
+	appID := rc.Conf.Application.ID
 
 	for _, funcMan := range instances {
 		// Resolve the function reference to get the function ID and config
@@ -336,9 +416,9 @@ func (rc *ResourceContext) ApplyFunctionInstances(instances []contracts.Function
 			return msg.ErrorFuncNotFound
 		}
 
-		// Check if this function instance already exists (by name in FunctionIds map)
+		// Check if this function instance already exists in the selected application (by name in FunctionIds map)
 		instanceKey := funcMan.Name
-		if existingFunc, ok := rc.FunctionIds[instanceKey]; ok && existingFunc.InstanceID > 0 {
+		if existingFunc, ok := rc.FunctionIds[instanceKey]; ok && existingFunc.InstanceID > 0 && existingFunc.ApplicationID == appID {
 			request := apiApplications.UpdateInstanceRequest{}
 			request.SetActive(funcMan.Active)
 			request.SetFunction(funcID)
@@ -352,10 +432,16 @@ func (rc *ResourceContext) ApplyFunctionInstances(instances []contracts.Function
 				request.SetArgs(args)
 			}
 			request.SetName(funcMan.Name)
-			updated, err := rc.ApplicationClient.UpdateInstance(rc.Ctx, &request, rc.Conf.Application.ID, existingFunc.InstanceID)
+			rc.journalUpdate(resourceRef{Kind: kindFunctionInstance, ID: existingFunc.InstanceID, ParentID: appID}, funcMan.Name,
+				request.PatchedFunctionInstanceRequest)
+			updated, err := callAPIWithResult(rc, func() (edgesdk.FunctionInstance, error) {
+				return rc.ApplicationClient.UpdateInstance(rc.Ctx, &request, appID, existingFunc.InstanceID)
+			})
 			if err != nil {
 				return err
 			}
+			rc.markApplied(kindFunctionInstance, existingFunc.InstanceID)
+			rc.markApplied(kindFunction, funcID)
 			msgf := fmt.Sprintf(msg.ManifestUpdateFunctionInstance, updated.GetName(), updated.GetId())
 			logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 			*rc.Msgs = append(*rc.Msgs, msgf)
@@ -373,20 +459,26 @@ func (rc *ResourceContext) ApplyFunctionInstances(instances []contracts.Function
 			}
 			request.SetName(funcMan.Name)
 			request.SetFunction(funcID)
-			resp, err := rc.ApplicationClient.CreateFuncInstances(rc.Ctx, &request, rc.Conf.Application.ID)
+			resp, err := callAPIWithResult(rc, func() (edgesdk.FunctionInstance, error) {
+				return rc.ApplicationClient.CreateFuncInstances(rc.Ctx, &request, appID)
+			})
 			if err != nil {
 				return err
 			}
+			rc.journalCreate(resourceRef{Kind: kindFunctionInstance, ID: resp.GetId(), ParentID: appID}, funcMan.Name)
 			// Update or create the function config entry with the new instance ID
 			newFunc := contracts.AzionJsonDataFunction{
-				ID:         funcID,
-				CacheId:    funcConf.CacheId,
-				Name:       funcMan.Name,
-				File:       funcConf.File,
-				Args:       funcConf.Args,
-				InstanceID: resp.GetId(),
+				ID:            funcID,
+				CacheId:       funcConf.CacheId,
+				Name:          funcMan.Name,
+				File:          funcConf.File,
+				Args:          funcConf.Args,
+				InstanceID:    resp.GetId(),
+				ApplicationID: appID,
 			}
 			rc.FunctionIds[funcMan.Name] = newFunc
+			rc.markApplied(kindFunctionInstance, resp.GetId())
+			rc.markApplied(kindFunction, funcID)
 			msgf := fmt.Sprintf(msg.ManifestCreateFunctionInstance, resp.GetName(), resp.GetId())
 			logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 			*rc.Msgs = append(*rc.Msgs, msgf)
@@ -407,7 +499,10 @@ func (rc *ResourceContext) ApplyEdgeApplication(app contracts.Applications) erro
 	if rc.Conf.Application.ID > 0 {
 		req := transformEdgeApplicationRequestUpdate(app)
 		req.Id = rc.Conf.Application.ID
-		updated, err := rc.ApplicationClient.Update(rc.Ctx, req)
+		rc.journalUpdate(resourceRef{Kind: kindApplication, ID: req.Id}, app.Name, req.PatchedApplicationRequest)
+		updated, err := callAPIWithResult(rc, func() (apiApplications.EdgeApplicationsResponse, error) {
+			return rc.ApplicationClient.Update(rc.Ctx, req)
+		})
 		if err != nil {
 			return err
 		}
@@ -422,7 +517,9 @@ func (rc *ResourceContext) ApplyEdgeApplication(app contracts.Applications) erro
 		for {
 			createreq := transformEdgeApplicationRequestCreate(app)
 			createreq.SetName(appName)
-			resp, err := rc.ApplicationClient.Create(rc.Ctx, createreq)
+			resp, err := callAPIWithResult(rc, func() (apiApplications.EdgeApplicationsResponse, error) {
+				return rc.ApplicationClient.Create(rc.Ctx, createreq)
+			})
 			if err != nil {
 				if errors.Is(err, utils.ErrorNameInUse) || strings.Contains(err.Error(), utils.ErrorNameInUse.Error()) {
 					logger.FInfoFlags(rc.Factory.IOStreams.Out, msg.AppInUse, rc.Factory.Format, rc.Factory.Out)
@@ -437,9 +534,12 @@ func (rc *ResourceContext) ApplyEdgeApplication(app contracts.Applications) erro
 				}
 				return err
 			}
+			rc.journalCreate(resourceRef{Kind: kindApplication, ID: resp.GetId()}, resp.GetName())
 			rc.Conf.Application.ID = resp.GetId()
 			rc.Conf.Application.Name = resp.GetName()
-			rc.Conf.Name = resp.GetName()
+			if rc.isPrimaryApplication() {
+				rc.Conf.Name = resp.GetName()
+			}
 			msgf := fmt.Sprintf(msg.ManifestCreateEdgeApplication, resp.GetName(), resp.GetId())
 			logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 			*rc.Msgs = append(*rc.Msgs, msgf)
@@ -508,7 +608,11 @@ func (rc *ResourceContext) ApplyCacheSettings(cacheSettings []contracts.Manifest
 
 func (rc *ResourceContext) updateCache(cache contracts.ManifestCacheSetting, cacheId int64) (contracts.AzionJsonDataCacheSettings, error) {
 	request := transformCacheRequest(cache)
-	updated, err := rc.CacheClient.Update(rc.Ctx, request, rc.Conf.Application.ID, cacheId)
+	appID := rc.Conf.Application.ID
+	rc.journalUpdate(resourceRef{Kind: kindCacheSetting, ID: cacheId, ParentID: appID}, cache.Name, request.PatchedCacheSettingRequest)
+	updated, err := callAPIWithResult(rc, func() (apiCache.ResponseV4, error) {
+		return rc.CacheClient.Update(rc.Ctx, request, appID, cacheId)
+	})
 	if errors.Is(err, utils.ErrorNotFound404) {
 		logger.Debug("Cache Setting not found. Trying to create", zap.Any("Error", err))
 		logger.FInfoFlags(rc.Factory.IOStreams.Out, msg.MessageDeleteResource+"\n", rc.Factory.Format, rc.Factory.Out)
@@ -529,7 +633,10 @@ func (rc *ResourceContext) updateCache(cache contracts.ManifestCacheSetting, cac
 
 func (rc *ResourceContext) createCache(cache contracts.ManifestCacheSetting) (contracts.AzionJsonDataCacheSettings, error) {
 	request := transformCacheRequestCreate(cache)
-	responseCache, err := rc.CacheClient.Create(rc.Ctx, request.CacheSettingRequest, rc.Conf.Application.ID)
+	appID := rc.Conf.Application.ID
+	responseCache, err := callAPIWithResult(rc, func() (edgesdk.CacheSetting, error) {
+		return rc.CacheClient.Create(rc.Ctx, request.CacheSettingRequest, appID)
+	})
 	if err != nil {
 		return contracts.AzionJsonDataCacheSettings{}, err
 	}
@@ -538,6 +645,7 @@ func (rc *ResourceContext) createCache(cache contracts.ManifestCacheSetting) (co
 		Name: responseCache.GetName(),
 	}
 	rc.CacheIds[newCache.Name] = newCache.Id
+	rc.journalCreate(resourceRef{Kind: kindCacheSetting, ID: newCache.Id, ParentID: appID}, newCache.Name)
 	msgf := fmt.Sprintf(msg.ManifestCreateCache, newCache.Name, newCache.Id)
 	logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 	*rc.Msgs = append(*rc.Msgs, msgf)
@@ -551,7 +659,10 @@ func (rc *ResourceContext) ApplyConnectors(connectors []edgesdk.ConnectorRequest
 		connName, connType := getConnectorName(connector, rc.Conf.Name)
 		if id := rc.ConnectorIds[connName]; id > 0 {
 			request := transformEdgeConnectorRequest(connector)
-			connectorResp, err := rc.ConnectorClient.Update(rc.Ctx, request, id)
+			rc.journalUpdate(resourceRef{Kind: kindConnector, ID: id}, connName, request.PatchedConnectorRequest)
+			connectorResp, err := callAPIWithResult(rc, func() (edgesdk.Connector, error) {
+				return rc.ConnectorClient.Update(rc.Ctx, request, id)
+			})
 			if err != nil {
 				return err
 			}
@@ -569,6 +680,7 @@ func (rc *ResourceContext) ApplyConnectors(connectors []edgesdk.ConnectorRequest
 			default:
 				return msg.ErrorConnectorTypeNotFound
 			}
+			rc.markApplied(kindConnector, conn.Id)
 			msgf := fmt.Sprintf(msg.ManifestUpdateConnector, conn.Name, conn.Id)
 			logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 			*rc.Msgs = append(*rc.Msgs, msgf)
@@ -577,7 +689,9 @@ func (rc *ResourceContext) ApplyConnectors(connectors []edgesdk.ConnectorRequest
 			request := apiConnector.CreateRequest{
 				ConnectorRequest: connector,
 			}
-			connectorResp, err := rc.ConnectorClient.Create(rc.Ctx, &request)
+			connectorResp, err := callAPIWithResult(rc, func() (edgesdk.Connector, error) {
+				return rc.ConnectorClient.Create(rc.Ctx, &request)
+			})
 			if err != nil {
 				return err
 			}
@@ -595,6 +709,8 @@ func (rc *ResourceContext) ApplyConnectors(connectors []edgesdk.ConnectorRequest
 				return msg.ErrorConnectorTypeNotFound
 			}
 			rc.ConnectorIds[conn.Name] = conn.Id
+			rc.journalCreate(resourceRef{Kind: kindConnector, ID: conn.Id}, conn.Name)
+			rc.markApplied(kindConnector, conn.Id)
 			msgf := fmt.Sprintf(msg.ManifestCreateConnector, conn.Name, conn.Id)
 			logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 			*rc.Msgs = append(*rc.Msgs, msgf)
@@ -634,6 +750,7 @@ func (rc *ResourceContext) ApplyRulesEngine(rules []contracts.ManifestRulesEngin
 			if err != nil {
 				return err
 			}
+			rc.journalCreate(resourceRef{Kind: kindRule, ID: newRule.Id, ParentID: rc.Conf.Application.ID, Phase: rule.Phase}, newRule.Name)
 			msgf := fmt.Sprintf(msg.ManifestCreateRule, newRule.Name, newRule.Id)
 			logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 			*rc.Msgs = append(*rc.Msgs, msgf)
@@ -656,7 +773,11 @@ func (rc *ResourceContext) updateRule(rule contracts.ManifestRulesEngine, existi
 			return contracts.AzionJsonDataRules{}, err
 		}
 		req.Behaviors = behs
-		updated, err := rc.ApplicationClient.UpdateRulesEngineRequest(rc.Ctx, req)
+		rc.journalUpdate(resourceRef{Kind: kindRule, ID: existing.Id, ParentID: req.IdApplication, Phase: rule.Phase}, rule.Rule.Name,
+			req.PatchedRequestPhaseRule)
+		updated, err := callAPIWithResult(rc, func() (apiApplications.RulesEngineResponse, error) {
+			return rc.ApplicationClient.UpdateRulesEngineRequest(rc.Ctx, req)
+		})
 		if err != nil {
 			return contracts.AzionJsonDataRules{}, err
 		}
@@ -674,7 +795,11 @@ func (rc *ResourceContext) updateRule(rule contracts.ManifestRulesEngine, existi
 			return contracts.AzionJsonDataRules{}, err
 		}
 		req.Behaviors = behs
-		updated, err := rc.ApplicationClient.UpdateRulesEngineResponse(rc.Ctx, req)
+		rc.journalUpdate(resourceRef{Kind: kindRule, ID: existing.Id, ParentID: req.IdApplication, Phase: rule.Phase}, rule.Rule.Name,
+			req.PatchedResponsePhaseRuleRequest)
+		updated, err := callAPIWithResult(rc, func() (apiApplications.RulesEngineResponse, error) {
+			return rc.ApplicationClient.UpdateRulesEngineResponse(rc.Ctx, req)
+		})
 		if err != nil {
 			return contracts.AzionJsonDataRules{}, err
 		}
@@ -689,6 +814,7 @@ func (rc *ResourceContext) updateRule(rule contracts.ManifestRulesEngine, existi
 }
 
 func (rc *ResourceContext) createRule(rule contracts.ManifestRulesEngine) (contracts.AzionJsonDataRules, error) {
+	appID := rc.Conf.Application.ID
 	switch rule.Phase {
 	case "request":
 		req := &apiApplications.CreateRulesEngineRequest{}
@@ -699,7 +825,9 @@ func (rc *ResourceContext) createRule(rule contracts.ManifestRulesEngine) (contr
 		}
 		req.RequestPhaseRuleRequest = createRequest
 		req.Behaviors = bh
-		created, err := rc.ApplicationClient.CreateRulesEngineRequest(rc.Ctx, rc.Conf.Application.ID, rule.Phase, req)
+		created, err := callAPIWithResult(rc, func() (apiApplications.RulesEngineResponse, error) {
+			return rc.ApplicationClient.CreateRulesEngineRequest(rc.Ctx, appID, rule.Phase, req)
+		})
 		if err != nil {
 			return contracts.AzionJsonDataRules{}, err
 		}
@@ -717,7 +845,9 @@ func (rc *ResourceContext) createRule(rule contracts.ManifestRulesEngine) (contr
 		}
 		req.ResponsePhaseRuleRequest = createRequest
 		req.Behaviors = bh
-		created, err := rc.ApplicationClient.CreateRulesEngineResponse(rc.Ctx, rc.Conf.Application.ID, rule.Phase, req)
+		created, err := callAPIWithResult(rc, func() (apiApplications.RulesEngineResponse, error) {
+			return rc.ApplicationClient.CreateRulesEngineResponse(rc.Ctx, appID, rule.Phase, req)
+		})
 		if err != nil {
 			return contracts.AzionJsonDataRules{}, err
 		}
@@ -740,7 +870,10 @@ func (rc *ResourceContext) ApplyWorkloads(workloads []contracts.WorkloadManifest
 	if rc.Conf.Workloads.Id > 0 {
 		request := transformWorkloadRequestUpdate(workloadMan)
 		request.Id = rc.Conf.Workloads.Id
-		updated, err := rc.WorkloadClient.Update(rc.Ctx, request)
+		rc.journalUpdate(resourceRef{Kind: kindWorkload, ID: request.Id}, workloadMan.Name, request.PatchedWorkloadRequest)
+		updated, err := callAPIWithResult(rc, func() (apiWorkloads.WorkloadResponse, error) {
+			return rc.WorkloadClient.Update(rc.Ctx, request)
+		})
 		if err != nil {
 			return err
 		}
@@ -757,7 +890,9 @@ func (rc *ResourceContext) ApplyWorkloads(workloads []contracts.WorkloadManifest
 		for {
 			workloadMan.Name = workloadName
 			request := transformWorkloadRequestCreate(workloadMan, rc.Conf.Application.ID)
-			resp, err := rc.WorkloadClient.Create(rc.Ctx, request)
+			resp, err := callAPIWithResult(rc, func() (apiWorkloads.WorkloadResponse, error) {
+				return rc.WorkloadClient.Create(rc.Ctx, request)
+			})
 			if err != nil {
 				// if the name is already in use, we ask for another one
 				if errors.Is(err, utils.ErrorNameInUse) || strings.Contains(err.Error(), utils.ErrorNameInUse.Error()) {
@@ -774,6 +909,7 @@ func (rc *ResourceContext) ApplyWorkloads(workloads []contracts.WorkloadManifest
 				}
 				return err
 			}
+			rc.journalCreate(resourceRef{Kind: kindWorkload, ID: resp.GetId()}, resp.GetName())
 			rc.Conf.Workloads.Id = resp.GetId()
 			rc.Conf.Workloads.Name = resp.GetName()
 			rc.Conf.Workloads.Domains = resp.GetDomains()
@@ -797,27 +933,36 @@ func (rc *ResourceContext) ApplyWorkloadDeployments(deployments []contracts.Work
 	if rc.Conf.Workloads.Id == 0 {
 		return msg.ErrorWorkloadIDRequired
 	}
+	workloadID := rc.Conf.Workloads.Id
 
 	for _, deployment := range deployments {
 		if id := rc.DeploymentIds[deployment.Name]; id > 0 {
-			request := transformWorkloadDeploymentRequestUpdate(deployment, rc.Conf)
-			updated, err := rc.WorkloadClient.UpdateDeployment(rc.Ctx, request, rc.Conf.Workloads.Id, id)
+			request := transformWorkloadDeploymentRequestUpdate(deployment, rc.applicationIdByName(deployment.Strategy.Attributes.Application))
+			rc.journalUpdate(resourceRef{Kind: kindDeployment, ID: id, ParentID: workloadID}, deployment.Name, request)
+			updated, err := callAPIWithResult(rc, func() (apiWorkloads.DeploymentResponse, error) {
+				return rc.WorkloadClient.UpdateDeployment(rc.Ctx, request, workloadID, id)
+			})
 			if err != nil {
 				return err
 			}
+			rc.markApplied(kindDeployment, id)
 			msgf := fmt.Sprintf(msg.ManifestUpdateWorkloadDeployment, updated.GetName(), updated.GetId())
 			logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 			*rc.Msgs = append(*rc.Msgs, msgf)
 		} else {
-			request := transformWorkloadDeploymentRequestCreate(deployment, rc.Conf)
-			resp, err := rc.WorkloadClient.CreateDeployment(rc.Ctx, request, rc.Conf.Workloads.Id)
+			request := transformWorkloadDeploymentRequestCreate(deployment, rc.applicationIdByName(deployment.Strategy.Attributes.Application))
+			resp, err := callAPIWithResult(rc, func() (apiWorkloads.DeploymentResponse, error) {
+				return rc.WorkloadClient.CreateDeployment(rc.Ctx, request, workloadID)
+			})
 			if err != nil {
 				return err
 			}
+			rc.journalCreate(resourceRef{Kind: kindDeployment, ID: resp.GetId(), ParentID: workloadID}, resp.GetName())
 			rc.Conf.Workloads.Deployments = append(rc.Conf.Workloads.Deployments, contracts.Deployments{
 				Id:   resp.GetId(),
 				Name: resp.GetName(),
 			})
+			rc.markApplied(kindDeployment, resp.GetId())
 			msgf := fmt.Sprintf(msg.ManifestCreateWorkloadDeployment, resp.GetName(), resp.GetId())
 			logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 			*rc.Msgs = append(*rc.Msgs, msgf)
@@ -850,7 +995,10 @@ func (rc *ResourceContext) ApplyFirewalls(firewalls []contracts.FirewallManifest
 			if fwMan.Modules != nil {
 				updateReq.SetModules(*fwMan.Modules)
 			}
-			updated, err := rc.FirewallClient.Update(rc.Ctx, updateReq, id)
+			rc.journalUpdate(resourceRef{Kind: kindFirewall, ID: id}, fwMan.Name, updateReq.PatchedFirewallRequest)
+			updated, err := callAPIWithResult(rc, func() (edgesdk.Firewall, error) {
+				return rc.FirewallClient.Update(rc.Ctx, updateReq, id)
+			})
 			if err != nil {
 				logger.Debug("Error while updating firewall", zap.Error(err))
 				return err
@@ -877,7 +1025,9 @@ func (rc *ResourceContext) ApplyFirewalls(firewalls []contracts.FirewallManifest
 				if fwMan.Modules != nil {
 					createReq.SetModules(*fwMan.Modules)
 				}
-				created, err := rc.FirewallClient.Create(rc.Ctx, createReq)
+				created, err := callAPIWithResult(rc, func() (edgesdk.Firewall, error) {
+					return rc.FirewallClient.Create(rc.Ctx, createReq)
+				})
 				if err != nil {
 					// if the name is already in use, we ask for another one
 					if errors.Is(err, utils.ErrorNameInUse) || strings.Contains(err.Error(), utils.ErrorNameInUse.Error()) {
@@ -898,6 +1048,7 @@ func (rc *ResourceContext) ApplyFirewalls(firewalls []contracts.FirewallManifest
 				firewallId = created.GetId()
 				firewallName = created.GetName()
 				rc.FirewallIds[firewallName] = firewallId
+				rc.journalCreate(resourceRef{Kind: kindFirewall, ID: firewallId}, firewallName)
 				msgf := fmt.Sprintf(msg.ManifestCreateFirewall, created.GetName(), created.GetId())
 				logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 				*rc.Msgs = append(*rc.Msgs, msgf)
@@ -910,6 +1061,8 @@ func (rc *ResourceContext) ApplyFirewalls(firewalls []contracts.FirewallManifest
 			}
 		}
 
+		rc.markApplied(kindFirewall, firewallId)
+
 		fwFuncInstConf := []contracts.AzionJsonDataFirewallFunctionInstance{}
 		for _, funcInst := range fwMan.FunctionsInstances {
 			funcID, funcConf, found := rc.resolveFunctionReference(funcInst.Function)
@@ -928,7 +1081,11 @@ func (rc *ResourceContext) ApplyFirewalls(firewalls []contracts.FirewallManifest
 					updateReq.SetArgs(funcInst.Args)
 				}
 
-				updated, err := rc.FirewallFunctionInstClient.Update(rc.Ctx, firewallId, funcInstRef.FunctionInstanceId, updateReq)
+				rc.journalUpdate(resourceRef{Kind: kindFirewallFunctionInstance, ID: funcInstRef.FunctionInstanceId, ParentID: firewallId}, funcInst.Name,
+					updateReq.PatchedFirewallFunctionInstanceRequest)
+				updated, err := callAPIWithResult(rc, func() (edgesdk.FirewallFunctionInstance, error) {
+					return rc.FirewallFunctionInstClient.Update(rc.Ctx, firewallId, funcInstRef.FunctionInstanceId, updateReq)
+				})
 				if err != nil {
 					logger.Debug("Error while updating firewall function instance", zap.Error(err))
 					return err
@@ -940,6 +1097,7 @@ func (rc *ResourceContext) ApplyFirewalls(firewalls []contracts.FirewallManifest
 					Active:     funcInst.Active,
 					Args:       funcInst.Args,
 				})
+				rc.markApplied(kindFirewallFunctionInstance, updated.GetId())
 				msgf := fmt.Sprintf(msg.ManifestUpdateFirewallFunctionInstance, updated.GetName(), updated.GetId())
 				logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 				*rc.Msgs = append(*rc.Msgs, msgf)
@@ -952,7 +1110,9 @@ func (rc *ResourceContext) ApplyFirewalls(firewalls []contracts.FirewallManifest
 					createReq.SetArgs(funcInst.Args)
 				}
 
-				created, err := rc.FirewallFunctionInstClient.Create(rc.Ctx, firewallId, createReq)
+				created, err := callAPIWithResult(rc, func() (edgesdk.FirewallFunctionInstance, error) {
+					return rc.FirewallFunctionInstClient.Create(rc.Ctx, firewallId, createReq)
+				})
 				if err != nil {
 					logger.Debug("Error while creating firewall function instance", zap.Error(err))
 					return err
@@ -965,14 +1125,17 @@ func (rc *ResourceContext) ApplyFirewalls(firewalls []contracts.FirewallManifest
 					Args:       funcInst.Args,
 				})
 
+				rc.journalCreate(resourceRef{Kind: kindFirewallFunctionInstance, ID: created.GetId(), ParentID: firewallId}, created.GetName())
 				rc.FirewallFunctionInstIds[funcInst.Name] = firewallFunctionInstIdRef{
 					FirewallId:         firewallId,
 					FunctionInstanceId: created.GetId(),
 				}
+				rc.markApplied(kindFirewallFunctionInstance, created.GetId())
 				msgf := fmt.Sprintf(msg.ManifestCreateFirewallFunctionInstance, created.GetName(), created.GetId())
 				logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 				*rc.Msgs = append(*rc.Msgs, msgf)
 			}
+			rc.markApplied(kindFunction, funcID)
 			// Use funcConf to avoid unused variable error if only ID was provided
 			_ = funcConf
 		}
@@ -1007,7 +1170,10 @@ func (rc *ResourceContext) ApplyFirewalls(firewalls []contracts.FirewallManifest
 				patchReq.SetCriteria(sdkRule.Criteria)
 				patchReq.SetBehaviors(sdkRule.Behaviors)
 
-				updated, err := rc.FirewallClient.UpdateRule(rc.Ctx, firewallId, ruleRef.RuleId, patchReq)
+				rc.journalUpdate(resourceRef{Kind: kindFirewallRule, ID: ruleRef.RuleId, ParentID: firewallId}, rule.Name, patchReq)
+				updated, err := callAPIWithResult(rc, func() (edgesdk.FirewallRule, error) {
+					return rc.FirewallClient.UpdateRule(rc.Ctx, firewallId, ruleRef.RuleId, patchReq)
+				})
 				if err != nil {
 					logger.Debug("Error while updating firewall rule", zap.Error(err))
 					return err
@@ -1016,11 +1182,14 @@ func (rc *ResourceContext) ApplyFirewalls(firewalls []contracts.FirewallManifest
 					Id:   updated.GetId(),
 					Name: updated.GetName(),
 				})
+				rc.markApplied(kindFirewallRule, updated.GetId())
 				msgf := fmt.Sprintf(msg.ManifestUpdateFirewallRule, updated.GetName(), updated.GetId())
 				logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 				*rc.Msgs = append(*rc.Msgs, msgf)
 			} else {
-				created, err := rc.FirewallClient.CreateRule(rc.Ctx, firewallId, sdkRule)
+				created, err := callAPIWithResult(rc, func() (edgesdk.FirewallRule, error) {
+					return rc.FirewallClient.CreateRule(rc.Ctx, firewallId, sdkRule)
+				})
 				if err != nil {
 					logger.Debug("Error while creating firewall rule", zap.Error(err))
 					return err
@@ -1029,6 +1198,8 @@ func (rc *ResourceContext) ApplyFirewalls(firewalls []contracts.FirewallManifest
 					Id:   created.GetId(),
 					Name: created.GetName(),
 				})
+				rc.journalCreate(resourceRef{Kind: kindFirewallRule, ID: created.GetId(), ParentID: firewallId}, created.GetName())
+				rc.markApplied(kindFirewallRule, created.GetId())
 				msgf := fmt.Sprintf(msg.ManifestCreateFirewallRule, created.GetName(), created.GetId())
 				logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
 				*rc.Msgs = append(*rc.Msgs, msgf)
@@ -1049,7 +1220,9 @@ func (rc *ResourceContext) ApplyFirewalls(firewalls []contracts.FirewallManifest
 
 func (rc *ResourceContext) ApplyPurge(purges []contracts.PurgeManifest) error {
 	for _, purgeObj := range purges {
-		err := rc.PurgeClient.PurgeCache(rc.Ctx, purgeObj.Items, purgeObj.Type, *purgeObj.Layer)
+		err := rc.callAPI(func() error {
+			return rc.PurgeClient.PurgeCache(rc.Ctx, purgeObj.Items, purgeObj.Type, *purgeObj.Layer)
+		})
 		if err != nil {
 			logger.Debug("Error while purging domains", zap.Error(err))
 			return err
@@ -1061,8 +1234,26 @@ func (rc *ResourceContext) ApplyPurge(purges []contracts.PurgeManifest) error {
 	return nil
 }
 
+// DeleteOrphanedResources removes every resource tracked in azion.json that is no longer declared
+// in the manifest: the cache settings and rules left over in the applied applications, as well as
+// workload deployments, function instances, applications, firewalls, connectors and functions.
 func (rc *ResourceContext) DeleteOrphanedResources() error {
-	return deleteResources(rc.Ctx, rc.Factory, rc.Conf, rc.Msgs)
+	if rc.Conf.SkipDeletion != nil && *rc.Conf.SkipDeletion {
+		logger.FInfoFlags(rc.Factory.IOStreams.Out, msg.SkipDeletion, rc.Factory.Format, rc.Factory.Out)
+		*rc.Msgs = append(*rc.Msgs, msg.SkipDeletion)
+		return nil
+	}
+
+	rc.saveActiveApplication()
+	for _, name := range rc.appliedApplications {
+		scope := rc.applications[name]
+		err := deleteApplicationResources(rc.Ctx, rc.Factory, scope.Conf.ID, scope.CacheIds, scope.RuleIds, rc.Msgs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rc.deleteOrphans(rc.orphanedResources())
 }
 
 func (rc *ResourceContext) transformBehaviorsRequest(behaviors []contracts.ManifestRuleBehavior) ([]edgesdk.RequestPhaseBehaviorRequest, error) {
@@ -1249,7 +1440,9 @@ func (rc *ResourceContext) ApplyStorage(storages []contracts.StorageManifest) er
 			// Bucket already exists, update it if needed
 			logger.Debug("Bucket already exists, updating", zap.String("name", storage.Name))
 			if storage.WorkloadsAccess != "" {
-				err := rc.StorageClient.UpdateBucket(rc.Ctx, storage.Name, storage.WorkloadsAccess)
+				err := rc.callAPI(func() error {
+					return rc.StorageClient.UpdateBucket(rc.Ctx, storage.Name, storage.WorkloadsAccess)
+				})
 				if err != nil {
 					return err
 				}
@@ -1266,10 +1459,13 @@ func (rc *ResourceContext) ApplyStorage(storages []contracts.StorageManifest) er
 					WorkloadsAccess: storage.WorkloadsAccess,
 				},
 			}
-			err := rc.StorageClient.CreateBucket(rc.Ctx, request)
+			err := rc.callAPI(func() error {
+				return rc.StorageClient.CreateBucket(rc.Ctx, request)
+			})
 			if err != nil {
 				return err
 			}
+			rc.journalCreate(resourceRef{Kind: kindStorage}, storage.Name)
 			rc.Conf.Bucket = storage.Name
 			msgf := fmt.Sprintf(msg.ManifestCreateStorage, storage.Name)
 			logger.FInfoFlags(rc.Factory.IOStreams.Out, msgf, rc.Factory.Format, rc.Factory.Out)
@@ -0,0 +1,208 @@
+package manifest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	msg "github.com/aziontech/azion-cli/messages/manifest"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/schema"
+)
+
+var (
+	//go:embed schema/manifest.schema.json
+	manifestSchemaJSON []byte
+	//go:embed schema/azion.schema.json
+	azionSchemaJSON []byte
+
+	manifestSchema = schema.MustCompile(manifestSchemaJSON)
+	azionSchema    = schema.MustCompile(azionSchemaJSON)
+)
+
+// manifestReferences holds the parts of manifest.json that refer to other resources by name.
+// It is decoded on its own so references are checked even when SDK typed fields are invalid.
+type manifestReferences struct {
+	KV []struct {
+		Name string `json:"name"`
+	} `json:"kv"`
+	Functions []struct {
+		Name     string                     `json:"name"`
+		Bindings contracts.FunctionBindings `json:"bindings"`
+	} `json:"functions"`
+	Applications []struct {
+		Name          string `json:"name"`
+		CacheSettings []struct {
+			Name string `json:"name"`
+		} `json:"cache_settings"`
+		FunctionsInstances []contracts.FunctionInstance `json:"functions_instances"`
+		Rules              []struct {
+			Rule struct {
+				Behaviors []contracts.ManifestRuleBehavior `json:"behaviors"`
+			} `json:"rule"`
+		} `json:"rules"`
+	} `json:"applications"`
+	Connectors []struct {
+		Name string `json:"name"`
+	} `json:"connectors"`
+	WorkloadDeployments []contracts.WorkloadDeployment `json:"workload_deployments"`
+	Firewalls           []struct {
+		Name               string                       `json:"name"`
+		FunctionsInstances []contracts.FunctionInstance `json:"functions_instances"`
+		RulesEngine        []struct {
+			Behaviors []contracts.FirewallManifestBehavior `json:"behaviors"`
+		} `json:"rules_engine"`
+	} `json:"firewall"`
+}
+
+// ValidateManifest checks a manifest.json document against the embedded manifest schema and checks that every
+// resource referred to by name is declared: the KV namespaces functions are bound to, the functions of function
+// instances, the function instances, cache settings and connectors used by rules and the applications and firewalls
+// of workload deployments.
+// It does not reach Azion Platform. Problems are sorted by line.
+func ValidateManifest(document []byte) []schema.Problem {
+	problems := manifestSchema.Validate(document)
+
+	refs := manifestReferences{}
+	if err := json.Unmarshal(document, &refs); err != nil {
+		// the schema already reports documents whose structure cannot be decoded
+		return problems
+	}
+	lines, err := schema.Lines(document)
+	if err != nil {
+		return problems
+	}
+
+	references := refs.check()
+	for i := range references {
+		references[i].Line = lines.Line(references[i].Pointer)
+	}
+	problems = append(problems, references...)
+	schema.Sort(problems)
+	return problems
+}
+
+// ValidateAzionJson checks an azion.json document against the embedded azion.json schema
+func ValidateAzionJson(document []byte) []schema.Problem {
+	return azionSchema.Validate(document)
+}
+
+func (refs *manifestReferences) check() []schema.Problem {
+	problems := []schema.Problem{}
+	report := func(pointer, format string, args ...any) {
+		problems = append(problems, schema.Problem{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	namespaces := declaredNames{}
+	for i, namespace := range refs.KV {
+		namespaces.add(namespace.Name, schema.Pointer("kv", i, "name"), "KV Namespace", report)
+	}
+
+	functions := declaredNames{}
+	for i, function := range refs.Functions {
+		functions.add(function.Name, schema.Pointer("functions", i, "name"), "Function", report)
+		if namespace := function.Bindings.KV.Namespace; namespace != "" && !namespaces.has(namespace) {
+			report(schema.Pointer("functions", i, "bindings", "kv", "namespace"), msg.ErrorReferenceKVNamespace, namespace)
+		}
+	}
+	connectors := declaredNames{}
+	for i, connector := range refs.Connectors {
+		connectors.add(connector.Name, schema.Pointer("connectors", i, "name"), "Connector", report)
+	}
+
+	// checkInstances returns the function instances declared by an application or firewall, whose names
+	// must not be repeated within unique
+	checkInstances := func(parent string, index int, instances []contracts.FunctionInstance, unique declaredNames) declaredNames {
+		declared := declaredNames{}
+		for j, instance := range instances {
+			pointer := schema.Pointer(parent, index, "functions_instances", j, "name")
+			unique.add(instance.Name, pointer, "Function Instance", report)
+			declared[instance.Name] = pointer
+			if instance.Function.Name != "" && !functions.has(instance.Function.Name) {
+				report(schema.Pointer(parent, index, "functions_instances", j, "function"), msg.ErrorReferenceFunction, instance.Function.Name)
+			}
+		}
+		return declared
+	}
+
+	// function instance names are unique across applications, since rules refer to them by name
+	applications, appInstances := declaredNames{}, declaredNames{}
+	for i, app := range refs.Applications {
+		applications.add(app.Name, schema.Pointer("applications", i, "name"), "Application", report)
+		instances := checkInstances("applications", i, app.FunctionsInstances, appInstances)
+		caches := declaredNames{}
+		for j, cache := range app.CacheSettings {
+			caches.add(cache.Name, schema.Pointer("applications", i, "cache_settings", j, "name"), "Cache Setting", report)
+		}
+
+		for j, rule := range app.Rules {
+			for k, behavior := range rule.Rule.Behaviors {
+				value, ok := behavior.Attributes["value"].(string)
+				if !ok {
+					continue
+				}
+				pointer := schema.Pointer("applications", i, "rules", j, "rule", "behaviors", k, "attributes", "value")
+				switch behavior.Type {
+				case "run_function":
+					if !instances.has(value) {
+						report(pointer, msg.ErrorReferenceFunctionInstance, value, fmt.Sprintf("Application '%s'", app.Name))
+					}
+				case "set_cache_policy":
+					if !caches.has(value) {
+						report(pointer, msg.ErrorReferenceCacheSetting, value, app.Name)
+					}
+				case "set_connector":
+					if !connectors.has(value) {
+						report(pointer, msg.ErrorReferenceConnector, value)
+					}
+				}
+			}
+		}
+	}
+
+	firewalls := declaredNames{}
+	for i, firewall := range refs.Firewalls {
+		firewalls.add(firewall.Name, schema.Pointer("firewall", i, "name"), "Firewall", report)
+		instances := checkInstances("firewall", i, firewall.FunctionsInstances, declaredNames{})
+		for j, rule := range firewall.RulesEngine {
+			for k, behavior := range rule.Behaviors {
+				value, ok := behavior.Attributes["value"].(string)
+				if behavior.Type == "run_function" && ok && !instances.has(value) {
+					report(schema.Pointer("firewall", i, "rules_engine", j, "behaviors", k, "attributes", "value"),
+						msg.ErrorReferenceFunctionInstance, value, fmt.Sprintf("Firewall '%s'", firewall.Name))
+				}
+			}
+		}
+	}
+
+	for i, deployment := range refs.WorkloadDeployments {
+		attributes := deployment.Strategy.Attributes
+		if attributes.Application != nil && *attributes.Application != "" && !applications.has(*attributes.Application) {
+			report(schema.Pointer("workload_deployments", i, "strategy", "attributes", "application"), msg.ErrorReferenceApplication, *attributes.Application)
+		}
+		if attributes.Firewall != nil && *attributes.Firewall != "" && !firewalls.has(*attributes.Firewall) {
+			report(schema.Pointer("workload_deployments", i, "strategy", "attributes", "firewall"), msg.ErrorReferenceFirewall, *attributes.Firewall)
+		}
+	}
+
+	return problems
+}
+
+// declaredNames keeps the pointer where each name of a kind of resource was first declared
+type declaredNames map[string]string
+
+func (n declaredNames) add(name, pointer, kind string, report func(pointer, format string, args ...any)) {
+	if name == "" {
+		return
+	}
+	if first, ok := n[name]; ok {
+		report(pointer, msg.ErrorDuplicateName, kind, name, first)
+		return
+	}
+	n[name] = pointer
+}
+
+func (n declaredNames) has(name string) bool {
+	_, ok := n[name]
+	return ok
+}
@@ -0,0 +1,159 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/schema"
+	"github.com/stretchr/testify/require"
+)
+
+const validManifest = `{
+  "build": {"preset": "react"},
+  "storage": [
+    {
+      "name": "assets",
+      "dir": ".edge/storage",
+      "compression": ["br", "gzip"],
+      "objects": [{"pattern": "*.js", "cache_control": "public, max-age=31536000"}],
+      "exclude": ["*.map"],
+      "keep_versions": 5
+    }
+  ],
+  "kv": [{"name": "flags", "data": "./kv/flags.ndjson"}, {"name": "sessions"}],
+  "functions": [{"name": "handler", "path": "./functions/handler.js", "bindings": {"kv": {"namespace": "flags"}}}],
+  "connectors": [{"name": "origin", "type": "http", "attributes": {}}],
+  "applications": [
+    {
+      "name": "api",
+      "functions_instances": [{"name": "handler-instance", "function": "handler"}],
+      "cache_settings": [{"name": "assets"}],
+      "rules": [
+        {
+          "phase": "request",
+          "rule": {
+            "name": "run",
+            "criteria": [[{"variable": "${uri}", "operator": "starts_with", "conditional": "if", "argument": "/"}]],
+            "behaviors": [
+              {"type": "run_function", "attributes": {"value": "handler-instance"}},
+              {"type": "set_cache_policy", "attributes": {"value": "assets"}},
+              {"type": "set_connector", "attributes": {"value": "origin"}}
+            ]
+          }
+        }
+      ]
+    }
+  ],
+  "workloads": [{"name": "api", "domains": ["example.com"]}],
+  "workload_deployments": [
+    {"name": "api", "current": true, "active": true, "strategy": {"type": "default", "attributes": {"application": "api"}}}
+  ],
+  "purge": [{"items": ["https://example.com/"], "type": "url", "layer": "cache"}],
+  "hooks": {"pre-build": ["npm test"], "post-deploy": ["./smoke.sh"], "rollback-on-failure": true}
+}`
+
+func TestValidateManifest(t *testing.T) {
+	t.Run("valid manifest", func(t *testing.T) {
+		require.Empty(t, ValidateManifest([]byte(validManifest)))
+	})
+
+	t.Run("schema and reference problems are reported with their location", func(t *testing.T) {
+		document := `{
+  "functions": [{"name": "handler", "path": "./handler.js"}],
+  "applications": [
+    {
+      "name": "api",
+      "cache_settings": [{"name": "assets"}],
+      "functions_instances": [{"name": "instance", "function": "handlr"}],
+      "rules": [
+        {
+          "phase": "request",
+          "rule": {
+            "name": "cache",
+            "criteria": [[{"variable": "${uri}", "operator": "starts_with", "conditional": "if", "argument": "/"}]],
+            "behaviors": [
+              {"type": "set_cache_policy", "attributes": {"value": "asset"}},
+              {"type": "set_connector", "attributes": {"value": "origin"}}
+            ]
+          }
+        }
+      ]
+    },
+    {"name": "api"}
+  ],
+  "workload_deployments": [
+    {"name": "api", "strategy": {"type": "default", "attributes": {"application": "web", "firewall": "waf"}}}
+  ],
+  "purge": [{"items": ["/"], "type": "url"}]
+}`
+
+		require.Equal(t, []schema.Problem{
+			{Pointer: "/applications/0/functions_instances/0/function", Line: 7, Message: "Function 'handlr' is not declared in functions"},
+			{Pointer: "/applications/0/rules/0/rule/behaviors/0/attributes/value", Line: 15, Message: "Cache Setting 'asset' is not declared in the cache_settings of Application 'api'"},
+			{Pointer: "/applications/0/rules/0/rule/behaviors/1/attributes/value", Line: 16, Message: "Connector 'origin' is not declared in connectors"},
+			{Pointer: "/applications/1/name", Line: 22, Message: "Application name 'api' is already declared at /applications/0/name"},
+			{Pointer: "/workload_deployments/0/strategy/attributes/application", Line: 25, Message: "Application 'web' is not declared in applications"},
+			{Pointer: "/workload_deployments/0/strategy/attributes/firewall", Line: 25, Message: "Firewall 'waf' is not declared in firewall"},
+			{Pointer: "/purge/0", Line: 27, Message: `missing required property "layer"`},
+		}, ValidateManifest([]byte(document)))
+	})
+
+	t.Run("firewall rules refer to the firewall function instances", func(t *testing.T) {
+		document := `{
+  "functions": [{"name": "handler", "path": "./handler.js"}],
+  "firewall": [
+    {
+      "name": "waf",
+      "functions_instances": [{"name": "guard", "function": "handler"}],
+      "rules_engine": [
+        {
+          "name": "block",
+          "criteria": [[{"variable": "${uri}", "operator": "starts_with", "conditional": "if", "argument": "/"}]],
+          "behaviors": [{"type": "run_function", "attributes": {"value": "gaurd"}}]
+        }
+      ]
+    }
+  ]
+}`
+
+		require.Equal(t, []schema.Problem{
+			{Pointer: "/firewall/0/rules_engine/0/behaviors/0/attributes/value", Line: 11, Message: "Function Instance 'gaurd' is not declared in the functions_instances of Firewall 'waf'"},
+		}, ValidateManifest([]byte(document)))
+	})
+
+	t.Run("kv namespaces are declared once", func(t *testing.T) {
+		document := `{
+  "kv": [{"name": "flags"}, {"name": "flags", "data": ""}]
+}`
+
+		require.Equal(t, []schema.Problem{
+			{Pointer: "/kv/1/data", Line: 2, Message: "must have at least 1 character(s)"},
+			{Pointer: "/kv/1/name", Line: 2, Message: "KV Namespace name 'flags' is already declared at /kv/0/name"},
+		}, ValidateManifest([]byte(document)))
+	})
+
+	t.Run("functions are bound to declared kv namespaces", func(t *testing.T) {
+		document := `{
+  "kv": [{"name": "flags"}],
+  "functions": [{"name": "handler", "path": "./handler.js", "bindings": {"kv": {"namespace": "flag"}}}]
+}`
+
+		require.Equal(t, []schema.Problem{
+			{Pointer: "/functions/0/bindings/kv/namespace", Line: 3, Message: "KV Namespace 'flag' is not declared in kv"},
+		}, ValidateManifest([]byte(document)))
+	})
+
+	t.Run("unknown properties are typos", func(t *testing.T) {
+		problems := ValidateManifest([]byte("{\n  \"aplications\": []\n}"))
+		require.Equal(t, []schema.Problem{{Pointer: "/aplications", Line: 2, Message: `unknown property "aplications"`}}, problems)
+	})
+}
+
+func TestValidateAzionJson(t *testing.T) {
+	require.Empty(t, ValidateAzionJson([]byte(`{"name": "project", "application": {"id": 1, "name": "project"}, "function": [], "connectors": null}`)))
+	require.Empty(t, ValidateAzionJson([]byte(`{"name": "project", "kv": [{"name": "flags"}]}`)))
+	require.Empty(t, ValidateAzionJson([]byte(`{"name": "project", "hooks": {"pre-build": ["npm test"], "post-deploy": ["./smoke.sh"], "rollback-on-failure": true}}`)))
+	require.Empty(t, ValidateAzionJson([]byte(`{"name": "project", "health-check": {"paths": ["/", "/api/health"], "status": 200, "body": "ok", "retries": 5, "interval": 3, "timeout": 5}}`)))
+	require.Equal(t, []schema.Problem{
+		{Pointer: "/application/id", Line: 1, Message: "expected integer, found string"},
+	}, ValidateAzionJson([]byte(`{"name": "project", "application": {"id": "1"}}`)))
+}
@@ -0,0 +1,78 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/tablecli"
+	"github.com/fatih/color"
+)
+
+// ChangeSetOutput prints a set of changes as a table whose first column holds the action of each change.
+// Actions are coloured when colours are enabled; when a format is requested the change set itself is printed.
+type ChangeSetOutput struct {
+	GeneralOutput `json:"-" yaml:"-" toml:"-"`
+	ChangeSet     any        `json:"-" yaml:"-" toml:"-"`
+	Columns       []string   `json:"-" yaml:"-" toml:"-"`
+	Lines         [][]string `json:"-" yaml:"-" toml:"-"`
+}
+
+var actionColors = map[string]color.Attribute{
+	"create": color.FgGreen,
+	"update": color.FgYellow,
+	"delete": color.FgRed,
+}
+
+func (c *ChangeSetOutput) Format() (bool, error) {
+	formatted := false
+	if len(c.Flags.Format) > 0 || len(c.Flags.Out) > 0 {
+		formatted = true
+		err := format(c.ChangeSet, c.GeneralOutput)
+		if err != nil {
+			return formatted, err
+		}
+	}
+	return formatted, nil
+}
+
+func (c *ChangeSetOutput) Output() {
+	if len(c.Lines) == 0 {
+		logger.FInfo(c.Out, c.Msg)
+		return
+	}
+
+	tbl := tablecli.NewTable(c.Columns)
+	tbl.WithWriter(c.Out)
+
+	if !c.Flags.NoColor {
+		headerFmt := color.New(color.FgBlue, color.Underline).SprintfFunc()
+		tbl.WithHeaderFormatter(headerFmt).WithFirstColumnFormatter(actionFormatter)
+	}
+
+	for _, ln := range c.Lines {
+		tbl.AddRows(ln)
+	}
+
+	format := strings.Repeat("%s", len(tbl.GetHeader())) + "\n"
+	tbl.CalculateWidths(c.Columns)
+
+	logger.PrintHeader(tbl, format)
+	for _, row := range tbl.GetRows() {
+		logger.PrintRow(tbl, format, row)
+	}
+
+	if c.Msg != "" {
+		logger.FInfo(c.Out, c.Msg)
+	}
+}
+
+// actionFormatter colours the padded action column according to the action it holds
+func actionFormatter(format string, a ...interface{}) string {
+	cell := fmt.Sprintf(format, a...)
+	attr, ok := actionColors[strings.ToLower(strings.TrimSpace(cell))]
+	if !ok {
+		return cell
+	}
+	return color.New(attr).Sprint(cell)
+}
@@ -0,0 +1,61 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestChangeSetOutput(t *testing.T) {
+	logger.New(zapcore.DebugLevel)
+
+	changeSet := map[string]any{"changes": []map[string]string{{"action": "create", "name": "api"}}}
+
+	t.Run("format prints the change set", func(t *testing.T) {
+		outBuffer := &bytes.Buffer{}
+		c := &ChangeSetOutput{
+			GeneralOutput: GeneralOutput{Out: outBuffer, Flags: cmdutil.Flags{Format: "json"}},
+			ChangeSet:     changeSet,
+			Columns:       []string{"ACTION", "NAME"},
+			Lines:         [][]string{{"create", "api"}},
+		}
+		err := Print(c)
+		require.NoError(t, err)
+		require.Contains(t, outBuffer.String(), `"changes"`)
+		require.NotContains(t, outBuffer.String(), "ACTION")
+	})
+
+	t.Run("table lists every change", func(t *testing.T) {
+		outBuffer := &bytes.Buffer{}
+		c := &ChangeSetOutput{
+			GeneralOutput: GeneralOutput{Out: outBuffer, Flags: cmdutil.Flags{NoColor: true}},
+			ChangeSet:     changeSet,
+			Columns:       []string{"ACTION", "NAME"},
+			Lines:         [][]string{{"create", "api"}, {"delete", "old"}},
+		}
+		err := Print(c)
+		require.NoError(t, err)
+		require.Contains(t, outBuffer.String(), "ACTION")
+		require.Contains(t, outBuffer.String(), "delete")
+	})
+
+	t.Run("no changes prints the message", func(t *testing.T) {
+		outBuffer := &bytes.Buffer{}
+		c := &ChangeSetOutput{
+			GeneralOutput: GeneralOutput{Out: outBuffer, Msg: "No changes\n"},
+			ChangeSet:     changeSet,
+		}
+		err := Print(c)
+		require.NoError(t, err)
+		require.Equal(t, "No changes\n", outBuffer.String())
+	})
+}
+
+func TestActionFormatter(t *testing.T) {
+	require.Equal(t, "other ", actionFormatter("%s", "other "))
+	require.Contains(t, actionFormatter("%s", "create "), "create ")
+}
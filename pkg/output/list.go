@@ -12,6 +12,8 @@ type ListOutput struct {
 	GeneralOutput `json:"-" yaml:"-" toml:"-"`
 	Columns       []string   `json:"columns" yaml:"columns" toml:"columns"`
 	Lines         [][]string `json:"lines" yaml:"lines" toml:"lines"`
+	// Cursor is the position of the next page, for lists paged by cursor
+	Cursor string `json:"cursor,omitempty" yaml:"cursor,omitempty" toml:"cursor,omitempty"`
 }
 
 func (l *ListOutput) Format() (bool, error) {
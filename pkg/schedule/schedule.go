@@ -134,6 +134,21 @@ func (s *Factory) readFileScheduleForProfile(profile string) ([]Schedule, error)
 
 func ExecSchedules(factory *cmdutil.Factory) {
 	logger.Debug("Exec Schedules")
+	execSchedules(factory, func(s Schedule) bool {
+		// buckets are deleted a day after being scheduled, old versions right away
+		return s.Kind == DELETE_VERSION || CheckIf24HoursPassed(s.Time)
+	})
+}
+
+// ExecSchedulesOfKind runs the schedules of the given kind right away. The ones that fail are kept for the next run.
+func ExecSchedulesOfKind(factory *cmdutil.Factory, kind string) {
+	logger.Debug("Exec Schedules", zap.String("kind", kind))
+	execSchedules(factory, func(s Schedule) bool {
+		return s.Kind == kind
+	})
+}
+
+func execSchedules(factory *cmdutil.Factory, due func(s Schedule) bool) {
 	activeProfile := factory.GetActiveProfile()
 	schedules, err := factoryShedule.readFileScheduleForProfile(activeProfile)
 	if err != nil {
@@ -142,13 +157,33 @@ func ExecSchedules(factory *cmdutil.Factory) {
 	}
 
 	scheds := []Schedule{}
+	// versions are deleted together, so that each bucket is listed once
+	versions := []Schedule{}
 	for _, s := range schedules {
-		if CheckIf24HoursPassed(s.Time) {
-			if s.Kind == DELETE_BUCKET {
-				if err := TriggerDeleteBucket(factory, s.Name); err != nil {
-					logger.Debug("Event execution error", zap.Error(err))
-					scheds = append(scheds, s)
-				}
+		if !due(s) {
+			scheds = append(scheds, s)
+			continue
+		}
+		if s.Kind == DELETE_VERSION {
+			versions = append(versions, s)
+			continue
+		}
+		if err := trigger(factory, s); err != nil {
+			logger.Debug("Event execution error", zap.Error(err))
+			scheds = append(scheds, s)
+		}
+	}
+
+	if len(versions) > 0 {
+		names := make([]string, 0, len(versions))
+		for _, s := range versions {
+			names = append(names, s.Name)
+		}
+		failed := TriggerDeleteVersions(factory, names)
+		for _, s := range versions {
+			if err, ok := failed[s.Name]; ok {
+				logger.Debug("Event execution error", zap.Error(err))
+				scheds = append(scheds, s)
 			}
 		}
 	}
@@ -158,6 +193,14 @@ func ExecSchedules(factory *cmdutil.Factory) {
 	}
 }
 
+func trigger(factory *cmdutil.Factory, s Schedule) error {
+	switch s.Kind {
+	case DELETE_BUCKET:
+		return TriggerDeleteBucket(factory, s.Name)
+	}
+	return nil
+}
+
 // CheckIf24HoursPassed Checks if the current time is before 24 hours after the time 's'.
 func CheckIf24HoursPassed(passed time.Time) bool {
 	now := time.Now()
@@ -0,0 +1,86 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	api "github.com/aziontech/azion-cli/pkg/api/storage"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/utils"
+)
+
+const DELETE_VERSION = "DeleteVersion"
+
+// VersionName is the name of the schedule deleting the objects of a deployed version, kept under prefix in bucket
+func VersionName(bucket, prefix string) string {
+	return bucket + "/" + prefix
+}
+
+// TriggerDeleteVersion deletes every object under the prefix of a version. Objects already gone are skipped,
+// so it can be triggered again after failing halfway.
+func TriggerDeleteVersion(f *cmdutil.Factory, name string) error {
+	return TriggerDeleteVersions(f, []string{name})[name]
+}
+
+// TriggerDeleteVersions deletes the objects of several versions, listing each bucket once for all of its
+// versions. It returns the error of each version that could not be deleted.
+func TriggerDeleteVersions(f *cmdutil.Factory, names []string) map[string]error {
+	client := api.NewClient(
+		f.HttpClient,
+		f.Config.GetString("storage_url"),
+		f.Config.GetString("token"))
+	ctx := context.Background()
+
+	buckets := map[string][]string{}
+	for _, name := range names {
+		bucket, _, _ := strings.Cut(name, "/")
+		buckets[bucket] = append(buckets[bucket], name)
+	}
+
+	failed := map[string]error{}
+	for bucket, versions := range buckets {
+		keys, err := versionKeys(ctx, client, bucket, versions)
+		if err != nil {
+			for _, name := range versions {
+				failed[name] = err
+			}
+			continue
+		}
+		for _, name := range versions {
+			for _, key := range keys[name] {
+				if err := client.DeleteObject(ctx, bucket, key); err != nil && !errors.Is(err, utils.ErrorNotFound404) {
+					failed[name] = err
+					break
+				}
+			}
+		}
+	}
+	return failed
+}
+
+// versionKeys lists the objects of a bucket once and returns the keys found under the prefix of each version
+func versionKeys(ctx context.Context, client *api.Client, bucket string, versions []string) (map[string][]string, error) {
+	keys := map[string][]string{}
+	options := &contracts.ListOptions{}
+	for {
+		resp, err := client.ListObject(ctx, bucket, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range resp.Results {
+			for _, name := range versions {
+				_, prefix, _ := strings.Cut(name, "/")
+				if strings.HasPrefix(object.Key, prefix+"/") {
+					keys[name] = append(keys[name], object.Key)
+					break
+				}
+			}
+		}
+		if resp.GetContinuationToken() == "" {
+			return keys, nil
+		}
+		options.ContinuationToken = resp.GetContinuationToken()
+	}
+}
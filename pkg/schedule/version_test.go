@@ -0,0 +1,98 @@
+package schedule
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aziontech/azion-cli/pkg/httpmock"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestTriggerDeleteVersion(t *testing.T) {
+	logger.New(zapcore.DebugLevel)
+
+	objects := `{
+		"continuation_token": "",
+		"results": [
+			{"key": "20240101120000/index.html", "size": 10},
+			{"key": "20240101120000/assets/app.js", "size": 20},
+			{"key": "20240202120000/index.html", "size": 10}
+		]
+	}`
+
+	t.Run("deletes the objects of the version only", func(t *testing.T) {
+		mock := &httpmock.Registry{}
+		mock.Register(
+			httpmock.REST(http.MethodGet, "workspace/storage/buckets/arthur-morgan/objects"),
+			httpmock.JSONFromString(objects),
+		)
+		mock.Register(
+			httpmock.REST(http.MethodDelete, "workspace/storage/buckets/arthur-morgan/objects/20240101120000/index.html"),
+			httpmock.StatusStringResponse(http.StatusNoContent, ""),
+		)
+		mock.Register(
+			httpmock.REST(http.MethodDelete, "workspace/storage/buckets/arthur-morgan/objects/20240101120000/assets/app.js"),
+			httpmock.StatusStringResponse(http.StatusNoContent, ""),
+		)
+
+		f, _, _ := testutils.NewFactory(mock)
+		require.NoError(t, TriggerDeleteVersion(f, VersionName("arthur-morgan", "20240101120000")))
+		mock.Verify(t)
+	})
+
+	t.Run("objects already deleted are skipped", func(t *testing.T) {
+		mock := &httpmock.Registry{}
+		mock.Register(
+			httpmock.REST(http.MethodGet, "workspace/storage/buckets/arthur-morgan/objects"),
+			httpmock.JSONFromString(objects),
+		)
+		mock.Register(
+			httpmock.REST(http.MethodDelete, "workspace/storage/buckets/arthur-morgan/objects/20240202120000/index.html"),
+			httpmock.StatusStringResponse(http.StatusNotFound, "{}"),
+		)
+
+		f, _, _ := testutils.NewFactory(mock)
+		require.NoError(t, TriggerDeleteVersion(f, VersionName("arthur-morgan", "20240202120000")))
+	})
+
+	t.Run("versions of the same bucket are listed once", func(t *testing.T) {
+		mock := &httpmock.Registry{}
+		// each stub answers a single request
+		mock.Register(
+			httpmock.REST(http.MethodGet, "workspace/storage/buckets/arthur-morgan/objects"),
+			httpmock.JSONFromString(objects),
+		)
+		for _, key := range []string{"20240101120000/index.html", "20240101120000/assets/app.js", "20240202120000/index.html"} {
+			mock.Register(
+				httpmock.REST(http.MethodDelete, "workspace/storage/buckets/arthur-morgan/objects/"+key),
+				httpmock.StatusStringResponse(http.StatusNoContent, ""),
+			)
+		}
+
+		f, _, _ := testutils.NewFactory(mock)
+		failed := TriggerDeleteVersions(f, []string{
+			VersionName("arthur-morgan", "20240101120000"),
+			VersionName("arthur-morgan", "20240202120000"),
+		})
+		require.Empty(t, failed)
+		mock.Verify(t)
+	})
+
+	t.Run("versions whose bucket cannot be listed are reported", func(t *testing.T) {
+		mock := &httpmock.Registry{}
+		mock.Register(
+			httpmock.REST(http.MethodGet, "workspace/storage/buckets/arthur-morgan/objects"),
+			httpmock.StatusStringResponse(http.StatusInternalServerError, "{}"),
+		)
+
+		f, _, _ := testutils.NewFactory(mock)
+		failed := TriggerDeleteVersions(f, []string{
+			VersionName("arthur-morgan", "20240101120000"),
+			VersionName("arthur-morgan", "20240202120000"),
+		})
+		require.Len(t, failed, 2)
+	})
+}
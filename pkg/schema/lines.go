@@ -0,0 +1,126 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LineIndex maps the JSON pointer of every value of a document to the line the value starts at
+type LineIndex map[string]int
+
+// Lines indexes the line of every value of a JSON document
+func Lines(document []byte) (LineIndex, error) {
+	type frame struct {
+		pointer   string
+		object    bool
+		key       string
+		index     int
+		expectKey bool
+	}
+
+	index := LineIndex{}
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	stack := []*frame{}
+	line, counted := 1, 0
+
+	// next returns the pointer of the value about to be read and moves the parent to its following member
+	next := func() string {
+		if len(stack) == 0 {
+			return ""
+		}
+		top := stack[len(stack)-1]
+		if top.object {
+			top.expectKey = true
+			return top.pointer + "/" + escape(top.key)
+		}
+		pointer := top.pointer + "/" + strconv.Itoa(top.index)
+		top.index++
+		return pointer
+	}
+
+	for {
+		start := skipSeparators(document, int(decoder.InputOffset()))
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		if len(stack) > 0 {
+			if top := stack[len(stack)-1]; top.object && top.expectKey {
+				top.key, _ = token.(string)
+				top.expectKey = false
+				continue
+			}
+		}
+
+		line += bytes.Count(document[counted:start], []byte("\n"))
+		counted = start
+		pointer := next()
+		index[pointer] = line
+
+		if delim, ok := token.(json.Delim); ok {
+			stack = append(stack, &frame{pointer: pointer, object: delim == '{', expectKey: delim == '{'})
+		}
+	}
+
+	return index, nil
+}
+
+// Line returns the line of the value at the given pointer. When the value is missing, the line of its closest
+// existing parent is returned.
+func (l LineIndex) Line(pointer string) int {
+	for {
+		if line, ok := l[pointer]; ok {
+			return line
+		}
+		i := strings.LastIndex(pointer, "/")
+		if i < 0 {
+			return 1
+		}
+		pointer = pointer[:i]
+	}
+}
+
+// Pointer builds a JSON pointer from its reference tokens
+func Pointer(tokens ...any) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		switch t := token.(type) {
+		case string:
+			b.WriteString(escape(t))
+		case int:
+			b.WriteString(strconv.Itoa(t))
+		}
+	}
+	return b.String()
+}
+
+func escape(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// skipSeparators moves the offset past the whitespace, colons and commas preceding the next token
+func skipSeparators(document []byte, offset int) int {
+	for offset < len(document) {
+		switch document[offset] {
+		case ' ', '\t', '\r', '\n', ':', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
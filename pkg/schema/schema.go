@@ -0,0 +1,318 @@
+// Package schema validates JSON documents against JSON Schemas without any network access.
+// It supports the subset of JSON Schema used by the schemas shipped with the CLI: $ref to local $defs, type,
+// enum, properties, required, additionalProperties, items, minItems, minLength and minimum.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	errorInvalidJSON     = "invalid JSON: %v"
+	errorNotAllowed      = "value is not allowed"
+	errorType            = "expected %s, found %s"
+	errorEnum            = "value must be one of %s"
+	errorRequired        = "missing required property %q"
+	errorUnknownProperty = "unknown property %q"
+	errorMinItems        = "must have at least %d item(s)"
+	errorMinLength       = "must have at least %d character(s)"
+	errorMinimum         = "must be greater than or equal to %s"
+)
+
+// Problem is a violation found in a document, located by its JSON pointer and the line it starts at
+type Problem struct {
+	Pointer string `json:"pointer"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// Schema is a compiled JSON Schema
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+
+	// never is set for the boolean schema false, which no value matches
+	never bool
+	root  *Schema
+}
+
+// Types holds the types allowed by a schema, written either as a single type or as a list of types
+type Types []string
+
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	switch string(bytes.TrimSpace(data)) {
+	case "true":
+		*s = Schema{}
+		return nil
+	case "false":
+		*s = Schema{never: true}
+		return nil
+	}
+	type plain Schema
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// Compile parses a JSON Schema and checks that every $ref it holds can be resolved
+func Compile(data []byte) (*Schema, error) {
+	s := &Schema{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if err := s.link(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// MustCompile is like Compile but panics when the schema is invalid. It is meant for schemas embedded in the CLI.
+func MustCompile(data []byte) *Schema {
+	s, err := Compile(data)
+	if err != nil {
+		panic(fmt.Sprintf("schema: %v", err))
+	}
+	return s
+}
+
+// link sets the root of every subschema and checks the references
+func (s *Schema) link(root *Schema) error {
+	if s == nil {
+		return nil
+	}
+	s.root = root
+	if s.Ref != "" {
+		if _, err := s.resolve(); err != nil {
+			return err
+		}
+	}
+	for _, def := range s.Defs {
+		if err := def.link(root); err != nil {
+			return err
+		}
+	}
+	for _, prop := range s.Properties {
+		if err := prop.link(root); err != nil {
+			return err
+		}
+	}
+	if err := s.AdditionalProperties.link(root); err != nil {
+		return err
+	}
+	return s.Items.link(root)
+}
+
+func (s *Schema) resolve() (*Schema, error) {
+	name, ok := strings.CutPrefix(s.Ref, "#/$defs/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported reference %q", s.Ref)
+	}
+	def, ok := s.root.Defs[name]
+	if !ok {
+		return nil, fmt.Errorf("reference %q not found", s.Ref)
+	}
+	return def, nil
+}
+
+// Validate checks a JSON document against the schema and returns every problem found, sorted by line.
+// A document that is not valid JSON results in a single problem pointing to the syntax error.
+func (s *Schema) Validate(document []byte) []Problem {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return []Problem{syntaxProblem(document, err)}
+	}
+
+	lines, err := Lines(document)
+	if err != nil {
+		return []Problem{syntaxProblem(document, err)}
+	}
+
+	problems := []Problem{}
+	s.validate(value, "", &problems)
+	for i := range problems {
+		problems[i].Line = lines.Line(problems[i].Pointer)
+	}
+	Sort(problems)
+	return problems
+}
+
+// Sort orders problems by line, then by pointer
+func Sort(problems []Problem) {
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+		return problems[i].Pointer < problems[j].Pointer
+	})
+}
+
+func (s *Schema) validate(value any, pointer string, problems *[]Problem) {
+	if s.Ref != "" {
+		def, _ := s.resolve()
+		def.validate(value, pointer, problems)
+		return
+	}
+	report := func(format string, args ...any) {
+		*problems = append(*problems, Problem{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.never {
+		report(errorNotAllowed)
+		return
+	}
+
+	if len(s.Type) > 0 && !s.Type.match(value) {
+		report(errorType, strings.Join(s.Type, " or "), typeOf(value))
+		return
+	}
+
+	if len(s.Enum) > 0 && !s.enumContains(value) {
+		allowed := make([]string, 0, len(s.Enum))
+		for _, option := range s.Enum {
+			encoded, _ := json.Marshal(option)
+			allowed = append(allowed, string(encoded))
+		}
+		report(errorEnum, strings.Join(allowed, ", "))
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				report(errorRequired, name)
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := pointer + "/" + escape(key)
+			if prop, ok := s.Properties[key]; ok {
+				prop.validate(v[key], child, problems)
+				continue
+			}
+			if s.AdditionalProperties == nil {
+				continue
+			}
+			if s.AdditionalProperties.never {
+				*problems = append(*problems, Problem{Pointer: child, Message: fmt.Sprintf(errorUnknownProperty, key)})
+				continue
+			}
+			s.AdditionalProperties.validate(v[key], child, problems)
+		}
+	case []any:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			report(errorMinItems, *s.MinItems)
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(item, pointer+"/"+strconv.Itoa(i), problems)
+			}
+		}
+	case string:
+		if s.MinLength != nil && len([]rune(v)) < *s.MinLength {
+			report(errorMinLength, *s.MinLength)
+		}
+	case json.Number:
+		if s.Minimum != nil {
+			if n, err := v.Float64(); err == nil && n < *s.Minimum {
+				report(errorMinimum, strconv.FormatFloat(*s.Minimum, 'f', -1, 64))
+			}
+		}
+	}
+}
+
+func (t Types) match(value any) bool {
+	actual := typeOf(value)
+	for _, expected := range t {
+		if expected == actual || (expected == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schema) enumContains(value any) bool {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	for _, option := range s.Enum {
+		expected, err := json.Marshal(option)
+		if err == nil && bytes.Equal(encoded, expected) {
+			return true
+		}
+	}
+	return false
+}
+
+// typeOf names the JSON type of a decoded value
+func typeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case json.Number:
+		if f, err := v.Float64(); err == nil && f == math.Trunc(f) && !strings.ContainsAny(v.String(), ".eE") {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// syntaxProblem reports a document that could not be decoded at the line where decoding stopped
+func syntaxProblem(document []byte, err error) Problem {
+	line := 1
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line = lineAt(document, syntaxErr.Offset)
+	}
+	return Problem{Pointer: "", Line: line, Message: fmt.Sprintf(errorInvalidJSON, err)}
+}
+
+func lineAt(document []byte, offset int64) int {
+	if offset > int64(len(document)) {
+		offset = int64(len(document))
+	}
+	return bytes.Count(document[:offset], []byte("\n")) + 1
+}
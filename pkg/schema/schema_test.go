@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["name"],
+  "properties": {
+    "name": { "type": "string", "minLength": 1 },
+    "items": { "type": "array", "minItems": 1, "items": { "$ref": "#/$defs/item" } }
+  },
+  "$defs": {
+    "item": {
+      "type": "object",
+      "required": ["type"],
+      "properties": {
+        "type": { "enum": ["url", "wildcard"] },
+        "ttl": { "type": "integer", "minimum": 0 },
+        "ref": { "type": ["string", "integer"] }
+      }
+    }
+  }
+}`
+
+func TestValidate(t *testing.T) {
+	s, err := Compile([]byte(testSchema))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		document string
+		want     []Problem
+	}{
+		{
+			name:     "valid document",
+			document: `{"name": "app", "items": [{"type": "url", "ttl": 10, "ref": 1}, {"type": "wildcard", "ref": "a"}]}`,
+			want:     []Problem{},
+		},
+		{
+			name: "every problem is reported with its pointer and line",
+			document: `{
+  "name": "",
+  "itens": [],
+  "items": [
+    {
+      "type": "uri",
+      "ttl": 1.5
+    },
+    {
+      "ref": true
+    }
+  ]
+}`,
+			want: []Problem{
+				{Pointer: "/name", Line: 2, Message: "must have at least 1 character(s)"},
+				{Pointer: "/itens", Line: 3, Message: `unknown property "itens"`},
+				{Pointer: "/items/0/type", Line: 6, Message: `value must be one of "url", "wildcard"`},
+				{Pointer: "/items/0/ttl", Line: 7, Message: "expected integer, found number"},
+				{Pointer: "/items/1", Line: 9, Message: `missing required property "type"`},
+				{Pointer: "/items/1/ref", Line: 10, Message: "expected string or integer, found boolean"},
+			},
+		},
+		{
+			name:     "invalid JSON",
+			document: "{\n  \"name\": \"app\",\n}",
+			want:     []Problem{{Pointer: "", Line: 3, Message: "invalid JSON: invalid character '}' looking for beginning of object key string"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, s.Validate([]byte(tt.document)))
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	_, err := Compile([]byte(`{"properties": {"a": {"$ref": "#/$defs/missing"}}}`))
+	require.ErrorContains(t, err, "missing")
+}
+
+func TestLines(t *testing.T) {
+	lines, err := Lines([]byte(`{
+  "a/b": [
+    1,
+    {"c": null}
+  ],
+  "d": {}
+}`))
+	require.NoError(t, err)
+	require.Equal(t, LineIndex{"": 1, "/a~1b": 2, "/a~1b/0": 3, "/a~1b/1": 4, "/a~1b/1/c": 4, "/d": 6}, lines)
+	require.Equal(t, 4, lines.Line("/a~1b/1/missing"))
+	require.Equal(t, "/a~1b/1/c", Pointer("a/b", 1, "c"))
+}
@@ -0,0 +1,174 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	msg "github.com/aziontech/azion-cli/messages/state"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/utils"
+	"go.uber.org/zap"
+)
+
+// LockFile is created next to azion.json while a command changes the project
+const LockFile = "azion.lock"
+
+// LockInfo identifies the process holding the lock of a project
+type LockInfo struct {
+	PID       int       `json:"pid"`
+	Hostname  string    `json:"hostname"`
+	Command   string    `json:"command"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// Stale tells whether the lock was left by a process that is no longer running on this host.
+// Locks taken on other hosts are never stale, as their processes cannot be checked.
+func (info LockInfo) Stale() bool {
+	hostname, err := os.Hostname()
+	if err != nil || hostname != info.Hostname {
+		return false
+	}
+	return !processAlive(info.PID)
+}
+
+// Lock is held by the command changing the project until it is released
+type Lock struct {
+	path string
+	// nested locks were taken while this process already held the lock, and releasing them keeps it
+	nested bool
+}
+
+// LockPath returns the path of the lock file of the project in confPath
+func LockPath(confPath string) (string, error) {
+	if filepath.IsAbs(confPath) {
+		return filepath.Join(confPath, LockFile), nil
+	}
+	wd, err := utils.GetWorkingDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(wd, confPath, LockFile), nil
+}
+
+// Acquire locks the project in confPath, so two commands cannot change azion.json at the same time.
+// A stale lock is taken over, and a lock already held by this process is shared with it, as commands
+// such as deploy run sync. Any other lock makes it fail with ErrorLocked.
+func Acquire(confPath, command string) (*Lock, error) {
+	lockPath, err := LockPath(confPath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Dir(lockPath)); errors.Is(err, fs.ErrNotExist) {
+		// the project was not initialized, so there is no azion.json to protect
+		logger.Debug("Config dir not found, skipping lock", zap.String("path", lockPath))
+		return &Lock{nested: true}, nil
+	}
+
+	hostname, _ := os.Hostname()
+	info := LockInfo{PID: os.Getpid(), Hostname: hostname, Command: command, StartedAt: time.Now()}
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, err = file.Write(data)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(lockPath)
+				return nil, fmt.Errorf(msg.ErrorCreatingLock, err)
+			}
+			logger.Debug("Lock acquired", zap.String("path", lockPath))
+			return &Lock{path: lockPath}, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf(msg.ErrorCreatingLock, err)
+		}
+
+		holder, err := ReadLock(confPath)
+		if err != nil {
+			return nil, err
+		}
+		if holder == nil {
+			// released in the meantime
+			continue
+		}
+		if holder.PID == info.PID && holder.Hostname == info.Hostname {
+			return &Lock{path: lockPath, nested: true}, nil
+		}
+		if !holder.Stale() {
+			return nil, lockedError(holder)
+		}
+		logger.Debug("Removing stale lock", zap.Int("pid", holder.PID), zap.String("command", holder.Command))
+		if err := os.Remove(lockPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf(msg.ErrorRemovingLock, err)
+		}
+	}
+
+	return nil, msg.ErrorLockContention
+}
+
+// Release removes the lock file, unless the lock is shared with a command that still holds it
+func (l *Lock) Release() {
+	if l == nil || l.nested {
+		return
+	}
+	if err := os.Remove(l.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logger.Debug("Error removing lock file", zap.String("path", l.path), zap.Error(err))
+	}
+}
+
+// ReadLock returns who holds the lock of the project in confPath, or nil when it is not locked.
+// A lock file that cannot be decoded is reported as held by an unknown process.
+func ReadLock(confPath string) (*LockInfo, error) {
+	lockPath, err := LockPath(confPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(lockPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf(msg.ErrorReadingLock, err)
+	}
+	info := &LockInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		logger.Debug("Error decoding lock file", zap.Error(err))
+		return &LockInfo{}, nil
+	}
+	return info, nil
+}
+
+// Unlock removes the lock of the project in confPath and returns who held it. Unless force is set,
+// only stale locks are removed.
+func Unlock(confPath string, force bool) (*LockInfo, error) {
+	holder, err := ReadLock(confPath)
+	if err != nil || holder == nil {
+		return nil, err
+	}
+	if !force && !holder.Stale() {
+		return holder, lockedError(holder)
+	}
+	lockPath, err := LockPath(confPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(lockPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf(msg.ErrorRemovingLock, err)
+	}
+	return holder, nil
+}
+
+func lockedError(holder *LockInfo) error {
+	return fmt.Errorf(msg.ErrorLocked, holder.Command, holder.PID, holder.Hostname, holder.StartedAt.Format(time.RFC3339))
+}
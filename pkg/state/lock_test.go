@@ -0,0 +1,102 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeLock(t *testing.T, dir string, info LockInfo) {
+	data, err := json.Marshal(info)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, LockFile), data, 0644))
+}
+
+func TestAcquire(t *testing.T) {
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+
+	t.Run("lock is created and released", func(t *testing.T) {
+		dir := t.TempDir()
+		lock, err := Acquire(dir, "config apply")
+		require.NoError(t, err)
+
+		holder, err := ReadLock(dir)
+		require.NoError(t, err)
+		require.Equal(t, os.Getpid(), holder.PID)
+		require.Equal(t, hostname, holder.Hostname)
+		require.Equal(t, "config apply", holder.Command)
+
+		lock.Release()
+		require.NoFileExists(t, filepath.Join(dir, LockFile))
+	})
+
+	t.Run("lock held by this process is shared", func(t *testing.T) {
+		dir := t.TempDir()
+		lock, err := Acquire(dir, "deploy")
+		require.NoError(t, err)
+		defer lock.Release()
+
+		nested, err := Acquire(dir, "sync")
+		require.NoError(t, err)
+		nested.Release()
+		require.FileExists(t, filepath.Join(dir, LockFile))
+	})
+
+	t.Run("lock held by another host is kept", func(t *testing.T) {
+		dir := t.TempDir()
+		writeLock(t, dir, LockInfo{PID: 42, Hostname: "ci-runner-2", Command: "deploy", StartedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)})
+
+		_, err := Acquire(dir, "deploy")
+		require.EqualError(t, err, "The project is locked by 'deploy' (PID 42 on ci-runner-2, started at 2026-01-02T03:04:05Z). Wait for it to finish or, if it is no longer running, run 'azion config unlock --force'")
+	})
+
+	t.Run("stale lock is taken over", func(t *testing.T) {
+		dir := t.TempDir()
+		writeLock(t, dir, LockInfo{PID: 1 << 30, Hostname: hostname, Command: "deploy", StartedAt: time.Now()})
+
+		lock, err := Acquire(dir, "sync")
+		require.NoError(t, err)
+		defer lock.Release()
+
+		holder, err := ReadLock(dir)
+		require.NoError(t, err)
+		require.Equal(t, "sync", holder.Command)
+	})
+
+	t.Run("missing config dir is not locked", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "azion")
+		lock, err := Acquire(dir, "deploy")
+		require.NoError(t, err)
+		lock.Release()
+		require.NoDirExists(t, dir)
+	})
+}
+
+func TestUnlock(t *testing.T) {
+	other := LockInfo{PID: 42, Hostname: "ci-runner-2", Command: "deploy", StartedAt: time.Now()}
+
+	t.Run("not locked", func(t *testing.T) {
+		holder, err := Unlock(t.TempDir(), false)
+		require.NoError(t, err)
+		require.Nil(t, holder)
+	})
+
+	t.Run("lock of another host requires force", func(t *testing.T) {
+		dir := t.TempDir()
+		writeLock(t, dir, other)
+
+		_, err := Unlock(dir, false)
+		require.Error(t, err)
+		require.FileExists(t, filepath.Join(dir, LockFile))
+
+		holder, err := Unlock(dir, true)
+		require.NoError(t, err)
+		require.Equal(t, 42, holder.PID)
+		require.NoFileExists(t, filepath.Join(dir, LockFile))
+	})
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package state
+
+import "os"
+
+// processAlive tells whether a process with the given PID is running
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	// on Windows, finding a process opens a handle to it, which fails when it is not running
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	process.Release()
+	return true
+}
@@ -0,0 +1,259 @@
+// Package state keeps the azion.json of a project, either in the local file or in an Azion Storage object
+// shared by everyone who deploys the project.
+package state
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	msg "github.com/aziontech/azion-cli/messages/state"
+	"github.com/aziontech/azion-cli/pkg/api/storage"
+	"github.com/aziontech/azion-cli/pkg/cmdutil"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	BackendLocal   = "local"
+	BackendStorage = "storage"
+)
+
+// Backend reads and writes the azion.json of a project. Its methods match the GetAzionJsonContent and
+// WriteAzionJsonContent functions injected in the commands.
+type Backend interface {
+	Read(confPath string) (*contracts.AzionApplicationOptions, error)
+	Write(conf *contracts.AzionApplicationOptions, confPath string) error
+}
+
+// LocalBackend keeps azion.json in the config dir of the project
+type LocalBackend struct {
+	GetAzionJsonContent   func(confPath string) (*contracts.AzionApplicationOptions, error)
+	WriteAzionJsonContent func(conf *contracts.AzionApplicationOptions, confPath string) error
+}
+
+func NewLocalBackend() *LocalBackend {
+	return &LocalBackend{
+		GetAzionJsonContent:   utils.GetAzionJsonContent,
+		WriteAzionJsonContent: utils.WriteAzionJsonContent,
+	}
+}
+
+func (b *LocalBackend) Read(confPath string) (*contracts.AzionApplicationOptions, error) {
+	return b.GetAzionJsonContent(confPath)
+}
+
+func (b *LocalBackend) Write(conf *contracts.AzionApplicationOptions, confPath string) error {
+	return b.WriteAzionJsonContent(conf, confPath)
+}
+
+// Store picks the backend of each project from the state settings of its local azion.json
+type Store struct {
+	Local      *LocalBackend
+	NewStorage func(settings contracts.AzionJsonState, name string) (Backend, error)
+	backends   map[string]Backend
+}
+
+func New(f *cmdutil.Factory) *Store {
+	store := &Store{
+		Local:    NewLocalBackend(),
+		backends: map[string]Backend{},
+	}
+	store.NewStorage = func(settings contracts.AzionJsonState, name string) (Backend, error) {
+		return NewStorageBackend(f, store.Local, settings, name)
+	}
+	return store
+}
+
+func (s *Store) Read(confPath string) (*contracts.AzionApplicationOptions, error) {
+	backend, err := s.backend(confPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	return backend.Read(confPath)
+}
+
+func (s *Store) Write(conf *contracts.AzionApplicationOptions, confPath string) error {
+	backend, err := s.backend(confPath, conf)
+	if err != nil {
+		return err
+	}
+	return backend.Write(conf, confPath)
+}
+
+// backend returns the backend of the project in confPath, chosen by the state settings of conf or, when it is
+// not given, of the local azion.json. It is kept for the next calls, since the storage backend tracks the
+// revision it read.
+func (s *Store) backend(confPath string, conf *contracts.AzionApplicationOptions) (Backend, error) {
+	if backend, ok := s.backends[confPath]; ok {
+		return backend, nil
+	}
+
+	if conf == nil {
+		local, err := s.Local.Read(confPath)
+		if err != nil {
+			// the local backend reports the missing or invalid file when reading it
+			return s.Local, nil
+		}
+		conf = local
+	}
+
+	var backend Backend = s.Local
+	if conf.State != nil {
+		switch conf.State.Backend {
+		case "", BackendLocal:
+		case BackendStorage:
+			storageBackend, err := s.NewStorage(*conf.State, conf.Name)
+			if err != nil {
+				return nil, err
+			}
+			backend = storageBackend
+		default:
+			return nil, fmt.Errorf(msg.ErrorUnknownBackend, conf.State.Backend)
+		}
+	}
+
+	s.backends[confPath] = backend
+	return backend, nil
+}
+
+type storageClient interface {
+	GetObject(ctx context.Context, bucketName, objectKey string) ([]byte, error)
+	CreateObject(ctx context.Context, fileOps *contracts.FileOps, bucketName, objectKey string) error
+}
+
+// StorageBackend keeps azion.json in an Azion Storage object. The local file is still written as a copy, so
+// the commands reading it directly see the shared state.
+//
+// Writes use optimistic locking: the object is read again before it is replaced, and the write is rejected
+// with ErrorStateConflict when it changed since this backend last read or wrote it. The storage API has no
+// conditional write, so two writers checking the object at the same moment may still both replace it, the last
+// one winning. The check only narrows that window to the time between reading the object and uploading it.
+type StorageBackend struct {
+	Bucket string
+	Key    string
+	Client storageClient
+	Local  *LocalBackend
+	// revision is the checksum of the object as last seen, empty while it does not exist
+	revision string
+}
+
+func NewStorageBackend(f *cmdutil.Factory, local *LocalBackend, settings contracts.AzionJsonState, name string) (*StorageBackend, error) {
+	if settings.Bucket == "" {
+		return nil, msg.ErrorStateBucketRequired
+	}
+	key := settings.Key
+	if key == "" {
+		key = fmt.Sprintf("%s/azion.json", name)
+	}
+	return &StorageBackend{
+		Bucket: settings.Bucket,
+		Key:    key,
+		Client: storage.NewClient(f.HttpClient, f.Config.GetString("storage_url"), f.Config.GetString("token")),
+		Local:  local,
+	}, nil
+}
+
+// Read returns the shared azion.json. While the object was not created yet, the local file is returned and
+// becomes the shared state on the first write.
+func (b *StorageBackend) Read(confPath string) (*contracts.AzionApplicationOptions, error) {
+	local, err := b.Local.Read(confPath)
+	if err != nil {
+		return nil, err
+	}
+
+	data, found, err := b.fetch()
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		logger.Debug("State not found in the bucket, using the local azion.json", zap.String("bucket", b.Bucket), zap.String("key", b.Key))
+		b.revision = ""
+		return local, nil
+	}
+
+	conf := &contracts.AzionApplicationOptions{}
+	if err := json.Unmarshal(data, conf); err != nil {
+		logger.Debug("Error unmarshalling the azion.json kept in the bucket", zap.Error(err))
+		return nil, utils.ErrorUnmarshalAzionJsonFile
+	}
+	// where the state is kept is always decided by the local file
+	conf.State = local.State
+	b.revision = checksum(data)
+
+	if err := b.Local.Write(conf, confPath); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+func (b *StorageBackend) Write(conf *contracts.AzionApplicationOptions, confPath string) error {
+	current, found, err := b.fetch()
+	if err != nil {
+		return err
+	}
+	revision := ""
+	if found {
+		revision = checksum(current)
+	}
+	if revision != b.revision {
+		logger.Debug("State changed since it was read", zap.String("expected", b.revision), zap.String("found", revision))
+		return msg.ErrorStateConflict
+	}
+
+	data, err := json.MarshalIndent(conf, "", "  ")
+	if err != nil {
+		logger.Debug("Error marshalling response", zap.Error(err))
+		return utils.ErrorMarshalAzionJsonFile
+	}
+	if err := b.upload(data); err != nil {
+		return fmt.Errorf(msg.ErrorWritingState, err)
+	}
+	b.revision = checksum(data)
+
+	return b.Local.Write(conf, confPath)
+}
+
+// fetch downloads the object, reporting whether it exists
+func (b *StorageBackend) fetch() ([]byte, bool, error) {
+	data, err := b.Client.GetObject(context.Background(), b.Bucket, b.Key)
+	if err != nil {
+		if errors.Is(err, utils.ErrorNotFound404) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf(msg.ErrorReadingState, err)
+	}
+	return data, true, nil
+}
+
+// upload replaces the object, going through a temporary file as the storage client sends files
+func (b *StorageBackend) upload(data []byte) error {
+	file, err := os.CreateTemp("", "azion-state-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	fileOps := &contracts.FileOps{Path: b.Key, MimeType: "application/json", FileContent: file}
+	return b.Client.CreateObject(context.Background(), fileOps, b.Bucket, b.Key)
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
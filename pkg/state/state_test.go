@@ -0,0 +1,178 @@
+package state
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	msg "github.com/aziontech/azion-cli/messages/state"
+	"github.com/aziontech/azion-cli/pkg/contracts"
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"github.com/aziontech/azion-cli/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	logger.New(zapcore.DebugLevel)
+}
+
+type fakeStorage struct {
+	objects map[string][]byte
+}
+
+func (s *fakeStorage) GetObject(ctx context.Context, bucketName, objectKey string) ([]byte, error) {
+	data, ok := s.objects[bucketName+"/"+objectKey]
+	if !ok {
+		return nil, utils.ErrorNotFound404
+	}
+	return data, nil
+}
+
+func (s *fakeStorage) CreateObject(ctx context.Context, fileOps *contracts.FileOps, bucketName, objectKey string) error {
+	data, err := io.ReadAll(fileOps.FileContent)
+	if err != nil {
+		return err
+	}
+	s.objects[bucketName+"/"+objectKey] = data
+	return nil
+}
+
+// memoryLocal is a local backend keeping azion.json in memory
+func memoryLocal(files map[string]*contracts.AzionApplicationOptions) *LocalBackend {
+	return &LocalBackend{
+		GetAzionJsonContent: func(confPath string) (*contracts.AzionApplicationOptions, error) {
+			conf, ok := files[confPath]
+			if !ok {
+				return nil, utils.ErrorOpeningAzionJsonFile
+			}
+			copied := *conf
+			return &copied, nil
+		},
+		WriteAzionJsonContent: func(conf *contracts.AzionApplicationOptions, confPath string) error {
+			copied := *conf
+			files[confPath] = &copied
+			return nil
+		},
+	}
+}
+
+func TestStorageBackend(t *testing.T) {
+	settings := &contracts.AzionJsonState{Backend: BackendStorage, Bucket: "team-state"}
+
+	newBackend := func(remote *fakeStorage, files map[string]*contracts.AzionApplicationOptions) *StorageBackend {
+		return &StorageBackend{Bucket: "team-state", Key: "project/azion.json", Client: remote, Local: memoryLocal(files)}
+	}
+
+	t.Run("local file becomes the shared state", func(t *testing.T) {
+		remote := &fakeStorage{objects: map[string][]byte{}}
+		files := map[string]*contracts.AzionApplicationOptions{"azion": {Name: "project", State: settings}}
+		backend := newBackend(remote, files)
+
+		conf, err := backend.Read("azion")
+		require.NoError(t, err)
+		require.Equal(t, "project", conf.Name)
+
+		conf.Bucket = "project-bucket"
+		require.NoError(t, backend.Write(conf, "azion"))
+		require.Contains(t, string(remote.objects["team-state/project/azion.json"]), `"bucket": "project-bucket"`)
+
+		// writing again keeps working, as the backend knows the revision it wrote
+		conf.Prefix = "20260101"
+		require.NoError(t, backend.Write(conf, "azion"))
+	})
+
+	t.Run("shared state replaces the local copy", func(t *testing.T) {
+		remote := &fakeStorage{objects: map[string][]byte{
+			"team-state/project/azion.json": []byte(`{"name": "project", "bucket": "shared-bucket"}`),
+		}}
+		files := map[string]*contracts.AzionApplicationOptions{"azion": {Name: "project", State: settings}}
+
+		conf, err := newBackend(remote, files).Read("azion")
+		require.NoError(t, err)
+		require.Equal(t, "shared-bucket", conf.Bucket)
+		require.Equal(t, settings, conf.State)
+		require.Equal(t, "shared-bucket", files["azion"].Bucket)
+	})
+
+	t.Run("concurrent writes are rejected", func(t *testing.T) {
+		remote := &fakeStorage{objects: map[string][]byte{
+			"team-state/project/azion.json": []byte(`{"name": "project"}`),
+		}}
+		first := newBackend(remote, map[string]*contracts.AzionApplicationOptions{"azion": {Name: "project", State: settings}})
+		second := newBackend(remote, map[string]*contracts.AzionApplicationOptions{"azion": {Name: "project", State: settings}})
+
+		firstConf, err := first.Read("azion")
+		require.NoError(t, err)
+		secondConf, err := second.Read("azion")
+		require.NoError(t, err)
+
+		firstConf.Bucket = "first"
+		require.NoError(t, first.Write(firstConf, "azion"))
+
+		secondConf.Bucket = "second"
+		require.ErrorIs(t, second.Write(secondConf, "azion"), msg.ErrorStateConflict)
+		require.Contains(t, string(remote.objects["team-state/project/azion.json"]), `"bucket": "first"`)
+	})
+
+	t.Run("state created by someone else is not replaced", func(t *testing.T) {
+		remote := &fakeStorage{objects: map[string][]byte{}}
+		backend := newBackend(remote, map[string]*contracts.AzionApplicationOptions{"azion": {Name: "project", State: settings}})
+
+		conf, err := backend.Read("azion")
+		require.NoError(t, err)
+		remote.objects["team-state/project/azion.json"] = []byte(`{"name": "project", "bucket": "other"}`)
+
+		require.ErrorIs(t, backend.Write(conf, "azion"), msg.ErrorStateConflict)
+	})
+}
+
+func TestStore(t *testing.T) {
+	t.Run("local backend without state settings", func(t *testing.T) {
+		files := map[string]*contracts.AzionApplicationOptions{"azion": {Name: "project"}}
+		store := &Store{Local: memoryLocal(files), backends: map[string]Backend{}}
+		store.NewStorage = func(settings contracts.AzionJsonState, name string) (Backend, error) {
+			t.Fatal("storage backend must not be used")
+			return nil, nil
+		}
+
+		conf, err := store.Read("azion")
+		require.NoError(t, err)
+		conf.Bucket = "bucket"
+		require.NoError(t, store.Write(conf, "azion"))
+		require.Equal(t, "bucket", files["azion"].Bucket)
+	})
+
+	t.Run("storage backend from the state settings", func(t *testing.T) {
+		files := map[string]*contracts.AzionApplicationOptions{
+			"azion": {Name: "project", State: &contracts.AzionJsonState{Backend: BackendStorage, Bucket: "team-state"}},
+		}
+		remote := &fakeStorage{objects: map[string][]byte{}}
+		store := &Store{Local: memoryLocal(files), backends: map[string]Backend{}}
+		store.NewStorage = func(settings contracts.AzionJsonState, name string) (Backend, error) {
+			return &StorageBackend{Bucket: settings.Bucket, Key: name + "/azion.json", Client: remote, Local: store.Local}, nil
+		}
+
+		conf, err := store.Read("azion")
+		require.NoError(t, err)
+		require.NoError(t, store.Write(conf, "azion"))
+		require.Contains(t, remote.objects, "team-state/project/azion.json")
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		files := map[string]*contracts.AzionApplicationOptions{
+			"azion": {Name: "project", State: &contracts.AzionJsonState{Backend: "s3"}},
+		}
+		store := &Store{Local: memoryLocal(files), backends: map[string]Backend{}}
+
+		_, err := store.Read("azion")
+		require.EqualError(t, err, "Unknown state backend 's3'. Use 'local' or 'storage'")
+	})
+
+	t.Run("missing azion.json is reported by the local backend", func(t *testing.T) {
+		store := &Store{Local: memoryLocal(map[string]*contracts.AzionApplicationOptions{}), backends: map[string]Backend{}}
+
+		_, err := store.Read("azion")
+		require.ErrorIs(t, err, utils.ErrorOpeningAzionJsonFile)
+	})
+}
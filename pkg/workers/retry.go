@@ -0,0 +1,75 @@
+package workers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	MaxRetries        = 20
+	InitialBackoff    = 1 * time.Second
+	MaxBackoff        = 30 * time.Second
+	BackoffMultiplier = 1.5
+	RateLimitBackoff  = 5 * time.Second
+)
+
+// sleep is replaced in tests to avoid waiting for the backoff
+var sleep = time.Sleep
+
+// IsRateLimitError checks if the error is due to rate limiting (HTTP 429)
+func IsRateLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	// Check for various rate limit error patterns
+	errStr := err.Error()
+	return strings.Contains(errStr, "429") ||
+		strings.Contains(errStr, "rate limit") ||
+		strings.Contains(errStr, "too many requests") ||
+		strings.Contains(errStr, "throttl")
+}
+
+// RetryDelay calculates the delay for retry with exponential backoff
+// For rate limit errors, uses a longer initial delay
+func RetryDelay(retryCount int, isRateLimit bool) time.Duration {
+	delay := InitialBackoff
+	if isRateLimit {
+		delay = RateLimitBackoff
+	}
+
+	for i := 0; i < retryCount; i++ {
+		delay = time.Duration(float64(delay) * BackoffMultiplier)
+		if delay > MaxBackoff {
+			delay = MaxBackoff
+			break
+		}
+	}
+	return delay
+}
+
+// RetryRateLimited runs call again with backoff while it fails due to rate limiting, up to MaxRetries times
+// Any other error is returned right away, so it is safe to use with requests that are not idempotent
+func RetryRateLimited(call func() error) error {
+	var err error
+	for retryCount := 0; retryCount < MaxRetries; retryCount++ {
+		if retryCount > 0 {
+			delay := RetryDelay(retryCount, true)
+			logger.Debug("Rate limit detected, waiting before retry",
+				zap.Duration("delay", delay),
+				zap.Int("retry", retryCount),
+				zap.Error(err))
+			sleep(delay)
+		}
+
+		err = call()
+		if !IsRateLimitError(err) {
+			return err
+		}
+	}
+
+	logger.Debug("Max retries reached", zap.Int("total_retries", MaxRetries), zap.Error(err))
+	return err
+}
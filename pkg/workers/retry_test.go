@@ -0,0 +1,83 @@
+package workers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aziontech/azion-cli/pkg/logger"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRetryDelay(t *testing.T) {
+	if got := RetryDelay(0, false); got != InitialBackoff {
+		t.Errorf("RetryDelay(0, false) = %s, expected %s", got, InitialBackoff)
+	}
+	if got := RetryDelay(0, true); got != RateLimitBackoff {
+		t.Errorf("RetryDelay(0, true) = %s, expected %s", got, RateLimitBackoff)
+	}
+	if got := RetryDelay(1, false); got != 1500*time.Millisecond {
+		t.Errorf("RetryDelay(1, false) = %s, expected %s", got, 1500*time.Millisecond)
+	}
+	if got := RetryDelay(MaxRetries, true); got != MaxBackoff {
+		t.Errorf("RetryDelay(%d, true) = %s, expected %s", MaxRetries, got, MaxBackoff)
+	}
+}
+
+func TestRetryRateLimited(t *testing.T) {
+	logger.New(zapcore.DebugLevel)
+	sleep = func(time.Duration) {}
+	defer func() { sleep = time.Sleep }()
+
+	tests := []struct {
+		name          string
+		errs          []error
+		expectedCalls int
+		expectedErr   bool
+	}{
+		{
+			name:          "succeeds at first",
+			errs:          []error{nil},
+			expectedCalls: 1,
+		},
+		{
+			name:          "retries while rate limited",
+			errs:          []error{errors.New("429 Too Many Requests"), errors.New("rate limit exceeded"), nil},
+			expectedCalls: 3,
+		},
+		{
+			name:          "does not retry other errors",
+			errs:          []error{errors.New("name already in use")},
+			expectedCalls: 1,
+			expectedErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := RetryRateLimited(func() error {
+				err := tt.errs[calls]
+				calls++
+				return err
+			})
+			if calls != tt.expectedCalls {
+				t.Errorf("expected %d calls, got %d", tt.expectedCalls, calls)
+			}
+			if (err != nil) != tt.expectedErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		calls := 0
+		err := RetryRateLimited(func() error {
+			calls++
+			return errors.New("throttled")
+		})
+		if err == nil || calls != MaxRetries {
+			t.Errorf("expected an error after %d calls, got %v after %d calls", MaxRetries, err, calls)
+		}
+	})
+}